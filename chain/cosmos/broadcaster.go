@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"path"
 	"testing"
 	"time"
 
@@ -16,7 +15,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
-	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 )
 
@@ -105,9 +103,7 @@ func (b *Broadcaster) GetClientContext(ctx context.Context, user User) (client.C
 
 	_, ok := b.keyrings[user]
 	if !ok {
-		localDir := b.t.TempDir()
-		containerKeyringDir := path.Join(cn.HomeDir(), "keyring-test")
-		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID())
+		kr, err := cn.localKeyring(ctx, b.t.TempDir())
 		if err != nil {
 			return client.Context{}, err
 		}
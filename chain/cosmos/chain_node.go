@@ -23,13 +23,16 @@ import (
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"go.uber.org/zap"
@@ -38,6 +41,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/runner"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 )
 
@@ -61,6 +65,9 @@ type ChainNode struct {
 
 	containerLifecycle *dockerutil.ContainerLifecycle
 
+	// native runs the node as a host process if the chain uses the native runtime.
+	native *runner.Native
+
 	// Ports set during StartContainer.
 	hostRPCPort  string
 	hostAPIPort  string
@@ -97,6 +104,7 @@ const (
 	grpcPort    = "9090/tcp"
 	apiPort     = "1317/tcp"
 	privValPort = "1234/tcp"
+	grpcWebPort = "9091/tcp"
 )
 
 var (
@@ -107,6 +115,9 @@ var (
 		nat.Port(apiPort):     {},
 		nat.Port(privValPort): {},
 	}
+
+	// nativePorts are allocated on the host for each node of the native runtime.
+	nativePorts = []string{p2pPort, rpcPort, grpcPort, apiPort, privValPort, grpcWebPort}
 )
 
 // NewClient creates and assigns a new Tendermint RPC client to the ChainNode
@@ -139,28 +150,8 @@ func (tn *ChainNode) NewSidecarProcess(
 ) error {
 	s := NewSidecar(tn.log, true, preStart, tn.Chain, cli, networkID, processName, tn.TestName, image, homeDir, tn.Index, ports, startCmd)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   tn.TestName,
-			dockerutil.NodeOwnerLabel: s.Name(),
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("creating volume for sidecar process: %w", err)
-	}
-	s.VolumeName = v.Name
-
-	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-		Log: tn.log,
-
-		Client: cli,
-
-		VolumeName: v.Name,
-		ImageRef:   image.Ref(),
-		TestName:   tn.TestName,
-		UidGid:     image.UidGid,
-	}); err != nil {
-		return fmt.Errorf("set volume owner: %w", err)
+	if err := s.createHome(ctx); err != nil {
+		return err
 	}
 
 	tn.Sidecars = append(tn.Sidecars, s)
@@ -195,7 +186,27 @@ func (tn *ChainNode) Name() string {
 }
 
 func (tn *ChainNode) ContainerID() string {
-	return tn.containerLifecycle.ContainerID()
+	return tn.runner().ID()
+}
+
+// runner returns the runtime of the node, which is docker unless the chain uses the native runtime.
+func (tn *ChainNode) runner() runner.Runner {
+	if tn.native != nil {
+		return tn.native
+	}
+	return &runner.Docker{
+		Log:        tn.logger(),
+		Client:     tn.DockerClient,
+		NetworkID:  tn.NetworkID,
+		TestName:   tn.TestName,
+		Image:      tn.Image,
+		VolumeName: tn.VolumeName,
+		Home:       tn.HomeDir(),
+		HostName:   tn.HostName(),
+		Ports:      sentryPorts,
+		Resources:  tn.Chain.Config().Resources,
+		Lifecycle:  tn.containerLifecycle,
+	}
 }
 
 // hostname of the test node container
@@ -221,21 +232,28 @@ func (tn *ChainNode) OverwriteGenesisFile(ctx context.Context, content []byte) e
 	return nil
 }
 
-func (tn *ChainNode) copyGentx(ctx context.Context, destVal *ChainNode) error {
-	nid, err := tn.NodeID(ctx)
-	if err != nil {
-		return fmt.Errorf("getting node ID: %w", err)
+// copyGentxs copies the gentx of each of vals into the gentx directory of tn,
+// reading and writing them in one batch.
+func (tn *ChainNode) copyGentxs(ctx context.Context, vals ChainNodes) error {
+	reads := make([]runner.File, len(vals))
+	for i, v := range vals {
+		nid, err := v.NodeID(ctx)
+		if err != nil {
+			return fmt.Errorf("getting node ID: %w", err)
+		}
+		reads[i] = runner.File{Runner: v.runner(), Path: fmt.Sprintf("config/gentx/gentx-%s.json", nid)}
 	}
 
-	relPath := fmt.Sprintf("config/gentx/gentx-%s.json", nid)
-
-	gentx, err := tn.ReadFile(ctx, relPath)
+	gentxs, err := runner.ReadAll(ctx, reads)
 	if err != nil {
 		return fmt.Errorf("getting gentx content: %w", err)
 	}
 
-	err = destVal.WriteFile(ctx, gentx, relPath)
-	if err != nil {
+	files := make(map[string][]byte, len(reads))
+	for i, r := range reads {
+		files[r.Path] = gentxs[i]
+	}
+	if err := tn.runner().WriteFiles(ctx, files); err != nil {
 		return fmt.Errorf("overwriting gentx: %w", err)
 	}
 
@@ -259,11 +277,16 @@ func (tn *ChainNode) Bind() []string {
 }
 
 func (tn *ChainNode) HomeDir() string {
+	if tn.native != nil {
+		return tn.native.HomeDir()
+	}
 	return path.Join("/var/cosmos-chain", tn.Chain.Config().Name)
 }
 
 // SetTestConfig modifies the config to reasonable values for use within interchaintest.
 func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
+	r := tn.runner()
+
 	c := make(testutil.Toml)
 
 	// Set Log Level to info
@@ -288,20 +311,18 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	rpc := make(testutil.Toml)
 
 	// Enable public RPC
-	rpc["laddr"] = "tcp://0.0.0.0:26657"
+	rpc["laddr"] = "tcp://" + r.ListenAddress(rpcPort)
 	rpc["allowed_origins"] = []string{"*"}
 
 	c["rpc"] = rpc
 
-	if err := testutil.ModifyTomlConfigFile(
-		ctx,
-		tn.logger(),
-		tn.DockerClient,
-		tn.TestName,
-		tn.VolumeName,
-		"config/config.toml",
-		c,
-	); err != nil {
+	if tn.native != nil {
+		// Host processes share the host network, so every listener of every node needs its own port.
+		p2p["laddr"] = "tcp://" + r.ListenAddress(p2pPort)
+		rpc["pprof_laddr"] = ""
+	}
+
+	if err := tn.ModifyTomlConfigFile(ctx, "config/config.toml", c); err != nil {
 		return err
 	}
 
@@ -311,28 +332,26 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	grpc := make(testutil.Toml)
 
 	// Enable public GRPC
-	grpc["address"] = "0.0.0.0:9090"
+	grpc["address"] = r.ListenAddress(grpcPort)
 
 	a["grpc"] = grpc
 
+	if tn.native != nil {
+		grpcWeb := make(testutil.Toml)
+		grpcWeb["address"] = r.ListenAddress(grpcWebPort)
+		a["grpc-web"] = grpcWeb
+	}
+
 	api := make(testutil.Toml)
 
 	// Enable public REST API
 	api["enable"] = true
 	api["swagger"] = true
-	api["address"] = "tcp://0.0.0.0:1317"
+	api["address"] = "tcp://" + r.ListenAddress(apiPort)
 
 	a["api"] = api
 
-	return testutil.ModifyTomlConfigFile(
-		ctx,
-		tn.logger(),
-		tn.DockerClient,
-		tn.TestName,
-		tn.VolumeName,
-		"config/app.toml",
-		a,
-	)
+	return tn.ModifyTomlConfigFile(ctx, "config/app.toml", a)
 }
 
 // SetPeers modifies the config persistent_peers for a node
@@ -344,15 +363,25 @@ func (tn *ChainNode) SetPeers(ctx context.Context, peers string) error {
 	p2p["persistent_peers"] = peers
 	c["p2p"] = p2p
 
-	return testutil.ModifyTomlConfigFile(
-		ctx,
-		tn.logger(),
-		tn.DockerClient,
-		tn.TestName,
-		tn.VolumeName,
-		"config/config.toml",
-		c,
-	)
+	return tn.ModifyTomlConfigFile(ctx, "config/config.toml", c)
+}
+
+// ModifyTomlConfigFile modifies a toml config file, such as config/config.toml, relative to the node home directory.
+func (tn *ChainNode) ModifyTomlConfigFile(ctx context.Context, relPath string, modifications testutil.Toml) error {
+	config, err := tn.ReadFile(ctx, relPath)
+	if err != nil {
+		return err
+	}
+
+	config, err = testutil.ModifyToml(config, modifications)
+	if err != nil {
+		return fmt.Errorf("failed to modify %s: %w", relPath, err)
+	}
+
+	if err := tn.WriteFile(ctx, config, relPath); err != nil {
+		return fmt.Errorf("overwriting %s: %w", relPath, err)
+	}
+	return nil
 }
 
 func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
@@ -461,6 +490,80 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	return txs, nil
 }
 
+// FindBlockHeader implements blockdb.BlockHeaderFinder.
+func (tn *ChainNode) FindBlockHeader(ctx context.Context, height uint64) (blockdb.BlockHeader, error) {
+	h := int64(height)
+	var eg errgroup.Group
+	var blockRes *coretypes.ResultBlockResults
+	var block *coretypes.ResultBlock
+	eg.Go(func() (err error) {
+		blockRes, err = tn.Client.BlockResults(ctx, &h)
+		return err
+	})
+	eg.Go(func() (err error) {
+		block, err = tn.Client.Block(ctx, &h)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return blockdb.BlockHeader{}, err
+	}
+
+	header := blockdb.BlockHeader{
+		Time:            block.Block.Time,
+		ProposerAddress: block.Block.ProposerAddress.String(),
+		AppHash:         block.Block.AppHash.String(),
+	}
+	for _, res := range blockRes.TxsResults {
+		header.GasUsed += res.GasUsed
+	}
+
+	if block.Block.LastCommit == nil || len(block.Block.LastCommit.Signatures) == 0 {
+		// The first block has no last commit.
+		return header, nil
+	}
+
+	// Absent signatures do not carry the validator address,
+	// but the signatures are in the order of the validator set of the previous height.
+	vals, err := tn.validatorAddresses(ctx, h-1)
+	if err != nil {
+		return blockdb.BlockHeader{}, fmt.Errorf("validators at height %d: %w", h-1, err)
+	}
+	for i, sig := range block.Block.LastCommit.Signatures {
+		addr := sig.ValidatorAddress.String()
+		if addr == "" && i < len(vals) {
+			addr = vals[i]
+		}
+		if addr == "" {
+			continue
+		}
+		header.Signatures = append(header.Signatures, blockdb.CommitSig{
+			ValidatorAddress: addr,
+			Signed:           sig.BlockIDFlag == tmtypes.BlockIDFlagCommit,
+		})
+	}
+	return header, nil
+}
+
+// validatorAddresses returns the hex-encoded addresses of the validator set at height, in order.
+func (tn *ChainNode) validatorAddresses(ctx context.Context, height int64) ([]string, error) {
+	var (
+		addrs   []string
+		perPage = 100
+	)
+	for page := 1; ; page++ {
+		res, err := tn.Client.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range res.Validators {
+			addrs = append(addrs, v.Address.String())
+		}
+		if len(addrs) >= res.Total || len(res.Validators) == 0 {
+			return addrs, nil
+		}
+	}
+}
+
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
 // with the chain node binary.
 func (tn *ChainNode) TxCommand(keyName string, command ...string) []string {
@@ -520,7 +623,7 @@ func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...stri
 func (tn *ChainNode) NodeCommand(command ...string) []string {
 	command = tn.BinCommand(command...)
 	return append(command,
-		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--node", "tcp://"+tn.runner().Address(rpcPort),
 	)
 }
 
@@ -604,8 +707,7 @@ func (tn *ChainNode) InitHomeFolder(ctx context.Context) error {
 // the docker filesystem. relPath describes the location of the file in the
 // docker volume relative to the home directory
 func (tn *ChainNode) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
-	return fw.WriteFile(ctx, tn.VolumeName, relPath, content)
+	return tn.runner().WriteFile(ctx, relPath, content)
 }
 
 // CopyFile adds a file from the host filesystem to the docker filesystem
@@ -622,8 +724,7 @@ func (tn *ChainNode) CopyFile(ctx context.Context, srcPath, dstPath string) erro
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
-	gen, err := fr.SingleFileContent(ctx, tn.VolumeName, relPath)
+	gen, err := tn.runner().ReadFile(ctx, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
 	}
@@ -958,9 +1059,8 @@ func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop Tx
 	if err != nil {
 		return "", err
 	}
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
-	if err := fw.WriteFile(ctx, tn.VolumeName, file, propJson); err != nil {
-		return "", fmt.Errorf("writing contract file to docker volume: %w", err)
+	if err := tn.WriteFile(ctx, propJson, file); err != nil {
+		return "", fmt.Errorf("writing proposal file: %w", err)
 	}
 
 	command := []string{
@@ -1084,18 +1184,18 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 	chainCfg := tn.Chain.Config()
 
 	var cmd []string
-	if chainCfg.NoHostMount {
+	if chainCfg.NoHostMount && tn.native == nil {
 		cmd = []string{"sh", "-c", fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt --x-crisis-skip-assert-invariants", tn.HomeDir(), tn.HomeDir(), chainCfg.Bin, tn.HomeDir())}
 	} else {
 		cmd = []string{chainCfg.Bin, "start", "--home", tn.HomeDir(), "--x-crisis-skip-assert-invariants"}
 	}
 
-	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, sentryPorts, tn.Bind(), tn.HostName(), cmd)
+	return tn.runner().Create(ctx, cmd)
 }
 
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	for _, s := range tn.Sidecars {
-		err := s.containerLifecycle.Running(ctx)
+		err := s.runner().Running(ctx)
 
 		if s.preStart && err != nil {
 			if err := s.CreateContainer(ctx); err != nil {
@@ -1108,12 +1208,13 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 		}
 	}
 
-	if err := tn.containerLifecycle.StartContainer(ctx); err != nil {
+	r := tn.runner()
+	if err := r.Start(ctx); err != nil {
 		return err
 	}
 
 	// Set the host ports once since they will not change after the container has started.
-	hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, rpcPort, grpcPort, apiPort)
+	hostPorts, err := r.HostPorts(ctx, rpcPort, grpcPort, apiPort)
 	if err != nil {
 		return err
 	}
@@ -1145,7 +1246,7 @@ func (tn *ChainNode) PauseContainer(ctx context.Context) error {
 			return err
 		}
 	}
-	return tn.containerLifecycle.PauseContainer(ctx)
+	return tn.runner().Pause(ctx)
 }
 
 func (tn *ChainNode) UnpauseContainer(ctx context.Context) error {
@@ -1154,7 +1255,7 @@ func (tn *ChainNode) UnpauseContainer(ctx context.Context) error {
 			return err
 		}
 	}
-	return tn.containerLifecycle.UnpauseContainer(ctx)
+	return tn.runner().Unpause(ctx)
 }
 
 func (tn *ChainNode) StopContainer(ctx context.Context) error {
@@ -1163,7 +1264,7 @@ func (tn *ChainNode) StopContainer(ctx context.Context) error {
 			return err
 		}
 	}
-	return tn.containerLifecycle.StopContainer(ctx)
+	return tn.runner().Stop(ctx)
 }
 
 func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
@@ -1172,7 +1273,7 @@ func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
 			return err
 		}
 	}
-	return tn.containerLifecycle.RemoveContainer(ctx)
+	return tn.runner().Remove(ctx)
 }
 
 // InitValidatorFiles creates the node files and signs a genesis transaction
@@ -1256,10 +1357,10 @@ func (nodes ChainNodes) PeerString(ctx context.Context) string {
 			// When would NodeId return an error?
 			break
 		}
-		hostName := n.HostName()
-		ps := fmt.Sprintf("%s@%s:26656", id, hostName)
+		addr := n.runner().Address(p2pPort)
+		ps := fmt.Sprintf("%s@%s", id, addr)
 		nodes.logger().Info("Peering",
-			zap.String("host_name", hostName),
+			zap.String("address", addr),
 			zap.String("peer", ps),
 			zap.String("container", n.Name()),
 		)
@@ -1268,15 +1369,31 @@ func (nodes ChainNodes) PeerString(ctx context.Context) string {
 	return strings.Join(addrs, ",")
 }
 
+// OverwriteGenesisFile writes content to the genesis file of every node in one batch.
+func (nodes ChainNodes) OverwriteGenesisFile(ctx context.Context, content []byte) error {
+	files := make([]runner.File, len(nodes))
+	for i, n := range nodes {
+		files[i] = runner.File{Runner: n.runner(), Path: "config/genesis.json", Content: content}
+	}
+	if err := runner.WriteAll(ctx, files); err != nil {
+		return fmt.Errorf("overwriting genesis.json: %w", err)
+	}
+	return nil
+}
+
 // LogGenesisHashes logs the genesis hashes for the various nodes
 func (nodes ChainNodes) LogGenesisHashes(ctx context.Context) error {
-	for _, n := range nodes {
-		gen, err := n.GenesisFileContent(ctx)
-		if err != nil {
-			return err
-		}
+	files := make([]runner.File, len(nodes))
+	for i, n := range nodes {
+		files[i] = runner.File{Runner: n.runner(), Path: "config/genesis.json"}
+	}
+	gens, err := runner.ReadAll(ctx, files)
+	if err != nil {
+		return fmt.Errorf("getting genesis.json content: %w", err)
+	}
 
-		n.logger().Info("Genesis", zap.String("hash", fmt.Sprintf("%X", sha256.Sum256(gen))))
+	for i, n := range nodes {
+		n.logger().Info("Genesis", zap.String("hash", fmt.Sprintf("%X", sha256.Sum256(gens[i]))))
 	}
 	return nil
 }
@@ -1289,15 +1406,23 @@ func (nodes ChainNodes) logger() *zap.Logger {
 }
 
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
-	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: tn.Bind(),
-	}
-	res := job.Run(ctx, cmd, opts)
+	res := tn.runner().Exec(ctx, cmd, env)
+	res.TrackChainExec(ibc.ChainExecReporterOf(tn.Chain), env)
 	return res.Stdout, res.Stderr, res.Err
 }
 
+// localKeyring returns the test keyring of the node, copied to localDir unless the node runs on the host.
+func (tn *ChainNode) localKeyring(ctx context.Context, localDir string) (keyring.Keyring, error) {
+	if tn.native == nil {
+		containerKeyringDir := path.Join(tn.HomeDir(), "keyring-test")
+		return dockerutil.NewLocalKeyringFromDockerContainer(ctx, tn.DockerClient, localDir, containerKeyringDir, tn.ContainerID())
+	}
+
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	return keyring.New("", keyring.BackendTest, tn.HomeDir(), os.Stdin, codec.NewProtoCodec(registry))
+}
+
 func (tn *ChainNode) logger() *zap.Logger {
 	return tn.log.With(
 		zap.String("chain_id", tn.Chain.Config().ChainID),
@@ -1332,12 +1457,26 @@ func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string)
 	return strings.TrimSpace(parts[1]), nil
 }
 
-// SendICABankTransfer builds a bank transfer message for a specified address and sends it to the specified
-// interchain account.
+// SendICABankTransfer sends amount from the interchain account owned by fromAddr on the counterparty chain of connectionID
+// to amount.Address, with a bank transfer message submitted by fromAddr through the intertx module.
+//
+// fromAddr is the owner of the interchain account on this controller chain, not the sender on the host chain:
+// the interchain account controlled by fromAddr is queried with QueryICA and used as the sender of the message,
+// as it is the only signer the host accepts. Callers that passed the interchain account address as fromAddr
+// must pass the address of its owner instead.
 func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
+	icaAddr, err := tn.QueryICA(ctx, connectionID, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to query interchain account: %w", err)
+	}
+	return tn.SendICABankTransferFromAccount(ctx, connectionID, fromAddr, icaAddr, amount)
+}
+
+// SendICABankTransferFromAccount is SendICABankTransfer for an owner whose interchain account icaAddr is already known.
+func (tn *ChainNode) SendICABankTransferFromAccount(ctx context.Context, connectionID, ownerAddr, icaAddr string, amount ibc.WalletAmount) error {
 	msg, err := json.Marshal(map[string]any{
 		"@type":        "/cosmos.bank.v1beta1.MsgSend",
-		"from_address": fromAddr,
+		"from_address": icaAddr,
 		"to_address":   amount.Address,
 		"amount": []map[string]any{
 			{
@@ -1350,9 +1489,58 @@ func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, from
 		return err
 	}
 
-	_, err = tn.ExecTx(ctx, fromAddr,
+	_, err = tn.ExecTx(ctx, ownerAddr,
 		"intertx", "submit", string(msg),
 		"--connection-id", connectionID,
 	)
 	return err
 }
+
+// RegisterInterchainAccount registers an interchain account owned by keyName on the counterparty chain of connectionID,
+// through the ibc-go interchain accounts controller module.
+func (tn *ChainNode) RegisterInterchainAccount(ctx context.Context, keyName, connectionID string) (string, error) {
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "register", connectionID,
+	)
+}
+
+// QueryInterchainAccount returns the address of the interchain account owned by owner on the counterparty chain of connectionID,
+// registered through the ibc-go interchain accounts controller module.
+func (tn *ChainNode) QueryInterchainAccount(ctx context.Context, connectionID, owner string) (string, error) {
+	stdout, _, err := tn.ExecQuery(ctx,
+		"interchain-accounts", "controller", "interchain-account", owner, connectionID,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return "", fmt.Errorf("malformed stdout from command: %s: %w", stdout, err)
+	}
+	return res.Address, nil
+}
+
+// SendInterchainAccountTx sends msg, a JSON encoded sdk.Msg, in a packet to the interchain account owned by keyName
+// on the counterparty chain of connectionID, through the ibc-go interchain accounts controller module.
+// The packet times out after timeout.
+func (tn *ChainNode) SendInterchainAccountTx(ctx context.Context, keyName, connectionID string, timeout time.Duration, msg []byte) (string, error) {
+	packetData, _, err := tn.ExecBin(ctx,
+		"tx", "interchain-accounts", "host", "generate-packet-data", string(msg),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate packet data: %w", err)
+	}
+
+	file := fmt.Sprintf("ica-packet-%X.json", sha256.Sum256(packetData))
+	if err := tn.WriteFile(ctx, packetData, file); err != nil {
+		return "", fmt.Errorf("failed to write packet data: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "send-tx", connectionID, path.Join(tn.HomeDir(), file),
+		"--relative-packet-timeout", strconv.FormatInt(timeout.Nanoseconds(), 10),
+	)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/runner"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -57,6 +58,8 @@ type CosmosChain struct {
 	log      *zap.Logger
 	keyring  keyring.Keyring
 	findTxMu sync.Mutex
+
+	ibc.ChainExecReporterHolder
 }
 
 func NewCosmosHeighlinerChainConfig(name string,
@@ -148,15 +151,7 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 				if !ok {
 					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
 				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					fn.logger(),
-					fn.DockerClient,
-					fn.TestName,
-					fn.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
+				if err := fn.ModifyTomlConfigFile(ctx, configFile, modifiedToml); err != nil {
 					return err
 				}
 			}
@@ -176,6 +171,9 @@ func (c *CosmosChain) Config() ibc.ChainConfig {
 
 // Implements Chain interface
 func (c *CosmosChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+	if err := c.buildImages(ctx, cli); err != nil {
+		return err
+	}
 	if err := c.initializeSidecars(ctx, testName, cli, networkID); err != nil {
 		return err
 	}
@@ -198,19 +196,21 @@ func (c *CosmosChain) Exec(ctx context.Context, cmd []string, env []string) (std
 	return c.getFullNode().Exec(ctx, cmd, env)
 }
 
+var _ ibc.ChainExecTracker = (*CosmosChain)(nil)
+
 // Implements Chain interface
 func (c *CosmosChain) GetRPCAddress() string {
-	return fmt.Sprintf("http://%s:26657", c.getFullNode().HostName())
+	return "http://" + c.getFullNode().runner().Address(rpcPort)
 }
 
 // Implements Chain interface
 func (c *CosmosChain) GetAPIAddress() string {
-	return fmt.Sprintf("http://%s:1317", c.getFullNode().HostName())
+	return "http://" + c.getFullNode().runner().Address(apiPort)
 }
 
 // Implements Chain interface
 func (c *CosmosChain) GetGRPCAddress() string {
-	return fmt.Sprintf("%s:9090", c.getFullNode().HostName())
+	return c.getFullNode().runner().Address(grpcPort)
 }
 
 // GetHostRPCAddress returns the address of the RPC server accessible by the host.
@@ -256,6 +256,24 @@ func (c *CosmosChain) GetAddress(ctx context.Context, keyName string) ([]byte, e
 	return types.GetFromBech32(b32Addr, c.Config().Bech32Prefix)
 }
 
+// ValidatorWallets returns the wallets of the validator keys of the chain, in the order of Validators.
+// The keys are generated in the keyring of each validator node, so the wallets have no mnemonic.
+func (c *CosmosChain) ValidatorWallets(ctx context.Context) ([]ibc.Wallet, error) {
+	wallets := make([]ibc.Wallet, len(c.Validators))
+	for i, v := range c.Validators {
+		b32Addr, err := v.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := types.GetFromBech32(b32Addr, c.Config().Bech32Prefix)
+		if err != nil {
+			return nil, err
+		}
+		wallets[i] = NewWallet(valKey, addr, "", c.cfg)
+	}
+	return wallets, nil
+}
+
 // BuildWallet will return a Cosmos wallet
 // If mnemonic != "", it will restore using that mnemonic
 // If mnemonic == "", it will create a new key
@@ -586,6 +604,8 @@ func (c *CosmosChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
 
 func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, containerRepo, version string) {
 	c.cfg.Images[0].Version = version
+	// The upgraded image is pulled, even if the previous one was built from local source.
+	c.cfg.Images[0].Build = nil
 	for _, n := range c.Validators {
 		n.Image.Version = version
 		n.Image.Repository = containerRepo
@@ -598,7 +618,14 @@ func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, co
 }
 
 func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
+	if c.cfg.IsNative() {
+		return
+	}
 	for _, image := range c.Config().Images {
+		if image.Build != nil {
+			// Built by buildImages.
+			continue
+		}
 		rc, err := cli.ImagePull(
 			ctx,
 			image.Repository+":"+image.Version,
@@ -617,7 +644,26 @@ func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
 	}
 }
 
-// NewChainNode constructs a new cosmos chain node with a docker volume.
+// buildImages builds the images of the chain and its sidecars that are built from local source.
+func (c *CosmosChain) buildImages(ctx context.Context, cli *client.Client) error {
+	if c.cfg.IsNative() {
+		return nil
+	}
+	for i := range c.cfg.Images {
+		if err := dockerutil.BuildImage(ctx, c.log, cli, &c.cfg.Images[i]); err != nil {
+			return err
+		}
+	}
+	for i := range c.cfg.SidecarConfigs {
+		if err := dockerutil.BuildImage(ctx, c.log, cli, &c.cfg.SidecarConfigs[i].Image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewChainNode constructs a new cosmos chain node with a docker volume,
+// or with a home directory on the host if the chain uses the native runtime.
 func (c *CosmosChain) NewChainNode(
 	ctx context.Context,
 	testName string,
@@ -631,15 +677,41 @@ func (c *CosmosChain) NewChainNode(
 	// The ChainNode's VolumeName cannot be set until after we create the volume.
 	tn := NewChainNode(c.log, validator, c, cli, networkID, testName, image, index)
 
+	if c.cfg.IsNative() {
+		n, err := runner.NewNative(c.log, testName, tn.Name(), nativePorts)
+		if err != nil {
+			return nil, fmt.Errorf("creating native runtime for chain node: %w", err)
+		}
+		tn.native = n
+	} else if err := c.createNodeVolume(ctx, cli, tn, image); err != nil {
+		return nil, err
+	}
+
+	for _, cfg := range c.cfg.SidecarConfigs {
+		if !cfg.ValidatorProcess {
+			continue
+		}
+
+		err := tn.NewSidecarProcess(ctx, cfg.PreStart, cfg.ProcessName, cli, networkID, cfg.Image, cfg.HomeDir, cfg.Ports, cfg.StartCmd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tn, nil
+}
+
+// createNodeVolume creates the docker volume holding the home directory of tn.
+func (c *CosmosChain) createNodeVolume(ctx context.Context, cli *client.Client, tn *ChainNode, image ibc.DockerImage) error {
 	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
 		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+			dockerutil.CleanupLabel: tn.TestName,
 
 			dockerutil.NodeOwnerLabel: tn.Name(),
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("creating volume for chain node: %w", err)
+		return fmt.Errorf("creating volume for chain node: %w", err)
 	}
 	tn.VolumeName = v.Name
 
@@ -650,27 +722,16 @@ func (c *CosmosChain) NewChainNode(
 
 		VolumeName: v.Name,
 		ImageRef:   image.Ref(),
-		TestName:   testName,
+		TestName:   tn.TestName,
 		UidGid:     image.UidGid,
 	}); err != nil {
-		return nil, fmt.Errorf("set volume owner: %w", err)
-	}
-
-	for _, cfg := range c.cfg.SidecarConfigs {
-		if !cfg.ValidatorProcess {
-			continue
-		}
-
-		err = tn.NewSidecarProcess(ctx, cfg.PreStart, cfg.ProcessName, cli, networkID, cfg.Image, cfg.HomeDir, cfg.Ports, cfg.StartCmd)
-		if err != nil {
-			return nil, err
-		}
+		return fmt.Errorf("set volume owner: %w", err)
 	}
-
-	return tn, nil
+	return nil
 }
 
-// NewSidecarProcess constructs a new sidecar process with a docker volume.
+// NewSidecarProcess constructs a new sidecar process with a docker volume,
+// or with a home directory on the host if the chain uses the native runtime.
 func (c *CosmosChain) NewSidecarProcess(
 	ctx context.Context,
 	preStart bool,
@@ -688,28 +749,8 @@ func (c *CosmosChain) NewSidecarProcess(
 	// The SidecarProcess's VolumeName cannot be set until after we create the volume.
 	s := NewSidecar(c.log, false, preStart, c, cli, networkID, processName, testName, image, homeDir, index, ports, startCmd)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   testName,
-			dockerutil.NodeOwnerLabel: s.Name(),
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("creating volume for sidecar process: %w", err)
-	}
-	s.VolumeName = v.Name
-
-	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-		Log: c.log,
-
-		Client: cli,
-
-		VolumeName: v.Name,
-		ImageRef:   image.Ref(),
-		TestName:   testName,
-		UidGid:     image.UidGid,
-	}); err != nil {
-		return fmt.Errorf("set volume owner: %w", err)
+	if err := s.createHome(ctx); err != nil {
+		return err
 	}
 
 	c.Sidecars = append(c.Sidecars, s)
@@ -726,7 +767,12 @@ func (c *CosmosChain) initializeChainNodes(
 ) error {
 	chainCfg := c.Config()
 	c.pullImages(ctx, cli)
-	image := chainCfg.Images[0]
+
+	// Chains of the native runtime need no image.
+	var image ibc.DockerImage
+	if len(chainCfg.Images) > 0 {
+		image = chainCfg.Images[0]
+	}
 
 	newVals := make(ChainNodes, c.numValidators)
 	copy(newVals, c.Validators)
@@ -853,15 +899,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 				if !ok {
 					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
 				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					v.logger(),
-					v.DockerClient,
-					v.TestName,
-					v.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
+				if err := v.ModifyTomlConfigFile(ctx, configFile, modifiedToml); err != nil {
 					return err
 				}
 			}
@@ -885,15 +923,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 				if !ok {
 					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
 				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					n.logger(),
-					n.DockerClient,
-					n.TestName,
-					n.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
+				if err := n.ModifyTomlConfigFile(ctx, configFile, modifiedToml); err != nil {
 					return err
 				}
 			}
@@ -927,11 +957,11 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
 			return err
 		}
+	}
 
-		if !c.cfg.SkipGenTx {
-			if err := validatorN.copyGentx(ctx, validator0); err != nil {
-				return err
-			}
+	if !c.cfg.SkipGenTx {
+		if err := validator0.copyGentxs(ctx, c.Validators[1:]); err != nil {
+			return err
 		}
 	}
 
@@ -974,10 +1004,8 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 
 	chainNodes := c.Nodes()
 
-	for _, cn := range chainNodes {
-		if err := cn.OverwriteGenesisFile(ctx, genbz); err != nil {
-			return err
-		}
+	if err := chainNodes.OverwriteGenesisFile(ctx, genbz); err != nil {
+		return err
 	}
 
 	if err := chainNodes.LogGenesisHashes(ctx); err != nil {
@@ -989,7 +1017,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	for _, s := range c.Sidecars {
 		s := s
 
-		err = s.containerLifecycle.Running(ctx)
+		err = s.runner().Running(ctx)
 		if s.preStart && err != nil {
 			eg.Go(func() error {
 				if err := s.CreateContainer(egCtx); err != nil {
@@ -1118,6 +1146,14 @@ func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlockHeader implements blockdb.BlockHeaderFinder.
+func (c *CosmosChain) FindBlockHeader(ctx context.Context, height uint64) (blockdb.BlockHeader, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlockHeader(ctx, height)
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
@@ -1177,7 +1213,7 @@ func (c *CosmosChain) StartAllSidecars(ctx context.Context) error {
 	for _, s := range c.Sidecars {
 		s := s
 
-		err := s.containerLifecycle.Running(ctx)
+		err := s.runner().Running(ctx)
 		if err == nil {
 			continue
 		}
@@ -1204,7 +1240,7 @@ func (c *CosmosChain) StartAllValSidecars(ctx context.Context) error {
 		for _, s := range v.Sidecars {
 			s := s
 
-			err := s.containerLifecycle.Running(ctx)
+			err := s.runner().Running(ctx)
 			if err == nil {
 				continue
 			}
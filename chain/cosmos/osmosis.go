@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
-
-	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 )
 
 // OsmosisPoolParams defines parameters for creating an osmosis gamm liquidity pool
@@ -28,8 +26,7 @@ func OsmosisCreatePool(c *CosmosChain, ctx context.Context, keyName string, para
 
 	poolFile := "pool.json"
 
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
-	if err := fw.WriteFile(ctx, tn.VolumeName, poolFile, poolbz); err != nil {
+	if err := tn.WriteFile(ctx, poolbz, poolFile); err != nil {
 		return "", fmt.Errorf("failed to write pool file: %w", err)
 	}
 
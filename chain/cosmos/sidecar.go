@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os"
 
+	volumetypes "github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/runner"
 )
 
 type SidecarProcesses []*SidecarProcess
@@ -40,6 +42,9 @@ type SidecarProcess struct {
 	homeDir      string
 
 	containerLifecycle *dockerutil.ContainerLifecycle
+
+	// native runs the process on the host if the chain uses the native runtime.
+	native *runner.Native
 }
 
 // NewSidecar instantiates a new SidecarProcess.
@@ -103,28 +108,96 @@ func (s *SidecarProcess) logger() *zap.Logger {
 	)
 }
 
+// createHome creates the docker volume holding the home directory of the process,
+// or its home directory on the host if the chain uses the native runtime.
+func (s *SidecarProcess) createHome(ctx context.Context) error {
+	if s.Chain.Config().IsNative() {
+		n, err := runner.NewNative(s.log, s.TestName, s.Name(), nil)
+		if err != nil {
+			return fmt.Errorf("creating native runtime for sidecar process: %w", err)
+		}
+		s.native = n
+		return nil
+	}
+
+	v, err := s.DockerClient.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{
+			dockerutil.CleanupLabel:   s.TestName,
+			dockerutil.NodeOwnerLabel: s.Name(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating volume for sidecar process: %w", err)
+	}
+	s.VolumeName = v.Name
+
+	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
+		Log: s.log,
+
+		Client: s.DockerClient,
+
+		VolumeName: v.Name,
+		ImageRef:   s.Image.Ref(),
+		TestName:   s.TestName,
+		UidGid:     s.Image.UidGid,
+	}); err != nil {
+		return fmt.Errorf("set volume owner: %w", err)
+	}
+	return nil
+}
+
+// runner returns the runtime of the process, which is docker unless the chain uses the native runtime.
+func (s *SidecarProcess) runner() runner.Runner {
+	if s.native != nil {
+		return s.native
+	}
+	return &runner.Docker{
+		Log:        s.logger(),
+		Client:     s.DockerClient,
+		NetworkID:  s.NetworkID,
+		TestName:   s.TestName,
+		Image:      s.Image,
+		VolumeName: s.VolumeName,
+		Home:       s.HomeDir(),
+		HostName:   s.HostName(),
+		Ports:      s.ports,
+		Resources:  s.resources(),
+		Lifecycle:  s.containerLifecycle,
+	}
+}
+
+// resources returns the resource limits of the sidecar config of the chain with the name of the process.
+func (s *SidecarProcess) resources() ibc.Resources {
+	for _, cfg := range s.Chain.Config().SidecarConfigs {
+		if cfg.ProcessName == s.ProcessName {
+			return cfg.Resources
+		}
+	}
+	return ibc.Resources{}
+}
+
 func (s *SidecarProcess) CreateContainer(ctx context.Context) error {
-	return s.containerLifecycle.CreateContainer(ctx, s.TestName, s.NetworkID, s.Image, s.ports, s.Bind(), s.HostName(), s.startCmd)
+	return s.runner().Create(ctx, s.startCmd)
 }
 
 func (s *SidecarProcess) StartContainer(ctx context.Context) error {
-	return s.containerLifecycle.StartContainer(ctx)
+	return s.runner().Start(ctx)
 }
 
 func (s *SidecarProcess) PauseContainer(ctx context.Context) error {
-	return s.containerLifecycle.PauseContainer(ctx)
+	return s.runner().Pause(ctx)
 }
 
 func (s *SidecarProcess) UnpauseContainer(ctx context.Context) error {
-	return s.containerLifecycle.UnpauseContainer(ctx)
+	return s.runner().Unpause(ctx)
 }
 
 func (s *SidecarProcess) StopContainer(ctx context.Context) error {
-	return s.containerLifecycle.StopContainer(ctx)
+	return s.runner().Stop(ctx)
 }
 
 func (s *SidecarProcess) RemoveContainer(ctx context.Context) error {
-	return s.containerLifecycle.RemoveContainer(ctx)
+	return s.runner().Remove(ctx)
 }
 
 // Bind returns the home folder bind point for running the process.
@@ -132,8 +205,12 @@ func (s *SidecarProcess) Bind() []string {
 	return []string{fmt.Sprintf("%s:%s", s.VolumeName, s.HomeDir())}
 }
 
-// HomeDir returns the path name where any configuration files will be written to the Docker filesystem.
+// HomeDir returns the path name where any configuration files will be written to the Docker filesystem,
+// or to the host filesystem if the chain uses the native runtime.
 func (s *SidecarProcess) HomeDir() string {
+	if s.native != nil {
+		return s.native.HomeDir()
+	}
 	return s.homeDir
 }
 
@@ -142,15 +219,14 @@ func (s *SidecarProcess) HostName() string {
 }
 
 func (s *SidecarProcess) GetHostPorts(ctx context.Context, portIDs ...string) ([]string, error) {
-	return s.containerLifecycle.GetHostPorts(ctx, portIDs...)
+	return s.runner().HostPorts(ctx, portIDs...)
 }
 
 // WriteFile accepts file contents in a byte slice and writes the contents to
 // the docker filesystem. relPath describes the location of the file in the
 // docker volume relative to the home directory
 func (s *SidecarProcess) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(s.logger(), s.DockerClient, s.TestName)
-	return fw.WriteFile(ctx, s.VolumeName, relPath, content)
+	return s.runner().WriteFile(ctx, relPath, content)
 }
 
 // CopyFile adds a file from the host filesystem to the docker filesystem
@@ -167,8 +243,7 @@ func (s *SidecarProcess) CopyFile(ctx context.Context, srcPath, dstPath string)
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(s.logger(), s.DockerClient, s.TestName)
-	gen, err := fr.SingleFileContent(ctx, s.VolumeName, relPath)
+	gen, err := s.runner().ReadFile(ctx, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
 	}
@@ -177,11 +252,7 @@ func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte,
 
 // Exec enables the execution of arbitrary CLI cmds against the process.
 func (s *SidecarProcess) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(s.logger(), s.DockerClient, s.NetworkID, s.TestName, s.Image.Repository, s.Image.Version)
-	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: s.Bind(),
-	}
-	res := job.Run(ctx, cmd, opts)
+	res := s.runner().Exec(ctx, cmd, env)
+	res.TrackChainExec(ibc.ChainExecReporterOf(s.Chain), env)
 	return res.Stdout, res.Stderr, res.Err
 }
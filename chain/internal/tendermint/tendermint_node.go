@@ -226,7 +226,7 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 	cmd := []string{chainCfg.Bin, "start", "--home", tn.HomeDir()}
 	cmd = append(cmd, additionalFlags...)
 
-	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, sentryPorts, tn.Bind(), tn.HostName(), cmd)
+	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, sentryPorts, tn.Bind(), tn.HostName(), cmd, tn.Chain.Config().Resources)
 }
 
 func (tn *TendermintNode) StopContainer(ctx context.Context) error {
@@ -315,14 +315,45 @@ func (tn TendermintNodes) PeerString(ctx context.Context, node *TendermintNode)
 	return strings.Join(addrs, ",")
 }
 
+// OverwriteGenesisFile writes content to the genesis file of every node,
+// with a single one-off container.
+func (tn TendermintNodes) OverwriteGenesisFile(ctx context.Context, content []byte) error {
+	if len(tn) == 0 {
+		return nil
+	}
+
+	files := make([]dockerutil.VolumeFile, len(tn))
+	for i, n := range tn {
+		files[i] = dockerutil.VolumeFile{Volume: n.VolumeName, Path: "config/genesis.json", Content: content}
+	}
+
+	fw := dockerutil.NewFileWriter(tn[0].logger(), tn[0].DockerClient, tn[0].TestName)
+	if err := fw.WriteFiles(ctx, files...); err != nil {
+		return fmt.Errorf("overwriting genesis.json: %w", err)
+	}
+
+	return nil
+}
+
 // LogGenesisHashes logs the genesis hashes for the various nodes
 func (tn TendermintNodes) LogGenesisHashes(ctx context.Context) error {
-	for _, n := range tn {
-		gen, err := n.GenesisFileContent(ctx)
-		if err != nil {
-			return err
-		}
-		n.logger().Info("Genesis", zap.String("hash", fmt.Sprintf("%X", sha256.Sum256(gen))))
+	if len(tn) == 0 {
+		return nil
+	}
+
+	files := make([]dockerutil.VolumeFile, len(tn))
+	for i, n := range tn {
+		files[i] = dockerutil.VolumeFile{Volume: n.VolumeName, Path: "config/genesis.json"}
+	}
+
+	fr := dockerutil.NewFileRetriever(tn[0].logger(), tn[0].DockerClient, tn[0].TestName)
+	gens, err := fr.FilesContent(ctx, files...)
+	if err != nil {
+		return fmt.Errorf("getting genesis.json content: %w", err)
+	}
+
+	for i, n := range tn {
+		n.logger().Info("Genesis", zap.String("hash", fmt.Sprintf("%X", sha256.Sum256(gens[i]))))
 	}
 	return nil
 }
@@ -330,10 +361,11 @@ func (tn TendermintNodes) LogGenesisHashes(ctx context.Context) error {
 func (tn *TendermintNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
 	job := dockerutil.NewImage(tn.Log, tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: tn.Bind(),
+		Env:       env,
+		Binds:     tn.Bind(),
+		Resources: tn.Chain.Config().Resources,
 	}
-	res := job.Run(ctx, cmd, opts)
+	res := job.RunReported(ctx, ibc.ChainExecReporterOf(tn.Chain), cmd, opts)
 	return res.Stdout, res.Stderr, res.Err
 }
 
@@ -212,7 +212,7 @@ func (p *PenumbraAppNode) SendIBCTransfer(
 func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 	cmd := []string{"pd", "start", "--host", "0.0.0.0", "--home", p.HomeDir()}
 
-	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), p.HostName(), cmd)
+	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), p.HostName(), cmd, p.Chain.Config().Resources)
 }
 
 func (p *PenumbraAppNode) StopContainer(ctx context.Context) error {
@@ -238,10 +238,11 @@ func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 func (p *PenumbraAppNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
 	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
-		Binds: p.Bind(),
-		Env:   env,
-		User:  p.Image.UidGid,
+		Binds:     p.Bind(),
+		Env:       env,
+		User:      p.Image.UidGid,
+		Resources: p.Chain.Config().Resources,
 	}
-	res := job.Run(ctx, cmd, opts)
+	res := job.RunReported(ctx, ibc.ChainExecReporterOf(p.Chain), cmd, opts)
 	return res.Stdout, res.Stderr, res.Err
 }
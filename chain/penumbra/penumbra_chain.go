@@ -43,6 +43,8 @@ type PenumbraChain struct {
 	numFullNodes  int
 	PenumbraNodes PenumbraNodes
 	keyring       keyring.Keyring
+
+	ibc.ChainExecReporterHolder
 }
 
 type PenumbraValidatorDefinition struct {
@@ -112,6 +114,8 @@ func (c *PenumbraChain) Exec(ctx context.Context, cmd []string, env []string) (s
 	return c.getRelayerNode().PenumbraAppNode.Exec(ctx, cmd, env)
 }
 
+var _ ibc.ChainExecTracker = (*PenumbraChain)(nil)
+
 func (c *PenumbraChain) getRelayerNode() PenumbraNode {
 	if len(c.PenumbraNodes) > c.numValidators {
 		// use first full node
@@ -329,8 +333,16 @@ func (c *PenumbraChain) initializeChainNodes(
 ) error {
 	penumbraNodes := []PenumbraNode{}
 	count := c.numValidators + c.numFullNodes
+	for i := range c.cfg.Images {
+		if err := dockerutil.BuildImage(ctx, c.log, cli, &c.cfg.Images[i]); err != nil {
+			return err
+		}
+	}
 	chainCfg := c.Config()
 	for _, image := range chainCfg.Images {
+		if image.Build != nil {
+			continue
+		}
 		rc, err := cli.ImagePull(
 			ctx,
 			image.Repository+":"+image.Version,
@@ -473,31 +485,33 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 	}
 
 	// penumbra generate-testnet right now overwrites new validator keys
-	eg, egCtx = errgroup.WithContext(ctx)
-	for i, val := range c.PenumbraNodes[:c.numValidators] {
-		i := i
-		val := val
-		// Use an errgroup to save some time doing many concurrent copies inside containers.
-		eg.Go(func() error {
-			firstValPrivKeyRelPath := fmt.Sprintf(".penumbra/testnet_data/node%d/tendermint/config/priv_validator_key.json", i)
-
-			fr := dockerutil.NewFileRetriever(c.log, firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
-			pk, err := fr.SingleFileContent(egCtx, firstVal.PenumbraAppNode.VolumeName, firstValPrivKeyRelPath)
-			if err != nil {
-				return fmt.Errorf("error getting validator private key content: %w", err)
-			}
+	privKeyFiles := make([]dockerutil.VolumeFile, len(validators))
+	for i := range validators {
+		privKeyFiles[i] = dockerutil.VolumeFile{
+			Volume: firstVal.PenumbraAppNode.VolumeName,
+			Path:   fmt.Sprintf(".penumbra/testnet_data/node%d/tendermint/config/priv_validator_key.json", i),
+		}
+	}
 
-			fw := dockerutil.NewFileWriter(c.log, val.PenumbraAppNode.DockerClient, val.PenumbraAppNode.TestName)
-			if err := fw.WriteFile(egCtx, val.TendermintNode.VolumeName, "config/priv_validator_key.json", pk); err != nil {
-				return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
-			}
+	// In all likelihood, every node has the same DockerClient and TestName,
+	// so all keys are read with one container and written with another.
+	fr := dockerutil.NewFileRetriever(c.log, firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
+	pks, err := fr.FilesContent(ctx, privKeyFiles...)
+	if err != nil {
+		return fmt.Errorf("error getting validator private key content: %w", err)
+	}
 
-			return nil
-		})
+	for i, val := range validators {
+		privKeyFiles[i] = dockerutil.VolumeFile{
+			Volume:  val.TendermintNode.VolumeName,
+			Path:    "config/priv_validator_key.json",
+			Content: pks[i],
+		}
 	}
 
-	if err := eg.Wait(); err != nil {
-		return err
+	fw := dockerutil.NewFileWriter(c.log, firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
+	if err := fw.WriteFiles(ctx, privKeyFiles...); err != nil {
+		return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
 	}
 
 	return c.start(ctx)
@@ -514,13 +528,14 @@ func (c *PenumbraChain) start(ctx context.Context) error {
 	tendermintNodes := make([]*tendermint.TendermintNode, len(c.PenumbraNodes))
 	for i, node := range c.PenumbraNodes {
 		tendermintNodes[i] = node.TendermintNode
-		if err := node.TendermintNode.OverwriteGenesisFile(ctx, genesisContent); err != nil {
-			return err
-		}
 	}
 
 	tmNodes := tendermint.TendermintNodes(tendermintNodes)
 
+	if err := tmNodes.OverwriteGenesisFile(ctx, genesisContent); err != nil {
+		return err
+	}
+
 	if err := tmNodes.LogGenesisHashes(ctx); err != nil {
 		return err
 	}
@@ -0,0 +1,339 @@
+package polkadot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/misko9/go-substrate-rpc-client/v4/types/codec"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"go.uber.org/zap"
+)
+
+// runtimeMetadata caches the metadata of a node's runtime, which only changes on runtime upgrades.
+type runtimeMetadata struct {
+	mu          sync.Mutex
+	specVersion gstypes.U32
+	meta        *gstypes.MetadataV14
+}
+
+// at returns the metadata of the runtime that produced the block with hash.
+func (rm *runtimeMetadata) at(api *gsrpc.SubstrateAPI, hash gstypes.Hash) (*gstypes.MetadataV14, error) {
+	rv, err := api.RPC.State.GetRuntimeVersion(hash)
+	if err != nil {
+		return nil, fmt.Errorf("get runtime version: %w", err)
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.meta != nil && rm.specVersion == rv.SpecVersion {
+		return rm.meta, nil
+	}
+
+	meta, err := api.RPC.State.GetMetadata(hash)
+	if err != nil {
+		return nil, fmt.Errorf("get metadata: %w", err)
+	}
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("unsupported metadata version %d", meta.Version)
+	}
+	rm.specVersion = rv.SpecVersion
+	rm.meta = &meta.AsMetadataV14
+	return rm.meta, nil
+}
+
+// extrinsic is the blockdb.Tx data of a decoded extrinsic.
+type extrinsic struct {
+	// Index is the position of the extrinsic in its block.
+	Index int `json:"index"`
+
+	Pallet string `json:"pallet"`
+	Call   string `json:"call"`
+
+	// Signer is the address of the signer of signed extrinsics.
+	Signer any `json:"signer,omitempty"`
+	Args   any `json:"args,omitempty"`
+
+	// Error and Raw are only set if the extrinsic could not be decoded.
+	Error string `json:"error,omitempty"`
+	Raw   string `json:"raw,omitempty"`
+}
+
+// event is a decoded record of the System.Events storage item.
+type event struct {
+	// Phase is one of "ApplyExtrinsic", "Initialization", or "Finalization".
+	Phase string
+	// Extrinsic is the index of the extrinsic that emitted the event, if Phase is "ApplyExtrinsic".
+	Extrinsic int
+
+	Pallet string
+	Name   string
+	Fields any
+}
+
+// findTxs returns one blockdb.Tx per extrinsic of the block at height,
+// holding the events the extrinsic emitted.
+// Events emitted while initializing and finalizing the block are held by two
+// additional artificial transactions, as with begin and end block events of cosmos chains.
+func findTxs(log *zap.Logger, api *gsrpc.SubstrateAPI, rm *runtimeMetadata, height uint64) ([]blockdb.Tx, error) {
+	hash, err := api.RPC.Chain.GetBlockHash(height)
+	if err != nil {
+		return nil, fmt.Errorf("get block hash: %w", err)
+	}
+	meta, err := rm.at(api, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// The block is fetched undecoded, because the typed extrinsics of the rpc client
+	// do not support the signed extensions of every runtime.
+	var block struct {
+		Block struct {
+			Extrinsics []string `json:"extrinsics"`
+		} `json:"block"`
+	}
+	if err := api.Client.Call(&block, "chain_getBlock", hash.Hex()); err != nil {
+		return nil, fmt.Errorf("get block: %w", err)
+	}
+
+	txs := make([]blockdb.Tx, len(block.Block.Extrinsics))
+	for i, xt := range block.Block.Extrinsics {
+		data, err := json.Marshal(decodeExtrinsic(meta, i, xt))
+		if err != nil {
+			return nil, fmt.Errorf("marshal extrinsic %d: %w", i, err)
+		}
+		txs[i].Data = data
+	}
+
+	raw, err := api.RPC.State.GetStorageRaw(gstypes.NewStorageKey(gstypes.CreateStorageKeyPrefix("System", "Events")), hash)
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+	if raw == nil || len(*raw) == 0 {
+		return txs, nil
+	}
+	events, err := decodeEvents(meta, *raw)
+	if err != nil {
+		// Keep the extrinsics even if an unsupported type prevents decoding the events.
+		log.Info("Failed to decode events", zap.Uint64("height", height), zap.Error(err))
+		return txs, nil
+	}
+
+	var initialization, finalization []blockdb.Event
+	for _, e := range events {
+		be := blockdb.Event{
+			Type:       e.Pallet + "." + e.Name,
+			Attributes: eventAttributes(e.Fields),
+		}
+		switch {
+		case e.Phase == "ApplyExtrinsic" && e.Extrinsic < len(txs):
+			txs[e.Extrinsic].Events = append(txs[e.Extrinsic].Events, be)
+		case e.Phase == "Finalization":
+			finalization = append(finalization, be)
+		default:
+			initialization = append(initialization, be)
+		}
+	}
+	if len(initialization) > 0 {
+		txs = append(txs, blockdb.Tx{
+			Data:   []byte(`{"data":"initialization","note":"this is a transaction artificially created for debugging purposes"}`),
+			Events: initialization,
+		})
+	}
+	if len(finalization) > 0 {
+		txs = append(txs, blockdb.Tx{
+			Data:   []byte(`{"data":"finalization","note":"this is a transaction artificially created for debugging purposes"}`),
+			Events: finalization,
+		})
+	}
+
+	return txs, nil
+}
+
+// decodeExtrinsic decodes the hex encoded extrinsic xt at index i of its block.
+// If the extrinsic cannot be decoded, the error and the raw extrinsic are returned instead.
+func decodeExtrinsic(meta *gstypes.MetadataV14, i int, xt string) extrinsic {
+	out := extrinsic{Index: i}
+	if err := decodeExtrinsicInto(meta, xt, &out); err != nil {
+		return extrinsic{Index: i, Error: err.Error(), Raw: xt}
+	}
+	return out
+}
+
+func decodeExtrinsicInto(meta *gstypes.MetadataV14, xt string, out *extrinsic) error {
+	bz, err := codec.HexDecodeString(xt)
+	if err != nil {
+		return err
+	}
+	d := newScaleDecoder(meta, bz)
+
+	// Extrinsics are encoded as length prefixed bytes.
+	if _, err := d.dec.DecodeUintCompact(); err != nil {
+		return err
+	}
+	version, err := d.dec.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	if version&0x7f != 4 {
+		return fmt.Errorf("unsupported extrinsic version %d", version&0x7f)
+	}
+
+	params := extrinsicTypeParams(meta)
+	if version&0x80 != 0 {
+		for _, name := range []string{"Address", "Signature", "Extra"} {
+			id, ok := params[name]
+			if !ok {
+				return fmt.Errorf("extrinsic type has no %s parameter", name)
+			}
+			v, err := d.decode(id)
+			if err != nil {
+				return fmt.Errorf("decode %s: %w", name, err)
+			}
+			if name == "Address" {
+				out.Signer = unwrapAddress(v)
+			}
+		}
+	}
+
+	id, ok := params["Call"]
+	if !ok {
+		return fmt.Errorf("extrinsic type has no Call parameter")
+	}
+	v, err := d.decode(id)
+	if err != nil {
+		return fmt.Errorf("decode call: %w", err)
+	}
+	pallet, ok := v.(scaleVariant)
+	if !ok {
+		return fmt.Errorf("unexpected call %T", v)
+	}
+	call, ok := pallet.Value.(scaleVariant)
+	if !ok {
+		return fmt.Errorf("unexpected %s call %T", pallet.Name, pallet.Value)
+	}
+	out.Pallet, out.Call, out.Args = pallet.Name, call.Name, call.Value
+	return nil
+}
+
+// extrinsicTypeParams returns the type parameters of the runtime's UncheckedExtrinsic type,
+// i.e. the types of the Address, Call, Signature, and Extra (signed extensions) of extrinsics.
+func extrinsicTypeParams(meta *gstypes.MetadataV14) map[string]gstypes.Si1LookupTypeID {
+	params := make(map[string]gstypes.Si1LookupTypeID)
+	typ, ok := meta.EfficientLookup[meta.Extrinsic.Type.Int64()]
+	if !ok {
+		return params
+	}
+	for _, p := range typ.Params {
+		if p.HasType {
+			params[string(p.Name)] = p.Type
+		}
+	}
+	return params
+}
+
+// unwrapAddress returns the account of a MultiAddress::Id, and the address unchanged otherwise.
+func unwrapAddress(v any) any {
+	if addr, ok := v.(scaleVariant); ok && addr.Name == "Id" {
+		return addr.Value
+	}
+	return v
+}
+
+// decodeEvents decodes the value of the System.Events storage item.
+func decodeEvents(meta *gstypes.MetadataV14, bz []byte) ([]event, error) {
+	id, err := systemEventsType(meta)
+	if err != nil {
+		return nil, err
+	}
+	v, err := newScaleDecoder(meta, bz).decode(id)
+	if err != nil {
+		return nil, err
+	}
+	records, ok := v.([]any)
+	if !ok && v != nil {
+		return nil, fmt.Errorf("unexpected event records %T", v)
+	}
+
+	events := make([]event, 0, len(records))
+	for i, r := range records {
+		var e event
+		record, ok := r.(scaleComposite)
+		if !ok {
+			return nil, fmt.Errorf("unexpected event record %d %T", i, r)
+		}
+		for _, f := range record {
+			switch f.Name {
+			case "phase":
+				phase, ok := f.Value.(scaleVariant)
+				if !ok {
+					return nil, fmt.Errorf("unexpected phase of event record %d %T", i, f.Value)
+				}
+				e.Phase = phase.Name
+				if n, ok := phase.Value.(uint64); ok {
+					e.Extrinsic = int(n)
+				}
+			case "event":
+				pallet, ok := f.Value.(scaleVariant)
+				if !ok {
+					return nil, fmt.Errorf("unexpected event of event record %d %T", i, f.Value)
+				}
+				ev, ok := pallet.Value.(scaleVariant)
+				if !ok {
+					return nil, fmt.Errorf("unexpected %s event of event record %d %T", pallet.Name, i, pallet.Value)
+				}
+				e.Pallet, e.Name, e.Fields = pallet.Name, ev.Name, ev.Value
+			}
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func systemEventsType(meta *gstypes.MetadataV14) (gstypes.Si1LookupTypeID, error) {
+	for _, p := range meta.Pallets {
+		if p.Name != "System" || !p.HasStorage {
+			continue
+		}
+		for _, item := range p.Storage.Items {
+			if item.Name == "Events" && item.Type.IsPlainType {
+				return item.Type.AsPlainType, nil
+			}
+		}
+	}
+	return gstypes.Si1LookupTypeID{}, fmt.Errorf("System.Events storage item not found in metadata")
+}
+
+// eventAttributes flattens the fields of an event into attributes.
+// Unnamed fields are keyed by their position.
+// Values other than strings are rendered as JSON.
+func eventAttributes(fields any) []blockdb.EventAttribute {
+	var attrs []blockdb.EventAttribute
+	switch fields := fields.(type) {
+	case nil:
+	case scaleComposite:
+		for _, f := range fields {
+			attrs = append(attrs, blockdb.EventAttribute{Key: f.Name, Value: attributeValue(f.Value)})
+		}
+	case []any:
+		for i, v := range fields {
+			attrs = append(attrs, blockdb.EventAttribute{Key: fmt.Sprint(i), Value: attributeValue(v)})
+		}
+	default:
+		attrs = append(attrs, blockdb.EventAttribute{Key: "0", Value: attributeValue(fields)})
+	}
+	return attrs
+}
+
+func attributeValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bz)
+}
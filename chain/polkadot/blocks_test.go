@@ -0,0 +1,195 @@
+package polkadot
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+// testMetadata returns metadata of a minimal runtime with a Balances.transfer call
+// and a Balances.Transfer event.
+func testMetadata() *gstypes.MetadataV14 {
+	id := gstypes.NewSi1LookupTypeIDFromUInt
+	field := func(name string, typ uint64) gstypes.Si1Field {
+		return gstypes.Si1Field{HasName: name != "", Name: gstypes.Text(name), Type: id(typ)}
+	}
+	variant := func(name string, index uint8, fields ...gstypes.Si1Field) gstypes.Si1Variant {
+		return gstypes.Si1Variant{Name: gstypes.Text(name), Index: gstypes.U8(index), Fields: fields}
+	}
+	primitive := func(p gstypes.Si0TypeDefPrimitive) gstypes.Si1TypeDef {
+		return gstypes.Si1TypeDef{IsPrimitive: true, Primitive: gstypes.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}
+	}
+	variants := func(vs ...gstypes.Si1Variant) gstypes.Si1TypeDef {
+		return gstypes.Si1TypeDef{IsVariant: true, Variant: gstypes.Si1TypeDefVariant{Variants: vs}}
+	}
+	composite := func(fields ...gstypes.Si1Field) gstypes.Si1TypeDef {
+		return gstypes.Si1TypeDef{IsComposite: true, Composite: gstypes.Si1TypeDefComposite{Fields: fields}}
+	}
+	array := func(n uint32, typ uint64) gstypes.Si1TypeDef {
+		return gstypes.Si1TypeDef{IsArray: true, Array: gstypes.Si1TypeDefArray{Len: gstypes.U32(n), Type: id(typ)}}
+	}
+	sequence := func(typ uint64) gstypes.Si1TypeDef {
+		return gstypes.Si1TypeDef{IsSequence: true, Sequence: gstypes.Si1TypeDefSequence{Type: id(typ)}}
+	}
+	param := func(name string, typ uint64) gstypes.Si1TypeParameter {
+		return gstypes.Si1TypeParameter{Name: gstypes.Text(name), HasType: true, Type: id(typ)}
+	}
+
+	types := map[int64]*gstypes.Si1Type{
+		0:  {Def: primitive(gstypes.IsU8)},
+		1:  {Def: array(32, 0)},
+		2:  {Path: gstypes.Si1Path{"sp_core", "crypto", "AccountId32"}, Def: composite(field("", 1))},
+		3:  {Def: primitive(gstypes.IsU128)},
+		4:  {Def: gstypes.Si1TypeDef{IsCompact: true, Compact: gstypes.Si1TypeDefCompact{Type: id(3)}}},
+		5:  {Def: variants(variant("transfer", 0, field("dest", 7), field("value", 4)))},
+		6:  {Def: variants(variant("Balances", 5, field("", 5)))},
+		7:  {Def: variants(variant("Id", 0, field("", 2)), variant("Index", 1, field("", 4)))},
+		8:  {Def: variants(variant("Sr25519", 1, field("", 9)))},
+		9:  {Def: array(64, 0)},
+		10: {Def: gstypes.Si1TypeDef{IsTuple: true, Tuple: gstypes.Si1TypeDefTuple{id(13)}}},
+		11: {
+			Path:   gstypes.Si1Path{"sp_runtime", "generic", "unchecked_extrinsic", "UncheckedExtrinsic"},
+			Params: []gstypes.Si1TypeParameter{param("Address", 7), param("Call", 6), param("Signature", 8), param("Extra", 10)},
+			Def:    composite(field("", 12)),
+		},
+		12: {Def: sequence(0)},
+		13: {Def: primitive(gstypes.IsU32)},
+		14: {Def: variants(variant("ApplyExtrinsic", 0, field("", 13)), variant("Finalization", 1), variant("Initialization", 2))},
+		15: {Def: variants(variant("Transfer", 2, field("from", 2), field("to", 2), field("amount", 3)))},
+		16: {Def: variants(variant("Balances", 5, field("", 15)))},
+		17: {Def: sequence(1)},
+		18: {Def: composite(field("phase", 14), field("event", 16), field("topics", 17))},
+		19: {Def: sequence(18)},
+	}
+
+	return &gstypes.MetadataV14{
+		Pallets: []gstypes.PalletMetadataV14{{
+			Name:       "System",
+			HasStorage: true,
+			Storage: gstypes.StorageMetadataV14{
+				Prefix: "System",
+				Items: []gstypes.StorageEntryMetadataV14{{
+					Name: "Events",
+					Type: gstypes.StorageEntryTypeV14{IsPlainType: true, AsPlainType: id(19)},
+				}},
+			},
+		}},
+		Extrinsic:       gstypes.ExtrinsicV14{Type: id(11), Version: 4},
+		EfficientLookup: types,
+	}
+}
+
+var (
+	alice = bytes.Repeat([]byte{0xaa}, 32)
+	bob   = bytes.Repeat([]byte{0xbb}, 32)
+)
+
+func ss58(t *testing.T, key []byte) string {
+	addr, err := EncodeAddressSS58(key)
+	require.NoError(t, err)
+	return addr
+}
+
+func TestDecodeExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	meta := testMetadata()
+
+	t.Run("signed", func(t *testing.T) {
+		var body bytes.Buffer
+		body.WriteByte(0x84) // signed, version 4
+		body.WriteByte(0)    // MultiAddress::Id
+		body.Write(alice)
+		body.WriteByte(1) // MultiSignature::Sr25519
+		body.Write(make([]byte, 64))
+		body.Write([]byte{7, 0, 0, 0}) // extra
+		body.Write([]byte{5, 0})       // Balances.transfer
+		body.WriteByte(0)              // MultiAddress::Id
+		body.Write(bob)
+		body.Write([]byte{0xa1, 0x0f}) // compact 1000
+		require.Equal(t, 140, body.Len())
+
+		// Extrinsics are prefixed with their compact encoded length.
+		xt := append([]byte{(140<<2 | 1) & 0xff, 140 >> 6}, body.Bytes()...)
+
+		got := decodeExtrinsic(meta, 1, "0x"+hex.EncodeToString(xt))
+		require.Empty(t, got.Error)
+
+		bz, err := json.Marshal(got)
+		require.NoError(t, err)
+		want := `{"index":1,"pallet":"Balances","call":"transfer","signer":"` + ss58(t, alice) + `","args":{"dest":{"Id":"` + ss58(t, bob) + `"},"value":1000}}`
+		require.JSONEq(t, want, string(bz))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		// Balances.transfer to MultiAddress::Index(3) of 5.
+		xt := []byte{6 << 2, 0x04, 5, 0, 1, 3 << 2, 5 << 2}
+		got := decodeExtrinsic(meta, 0, "0x"+hex.EncodeToString(xt))
+		require.Empty(t, got.Error)
+		require.Equal(t, "Balances", got.Pallet)
+		require.Equal(t, "transfer", got.Call)
+		require.Nil(t, got.Signer)
+	})
+
+	t.Run("undecodable", func(t *testing.T) {
+		const xt = "0x040500"
+		got := decodeExtrinsic(meta, 2, xt)
+		require.Equal(t, 2, got.Index)
+		require.Contains(t, got.Error, "unsupported extrinsic version 5")
+		require.Equal(t, xt, got.Raw)
+	})
+}
+
+func TestDecodeEvents(t *testing.T) {
+	t.Parallel()
+
+	transfer := func(buf *bytes.Buffer, amount byte) {
+		buf.Write([]byte{5, 2}) // Balances.Transfer
+		buf.Write(alice)
+		buf.Write(bob)
+		buf.WriteByte(amount)
+		buf.Write(make([]byte, 15))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(2 << 2) // two records
+	buf.Write([]byte{0, 1, 0, 0, 0})
+	transfer(&buf, 10)
+	buf.WriteByte(0) // no topics
+	buf.WriteByte(1) // Finalization
+	transfer(&buf, 20)
+	buf.WriteByte(0)
+
+	events, err := decodeEvents(testMetadata(), buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, "ApplyExtrinsic", events[0].Phase)
+	require.Equal(t, 1, events[0].Extrinsic)
+	require.Equal(t, "Balances", events[0].Pallet)
+	require.Equal(t, "Transfer", events[0].Name)
+	require.Equal(t, []blockdb.EventAttribute{
+		{Key: "from", Value: ss58(t, alice)},
+		{Key: "to", Value: ss58(t, bob)},
+		{Key: "amount", Value: "10"},
+	}, eventAttributes(events[0].Fields))
+
+	require.Equal(t, "Finalization", events[1].Phase)
+	require.Equal(t, "20", eventAttributes(events[1].Fields)[2].Value)
+}
+
+func TestEventAttributes(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, eventAttributes(nil))
+	require.Equal(t, []blockdb.EventAttribute{{Key: "0", Value: "7"}}, eventAttributes(uint64(7)))
+	require.Equal(t, []blockdb.EventAttribute{
+		{Key: "0", Value: "a"},
+		{Key: "1", Value: `{"Some":true}`},
+	}, eventAttributes([]any{"a", scaleVariant{Name: "Some", Value: true}}))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"go.uber.org/zap"
 )
@@ -46,6 +47,8 @@ type ParachainNode struct {
 	api         *gsrpc.SubstrateAPI
 	hostWsPort  string
 	hostRpcPort string
+
+	metadata runtimeMetadata
 }
 
 type ParachainNodes []*ParachainNode
@@ -256,7 +259,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 	cmd = append(cmd, "--", fmt.Sprintf("--chain=%s", pn.RawRelayChainSpecFilePathFull()))
 	cmd = append(cmd, pn.RelayChainFlags...)
 
-	return pn.containerLifecycle.CreateContainer(ctx, pn.TestName, pn.NetworkID, pn.Image, exposedPorts, pn.Bind(), pn.HostName(), cmd)
+	return pn.containerLifecycle.CreateContainer(ctx, pn.TestName, pn.NetworkID, pn.Image, exposedPorts, pn.Bind(), pn.HostName(), cmd, pn.Chain.Config().Resources)
 }
 
 // StopContainer stops the relay chain node container, waiting at most 30 seconds.
@@ -298,11 +301,12 @@ func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 func (pn *ParachainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
 	job := dockerutil.NewImage(pn.log, pn.DockerClient, pn.NetworkID, pn.TestName, pn.Image.Repository, pn.Image.Version)
 	opts := dockerutil.ContainerOptions{
-		Binds: pn.Bind(),
-		Env:   env,
-		User:  pn.Image.UidGid,
+		Binds:     pn.Bind(),
+		Env:       env,
+		User:      pn.Image.UidGid,
+		Resources: pn.Chain.Config().Resources,
 	}
-	return job.Run(ctx, cmd, opts)
+	return job.RunReported(ctx, ibc.ChainExecReporterOf(pn.Chain), cmd, opts)
 }
 
 func (pn *ParachainNode) GetBalance(ctx context.Context, address string, denom string) (math.Int, error) {
@@ -394,3 +398,8 @@ func (pn *ParachainNode) MintFunds(
 	pn.log.Info("MintFunds sent", zap.String("hash", fmt.Sprintf("%#x", hash)), zap.String("container", pn.Name()))
 	return nil
 }
+
+// FindTxs returns the extrinsics of the block at height, with the events they emitted.
+func (pn *ParachainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	return findTxs(pn.log, pn.api, &pn.metadata, height)
+}
@@ -42,6 +42,8 @@ type PolkadotChain struct {
 	RelayChainNodes    RelayChainNodes
 	ParachainNodes     []ParachainNodes
 	keyring            keyring.Keyring
+
+	ibc.ChainExecReporterHolder
 }
 
 // PolkadotAuthority is used when constructing the validator authorities in the substrate chain spec.
@@ -241,6 +243,16 @@ func (c *PolkadotChain) NewParachainNode(
 // Implements Chain interface.
 func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
 	relayChainNodes := []*RelayChainNode{}
+	for i := range c.cfg.Images {
+		if err := dockerutil.BuildImage(ctx, c.log, cli, &c.cfg.Images[i]); err != nil {
+			return err
+		}
+	}
+	for i := range c.parachainConfig {
+		if err := dockerutil.BuildImage(ctx, c.log, cli, &c.parachainConfig[i].Image); err != nil {
+			return err
+		}
+	}
 	chainCfg := c.Config()
 	images := []ibc.DockerImage{}
 	images = append(images, chainCfg.Images...)
@@ -248,6 +260,9 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 		images = append(images, parachain.Image)
 	}
 	for _, image := range images {
+		if image.Build != nil {
+			continue
+		}
 		rc, err := cli.ImagePull(
 			ctx,
 			image.Repository+":"+image.Version,
@@ -534,6 +549,8 @@ func (c *PolkadotChain) Exec(ctx context.Context, cmd []string, env []string) ([
 	return res.Stdout, res.Stderr, res.Err
 }
 
+var _ ibc.ChainExecTracker = (*PolkadotChain)(nil)
+
 // GetRPCAddress retrieves the rpc address that can be reached by other containers in the docker network.
 // Implements Chain interface.
 func (c *PolkadotChain) GetRPCAddress() string {
@@ -836,9 +853,31 @@ func (c *PolkadotChain) GetKeyringPair(keyName string) (signature.KeyringPair, e
 	return kp, nil
 }
 
-// FindTxs implements blockdb.BlockSaver (Not implemented yet for polkadot, but we don't want to exit)
+// FindTxs implements blockdb.TxFinder.
+// It returns the decoded extrinsics of the block at height of the first parachain, if any,
+// and of the relay chain otherwise, consistent with Height.
+// The blocks of the relay chain and of every parachain are saved by TxFinders.
 func (c *PolkadotChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
-	return []blockdb.Tx{}, nil
+	if len(c.ParachainNodes) > 0 && len(c.ParachainNodes[0]) > 0 {
+		return c.ParachainNodes[0][0].FindTxs(ctx, height)
+	}
+	return c.RelayChainNodes[0].FindTxs(ctx, height)
+}
+
+// TxFinders implements blockdb.MultiTxFinder.
+// It returns the first node of the relay chain, keyed by the chain ID of the chain,
+// and the first node of each parachain, keyed by the parachain ID.
+func (c *PolkadotChain) TxFinders() map[string]blockdb.TxFinder {
+	finders := make(map[string]blockdb.TxFinder, len(c.ParachainNodes)+1)
+	if len(c.RelayChainNodes) > 0 {
+		finders[c.cfg.ChainID] = c.RelayChainNodes[0]
+	}
+	for _, nodes := range c.ParachainNodes {
+		if len(nodes) > 0 {
+			finders[nodes[0].ChainID] = nodes[0]
+		}
+	}
+	return finders
 }
 
 // GetIbcBalance returns the Coins type of ibc coins in account
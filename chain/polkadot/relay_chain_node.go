@@ -20,6 +20,7 @@ import (
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v2"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 )
 
@@ -45,6 +46,8 @@ type RelayChainNode struct {
 	api         *gsrpc.SubstrateAPI
 	hostWsPort  string
 	hostRpcPort string
+
+	metadata runtimeMetadata
 }
 
 type RelayChainNodes []*RelayChainNode
@@ -224,7 +227,7 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 		fmt.Sprintf("--public-addr=%s", multiAddress),
 		"--base-path", p.NodeHome(),
 	}
-	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), p.HostName(), cmd)
+	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), p.HostName(), cmd, p.Chain.Config().Resources)
 }
 
 // StopContainer stops the relay chain node container, waiting at most 30 seconds.
@@ -269,11 +272,12 @@ func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 func (p *RelayChainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
 	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
-		Binds: p.Bind(),
-		Env:   env,
-		User:  p.Image.UidGid,
+		Binds:     p.Bind(),
+		Env:       env,
+		User:      p.Image.UidGid,
+		Resources: p.Chain.Config().Resources,
 	}
-	return job.Run(ctx, cmd, opts)
+	return job.RunReported(ctx, ibc.ChainExecReporterOf(p.Chain), cmd, opts)
 }
 
 // SendFunds sends funds to a wallet from a user account.
@@ -298,3 +302,8 @@ func (p *RelayChainNode) SendFunds(ctx context.Context, keyName string, amount i
 func (p *RelayChainNode) GetBalance(ctx context.Context, address string, denom string) (math.Int, error) {
 	return GetBalance(p.api, address)
 }
+
+// FindTxs returns the extrinsics of the block at height, with the events they emitted.
+func (p *RelayChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	return findTxs(p.log, p.api, &p.metadata, height)
+}
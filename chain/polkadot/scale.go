@@ -0,0 +1,330 @@
+package polkadot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/misko9/go-substrate-rpc-client/v4/scale"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+)
+
+// scaleDecoder decodes SCALE encoded values without knowing their Go types,
+// by walking the portable type registry of a runtime's V14 metadata.
+//
+// Values decode to:
+//   - scaleComposite for structs with named fields,
+//   - scaleVariant for enums,
+//   - []any for tuples, sequences, arrays, and structs with several unnamed fields,
+//   - a "0x" prefixed hex string for sequences and arrays of bytes,
+//   - an SS58 string for AccountId32,
+//   - bool, string, uint64, or int64 for primitives, with 128 and 256 bit integers as decimal strings.
+//
+// Structs with a single unnamed field decode to the value of that field.
+type scaleDecoder struct {
+	meta *gstypes.MetadataV14
+	r    *bytes.Reader
+	dec  *scale.Decoder
+}
+
+func newScaleDecoder(meta *gstypes.MetadataV14, bz []byte) *scaleDecoder {
+	r := bytes.NewReader(bz)
+	return &scaleDecoder{meta: meta, r: r, dec: scale.NewDecoder(r)}
+}
+
+// scaleVariant is a decoded enum value.
+type scaleVariant struct {
+	Name  string
+	Value any
+}
+
+// MarshalJSON renders variants without fields as their name, e.g. "None",
+// and others as an object keyed by their name, e.g. {"Some":1}.
+func (v scaleVariant) MarshalJSON() ([]byte, error) {
+	if v.Value == nil {
+		return json.Marshal(v.Name)
+	}
+	return json.Marshal(map[string]any{v.Name: v.Value})
+}
+
+type scaleField struct {
+	Name  string
+	Value any
+}
+
+// scaleComposite is a decoded struct with named fields, in declaration order.
+type scaleComposite []scaleField
+
+// MarshalJSON renders the fields as a JSON object, preserving their order.
+func (c scaleComposite) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range c {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (d *scaleDecoder) decode(id gstypes.Si1LookupTypeID) (any, error) {
+	typ, ok := d.meta.EfficientLookup[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found in metadata", id.Int64())
+	}
+
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		if isAccountID32(typ) {
+			var key [32]byte
+			if err := d.dec.Read(key[:]); err != nil {
+				return nil, err
+			}
+			return EncodeAddressSS58(key[:])
+		}
+		return d.decodeFields(def.Composite.Fields)
+
+	case def.IsVariant:
+		idx, err := d.dec.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range def.Variant.Variants {
+			if byte(v.Index) != idx {
+				continue
+			}
+			value, err := d.decodeFields(v.Fields)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v.Name, err)
+			}
+			return scaleVariant{Name: string(v.Name), Value: value}, nil
+		}
+		return nil, fmt.Errorf("variant index %d not found in type %d", idx, id.Int64())
+
+	case def.IsSequence:
+		n, err := d.dec.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsUint64() {
+			return nil, fmt.Errorf("sequence length %s out of range", n)
+		}
+		return d.decodeList(def.Sequence.Type, n.Uint64())
+
+	case def.IsArray:
+		return d.decodeList(def.Array.Type, uint64(def.Array.Len))
+
+	case def.IsTuple:
+		if len(def.Tuple) == 0 {
+			return nil, nil
+		}
+		values := make([]any, len(def.Tuple))
+		for i, elem := range def.Tuple {
+			v, err := d.decode(elem)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+
+	case def.IsPrimitive:
+		return d.decodePrimitive(def.Primitive.Si0TypeDefPrimitive)
+
+	case def.IsCompact:
+		n, err := d.dec.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		return bigValue(n), nil
+
+	case def.IsBitSequence:
+		return d.decodeBitSequence(def.BitSequence)
+	}
+
+	return nil, fmt.Errorf("unsupported definition of type %d", id.Int64())
+}
+
+func (d *scaleDecoder) decodeFields(fields []gstypes.Si1Field) (any, error) {
+	switch {
+	case len(fields) == 0:
+		return nil, nil
+	case len(fields) == 1 && !fields[0].HasName:
+		return d.decode(fields[0].Type)
+	case !fields[0].HasName:
+		values := make([]any, len(fields))
+		for i, f := range fields {
+			v, err := d.decode(f.Type)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	c := make(scaleComposite, len(fields))
+	for i, f := range fields {
+		v, err := d.decode(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		c[i] = scaleField{Name: string(f.Name), Value: v}
+	}
+	return c, nil
+}
+
+func (d *scaleDecoder) decodeList(elem gstypes.Si1LookupTypeID, n uint64) (any, error) {
+	if t, ok := d.meta.EfficientLookup[elem.Int64()]; ok && t.Def.IsPrimitive && t.Def.Primitive.Si0TypeDefPrimitive == gstypes.IsU8 {
+		if n > uint64(d.r.Len()) {
+			return nil, fmt.Errorf("byte sequence length %d exceeds remaining %d bytes", n, d.r.Len())
+		}
+		bz := make([]byte, n)
+		if err := d.dec.Read(bz); err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(bz), nil
+	}
+
+	var values []any
+	for i := uint64(0); i < n; i++ {
+		v, err := d.decode(elem)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (d *scaleDecoder) decodePrimitive(p gstypes.Si0TypeDefPrimitive) (any, error) {
+	switch p {
+	case gstypes.IsBool:
+		b, err := d.dec.ReadOneByte()
+		return b != 0, err
+	case gstypes.IsChar:
+		bz, err := d.read(4)
+		return string(rune(binary.LittleEndian.Uint32(bz))), err
+	case gstypes.IsStr:
+		n, err := d.dec.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsUint64() || n.Uint64() > uint64(d.r.Len()) {
+			return nil, fmt.Errorf("string length %s exceeds remaining %d bytes", n, d.r.Len())
+		}
+		bz, err := d.read(int(n.Uint64()))
+		return string(bz), err
+	case gstypes.IsU8, gstypes.IsU16, gstypes.IsU32, gstypes.IsU64:
+		bz, err := d.read(primitiveSize(p))
+		if err != nil {
+			return nil, err
+		}
+		var buf [8]byte
+		copy(buf[:], bz)
+		return binary.LittleEndian.Uint64(buf[:]), nil
+	case gstypes.IsI8, gstypes.IsI16, gstypes.IsI32, gstypes.IsI64:
+		bz, err := d.read(primitiveSize(p))
+		if err != nil {
+			return nil, err
+		}
+		// Sign extend into 8 bytes.
+		var buf [8]byte
+		if bz[len(bz)-1]&0x80 != 0 {
+			buf = [8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+		}
+		copy(buf[:], bz)
+		return int64(binary.LittleEndian.Uint64(buf[:])), nil
+	case gstypes.IsU128, gstypes.IsU256, gstypes.IsI128, gstypes.IsI256:
+		bz, err := d.read(primitiveSize(p))
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).SetBytes(reversed(bz))
+		if (p == gstypes.IsI128 || p == gstypes.IsI256) && bz[len(bz)-1]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(bz))))
+		}
+		return n.String(), nil
+	}
+	return nil, fmt.Errorf("unsupported primitive %d", p)
+}
+
+func (d *scaleDecoder) decodeBitSequence(def gstypes.Si1TypeDefBitSequence) (any, error) {
+	bits, err := d.dec.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+	store, ok := d.meta.EfficientLookup[def.BitStoreType.Int64()]
+	if !ok || !store.Def.IsPrimitive {
+		return nil, fmt.Errorf("unsupported bit store type %d", def.BitStoreType.Int64())
+	}
+	storeBits := uint64(8 * primitiveSize(store.Def.Primitive.Si0TypeDefPrimitive))
+	if !bits.IsUint64() || bits.Uint64()/8 > uint64(d.r.Len()) {
+		return nil, fmt.Errorf("bit sequence length %s exceeds remaining %d bytes", bits, d.r.Len())
+	}
+	words := (bits.Uint64() + storeBits - 1) / storeBits
+	bz, err := d.read(int(words * storeBits / 8))
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + hex.EncodeToString(bz), nil
+}
+
+func (d *scaleDecoder) read(n int) ([]byte, error) {
+	bz := make([]byte, n)
+	return bz, d.dec.Read(bz)
+}
+
+func primitiveSize(p gstypes.Si0TypeDefPrimitive) int {
+	switch p {
+	case gstypes.IsBool, gstypes.IsU8, gstypes.IsI8:
+		return 1
+	case gstypes.IsU16, gstypes.IsI16:
+		return 2
+	case gstypes.IsChar, gstypes.IsU32, gstypes.IsI32:
+		return 4
+	case gstypes.IsU64, gstypes.IsI64:
+		return 8
+	case gstypes.IsU128, gstypes.IsI128:
+		return 16
+	case gstypes.IsU256, gstypes.IsI256:
+		return 32
+	}
+	return 0
+}
+
+func isAccountID32(typ *gstypes.Si1Type) bool {
+	return len(typ.Path) > 0 && typ.Path[len(typ.Path)-1] == "AccountId32"
+}
+
+// bigValue returns n as a uint64 if it fits, otherwise as a decimal string.
+func bigValue(n *big.Int) any {
+	if n.IsUint64() {
+		return n.Uint64()
+	}
+	return n.String()
+}
+
+func reversed(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	for i, b := range bz {
+		out[len(bz)-1-i] = b
+	}
+	return out
+}
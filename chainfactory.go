@@ -7,9 +7,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
-	"github.com/strangelove-ventures/interchaintest/v7/chain/penumbra"
-	"github.com/strangelove-ventures/interchaintest/v7/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -128,34 +125,25 @@ func buildChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValida
 		nf = *numFullNodes
 	}
 
-	switch cfg.Type {
-	case "cosmos":
-		return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
-	case "penumbra":
-		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
-	case "polkadot":
-		// TODO Clean this up. RelayChain config should only reference cfg.Images[0] and parachains should iterate through the remaining
-		// Maybe just pass everything in like NewCosmosChain and NewPenumbraChain, let NewPolkadotChain figure it out
-		// Or parachains and ICS consumer chains maybe should be their own chain
-		switch {
-		case strings.Contains(cfg.Name, "composable"):
-			parachains := []polkadot.ParachainConfig{{
-				//Bin:             "composable",
-				Bin:     "parachain-node",
-				ChainID: "dev-2000",
-				//ChainID:         "dali-dev",
-				Image:           cfg.Images[1],
-				NumNodes:        nf,
-				Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
-				RelayChainFlags: []string{"--execution=wasm"},
-			}}
-			return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
-		default:
-			return nil, fmt.Errorf("unexpected error, unknown polkadot parachain: %s", cfg.Name)
-		}
-	default:
+	t, ok := chainType(cfg.Type)
+	if !ok {
 		return nil, fmt.Errorf("unexpected error, unknown chain type: %s for chain: %s", cfg.Type, cfg.Name)
 	}
+
+	if cfg.IsNative() && !t.Native {
+		return nil, fmt.Errorf("the native runtime is not supported for chain type %s of chain %s", cfg.Type, cfg.Name)
+	}
+
+	var typeConfig any
+	if t.ParseConfig != nil {
+		var err error
+		typeConfig, err = t.ParseConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t.NewChain(log, testName, cfg, typeConfig, nv, nf)
 }
 
 func (f *BuiltinChainFactory) Name() string {
@@ -167,7 +155,14 @@ func (f *BuiltinChainFactory) Name() string {
 
 		v := s.Version
 		if v == "" {
-			v = cfg.Images[0].Version
+			switch {
+			case cfg.IsNative():
+				v = string(ibc.NativeRuntime)
+			case cfg.Images[0].Build != nil:
+				v = "local"
+			default:
+				v = cfg.Images[0].Version
+			}
 		}
 
 		parts[i] = cfg.Name + "@" + v
@@ -0,0 +1,239 @@
+package interchaintest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// ChainRegistryImage is the repository of the images of chains imported by ImportChainRegistry,
+// followed by the chain name. The heighliner images are tagged with the git tags of the chains.
+const ChainRegistryImage = "ghcr.io/strangelove-ventures/heighliner/"
+
+// ChainRegistryImport is a chain converted from the cosmos chain registry by ImportChainRegistry.
+type ChainRegistryImport struct {
+	// Spec holds the converted ChainConfig,
+	// and the recommended version of the chain from the registry's codebase as Version.
+	Spec *ChainSpec
+
+	// Unmapped lists the fields of the registry files that have no equivalent in the ChainConfig,
+	// e.g. "chain.json: apis", for review.
+	Unmapped []string
+}
+
+// registryChain holds the fields of a chain-registry chain.json that ImportChainRegistry maps.
+type registryChain struct {
+	ChainName    string   `json:"chain_name"`
+	Bech32Prefix string   `json:"bech32_prefix"`
+	DaemonName   string   `json:"daemon_name"`
+	Slip44       *int     `json:"slip44"`
+	KeyAlgos     []string `json:"key_algos"`
+
+	Fees struct {
+		FeeTokens []struct {
+			Denom            string   `json:"denom"`
+			FixedMinGasPrice *float64 `json:"fixed_min_gas_price"`
+			LowGasPrice      *float64 `json:"low_gas_price"`
+			AverageGasPrice  *float64 `json:"average_gas_price"`
+		} `json:"fee_tokens"`
+	} `json:"fees"`
+
+	Staking struct {
+		StakingTokens []struct {
+			Denom string `json:"denom"`
+		} `json:"staking_tokens"`
+		LockDuration struct {
+			Time string `json:"time"`
+		} `json:"lock_duration"`
+	} `json:"staking"`
+
+	Codebase struct {
+		RecommendedVersion string `json:"recommended_version"`
+		Versions           []struct {
+			RecommendedVersion string `json:"recommended_version"`
+		} `json:"versions"`
+	} `json:"codebase"`
+}
+
+// registryAssetList holds the fields of a chain-registry assetlist.json that ImportChainRegistry maps.
+type registryAssetList struct {
+	ChainName string `json:"chain_name"`
+	Assets    []struct {
+		Base string `json:"base"`
+	} `json:"assets"`
+}
+
+// Fields of chain.json and codebase that ImportChainRegistry maps, or uses to check the mapped fields.
+var (
+	mappedRegistryChainFields    = []string{"chain_name", "bech32_prefix", "daemon_name", "slip44", "key_algos", "fees", "staking"}
+	mappedRegistryCodebaseFields = []string{"recommended_version", "versions"}
+)
+
+// Defaults of the fields of an imported ChainConfig that the chain registry does not describe,
+// matching most of the configured chains.
+const (
+	registryGasAdjustment  = 1.3
+	registryGasPrice       = "0.01"
+	registryTrustingPeriod = "504h"
+	registryUidGid         = "1025:1025"
+)
+
+// ImportChainRegistry converts the chain.json and optional assetlist.json of a chain
+// in the cosmos chain registry (https://github.com/cosmos/chain-registry) into a ChainSpec.
+//
+// The name, binary, bech32 prefix, coin type and denom are copied.
+// Gas prices come from the low, fixed minimum or average price of the fee token of the denom,
+// and the trusting period from the unbonding period of the staking token.
+// The image is the heighliner image of the chain, at the recommended version of its codebase.
+// The chain ID is not copied, so that every chain of a test gets a unique chain ID.
+func ImportChainRegistry(chainJSON, assetListJSON []byte) (*ChainRegistryImport, error) {
+	var c registryChain
+	if err := json.Unmarshal(chainJSON, &c); err != nil {
+		return nil, fmt.Errorf("parsing chain.json: %w", err)
+	}
+	if c.ChainName == "" {
+		return nil, errors.New("chain.json has no chain_name")
+	}
+
+	unmapped, err := unmappedRegistryFields(chainJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := ibc.ChainConfig{
+		Type:           "cosmos",
+		Name:           c.ChainName,
+		Bin:            c.DaemonName,
+		Bech32Prefix:   c.Bech32Prefix,
+		GasAdjustment:  registryGasAdjustment,
+		TrustingPeriod: registryTrustingPeriod,
+		Images: []ibc.DockerImage{{
+			Repository: ChainRegistryImage + c.ChainName,
+			UidGid:     registryUidGid,
+		}},
+	}
+
+	if c.Slip44 != nil && *c.Slip44 != 118 {
+		cfg.CoinType = strconv.Itoa(*c.Slip44)
+	}
+
+	if len(c.KeyAlgos) > 0 && (len(c.KeyAlgos) != 1 || c.KeyAlgos[0] != "secp256k1") {
+		unmapped = append(unmapped, "chain.json: key_algos")
+	}
+
+	switch {
+	case len(c.Staking.StakingTokens) > 0:
+		cfg.Denom = c.Staking.StakingTokens[0].Denom
+	case len(c.Fees.FeeTokens) > 0:
+		cfg.Denom = c.Fees.FeeTokens[0].Denom
+	}
+
+	if assetListJSON != nil {
+		var a registryAssetList
+		if err := json.Unmarshal(assetListJSON, &a); err != nil {
+			return nil, fmt.Errorf("parsing assetlist.json: %w", err)
+		}
+		if a.ChainName != "" && a.ChainName != c.ChainName {
+			return nil, fmt.Errorf("assetlist.json is of chain %s, not %s", a.ChainName, c.ChainName)
+		}
+
+		found := false
+		for _, asset := range a.Assets {
+			if cfg.Denom == "" {
+				cfg.Denom = asset.Base
+			}
+			if asset.Base == cfg.Denom {
+				found = true
+				continue
+			}
+			unmapped = append(unmapped, fmt.Sprintf("assetlist.json: assets[%s]", asset.Base))
+		}
+		if !found && cfg.Denom != "" {
+			return nil, fmt.Errorf("denom %s of chain.json is not in assetlist.json", cfg.Denom)
+		}
+	}
+
+	if cfg.Denom == "" {
+		return nil, errors.New("no staking token, fee token or asset to take the denom from")
+	}
+
+	cfg.GasPrices = registryGasPrice + cfg.Denom
+	for _, t := range c.Fees.FeeTokens {
+		if t.Denom != cfg.Denom {
+			continue
+		}
+		for _, p := range []*float64{t.LowGasPrice, t.FixedMinGasPrice, t.AverageGasPrice} {
+			if p != nil && *p > 0 {
+				cfg.GasPrices = strconv.FormatFloat(*p, 'f', -1, 64) + cfg.Denom
+				break
+			}
+		}
+		break
+	}
+
+	if t := c.Staking.LockDuration.Time; t != "" {
+		d, err := time.ParseDuration(t)
+		if err != nil {
+			return nil, fmt.Errorf("parsing staking lock_duration %q: %w", t, err)
+		}
+		cfg.TrustingPeriod = strconv.FormatFloat(d.Hours(), 'f', -1, 64) + "h"
+	}
+
+	version := c.Codebase.RecommendedVersion
+	if version == "" && len(c.Codebase.Versions) > 0 {
+		version = c.Codebase.Versions[len(c.Codebase.Versions)-1].RecommendedVersion
+	}
+
+	sort.Strings(unmapped)
+	return &ChainRegistryImport{
+		Spec: &ChainSpec{
+			Name:        c.ChainName,
+			Version:     version,
+			ChainConfig: cfg,
+		},
+		Unmapped: unmapped,
+	}, nil
+}
+
+// unmappedRegistryFields returns the top-level and codebase fields of chainJSON that ImportChainRegistry does not map.
+func unmappedRegistryFields(chainJSON []byte) ([]string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(chainJSON, &fields); err != nil {
+		return nil, fmt.Errorf("parsing chain.json: %w", err)
+	}
+
+	var unmapped []string
+	for name, raw := range fields {
+		switch {
+		case name == "$schema":
+			// Not a field of the chain.
+		case name == "codebase":
+			var codebase map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &codebase); err != nil {
+				return nil, fmt.Errorf("parsing chain.json codebase: %w", err)
+			}
+			for name := range codebase {
+				if !containsString(mappedRegistryCodebaseFields, name) {
+					unmapped = append(unmapped, "chain.json: codebase."+name)
+				}
+			}
+		case !containsString(mappedRegistryChainFields, name):
+			unmapped = append(unmapped, "chain.json: "+name)
+		}
+	}
+	return unmapped, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
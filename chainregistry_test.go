@@ -0,0 +1,120 @@
+package interchaintest_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// A trimmed chain.json and assetlist.json of osmosis in the cosmos chain registry.
+const (
+	registryChainJSON = `{
+  "$schema": "../chain.schema.json",
+  "chain_name": "osmosis",
+  "status": "live",
+  "chain_id": "osmosis-1",
+  "bech32_prefix": "osmo",
+  "daemon_name": "osmosisd",
+  "key_algos": ["secp256k1"],
+  "slip44": 118,
+  "fees": {
+    "fee_tokens": [
+      {"denom": "uosmo", "fixed_min_gas_price": 0, "low_gas_price": 0.0025, "average_gas_price": 0.025},
+      {"denom": "uion", "fixed_min_gas_price": 0.0001}
+    ]
+  },
+  "staking": {
+    "staking_tokens": [{"denom": "uosmo"}],
+    "lock_duration": {"time": "1209600s"}
+  },
+  "codebase": {
+    "git_repo": "https://github.com/osmosis-labs/osmosis",
+    "recommended_version": "v15.1.2",
+    "binaries": {"linux/amd64": "https://example.com/osmosisd"}
+  },
+  "apis": {"rpc": [{"address": "https://rpc.osmosis.zone"}]}
+}`
+
+	registryAssetListJSON = `{
+  "chain_name": "osmosis",
+  "assets": [
+    {"base": "uosmo", "display": "osmo", "symbol": "OSMO"},
+    {"base": "uion", "display": "ion", "symbol": "ION"}
+  ]
+}`
+)
+
+func TestImportChainRegistry(t *testing.T) {
+	imp, err := interchaintest.ImportChainRegistry([]byte(registryChainJSON), []byte(registryAssetListJSON))
+	require.NoError(t, err)
+
+	require.Equal(t, "osmosis", imp.Spec.Name)
+	require.Equal(t, "v15.1.2", imp.Spec.Version)
+	require.Equal(t, ibc.ChainConfig{
+		Type:           "cosmos",
+		Name:           "osmosis",
+		Bin:            "osmosisd",
+		Bech32Prefix:   "osmo",
+		Denom:          "uosmo",
+		GasPrices:      "0.0025uosmo",
+		GasAdjustment:  1.3,
+		TrustingPeriod: "336h",
+		Images: []ibc.DockerImage{{
+			Repository: "ghcr.io/strangelove-ventures/heighliner/osmosis",
+			UidGid:     "1025:1025",
+		}},
+	}, imp.Spec.ChainConfig)
+
+	require.Equal(t, []string{
+		"assetlist.json: assets[uion]",
+		"chain.json: apis",
+		"chain.json: chain_id",
+		"chain.json: codebase.binaries",
+		"chain.json: codebase.git_repo",
+		"chain.json: status",
+	}, imp.Unmapped)
+
+	// The spec is usable as is.
+	cfg, err := imp.Spec.Config(zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, "v15.1.2", cfg.Images[0].Version)
+
+	t.Run("without assetlist", func(t *testing.T) {
+		imp, err := interchaintest.ImportChainRegistry([]byte(registryChainJSON), nil)
+		require.NoError(t, err)
+		require.Equal(t, "uosmo", imp.Spec.Denom)
+		require.NotContains(t, imp.Unmapped, "assetlist.json: assets[uion]")
+	})
+
+	t.Run("coin type and defaults", func(t *testing.T) {
+		imp, err := interchaintest.ImportChainRegistry([]byte(`{
+  "chain_name": "evmos",
+  "bech32_prefix": "evmos",
+  "daemon_name": "evmosd",
+  "key_algos": ["ethsecp256k1"],
+  "slip44": 60,
+  "fees": {"fee_tokens": [{"denom": "aevmos"}]}
+}`), nil)
+		require.NoError(t, err)
+		require.Equal(t, "60", imp.Spec.CoinType)
+		require.Equal(t, "aevmos", imp.Spec.Denom)
+		require.Equal(t, "0.01aevmos", imp.Spec.GasPrices)
+		require.Equal(t, "504h", imp.Spec.TrustingPeriod)
+		require.Empty(t, imp.Spec.Version)
+		require.Equal(t, []string{"chain.json: key_algos"}, imp.Unmapped)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := interchaintest.ImportChainRegistry([]byte(`{"bech32_prefix": "x"}`), nil)
+		require.EqualError(t, err, "chain.json has no chain_name")
+
+		_, err = interchaintest.ImportChainRegistry([]byte(registryChainJSON), []byte(`{"chain_name": "juno"}`))
+		require.EqualError(t, err, "assetlist.json is of chain juno, not osmosis")
+
+		_, err = interchaintest.ImportChainRegistry([]byte(registryChainJSON), []byte(`{"assets": [{"base": "uion"}]}`))
+		require.EqualError(t, err, "denom uosmo of chain.json is not in assetlist.json")
+	})
+}
@@ -122,8 +122,10 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 // This method is a nop if dbPath is blank.
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
+// Before the first test invocation of the process is recorded in dbPath,
+// test cases selected by retention are deleted from the database.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string, retention BlockDatabaseRetention) error {
 	if len(dbPath) == 0 {
 		// nop
 		return nil
@@ -143,6 +145,21 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		return fmt.Errorf("migrate sqlite database %s; deleting file recommended: %w", dbPath, err)
 	}
 
+	if !retention.IsZero() && firstBlockDatabasePrune(dbPath) {
+		// Failing to prune old test cases does not prevent recording this one.
+		if res, err := blockdb.Prune(ctx, db, retention); err != nil {
+			cs.log.Warn("Failed to prune block database", zap.String("path", dbPath), zap.Error(err))
+		} else if res.TestCases > 0 {
+			cs.log.Info(
+				"Pruned block database",
+				zap.String("path", dbPath),
+				zap.Int64("test_cases", res.TestCases),
+				zap.Int64("size_before", res.SizeBefore),
+				zap.Int64("size_after", res.SizeAfter),
+			)
+		}
+	}
+
 	testCase, err := blockdb.CreateTestCase(ctx, db, testName, gitSha)
 	if err != nil {
 		_ = db.Close()
@@ -151,35 +168,65 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
 	cs.trackerEg = new(errgroup.Group)
-	cs.collectors = make([]*blockdb.Collector, len(cs.chains))
-	i := 0
+	type trackedChain struct {
+		id, typ string
+		finder  blockdb.TxFinder
+	}
+	var tracked []trackedChain
 	for c := range cs.chains {
-		c := c
-		id := c.Config().ChainID
-		finder, ok := c.(blockdb.TxFinder)
-		if !ok {
+		id, typ := c.Config().ChainID, c.Config().Type
+		switch f := c.(type) {
+		case blockdb.MultiTxFinder:
+			// E.g. a polkadot relay chain and its parachains, saved as separate chains.
+			for id, finder := range f.TxFinders() {
+				tracked = append(tracked, trackedChain{id: id, typ: typ, finder: finder})
+			}
+		case blockdb.TxFinder:
+			tracked = append(tracked, trackedChain{id: id, typ: typ, finder: f})
+		default:
 			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height uint64) ([][]byte, error)"`+"\n", id)
 			return nil
 		}
-		j := i // Avoid closure on loop variable.
+	}
+
+	cs.collectors = make([]*blockdb.Collector, len(tracked))
+	for i, tc := range tracked {
+		i, tc := i, tc // Avoid closure on loop variables.
 		cs.trackerEg.Go(func() error {
-			chaindb, err := testCase.AddChain(ctx, id, c.Config().Type)
+			chaindb, err := testCase.AddChain(ctx, tc.id, tc.typ)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to add chain %s to database: %v", id, err)
+				fmt.Fprintf(os.Stderr, "Failed to add chain %s to database: %v", tc.id, err)
 				return nil
 			}
-			log := cs.log.With(zap.String("chain_id", id))
-			collector := blockdb.NewCollector(log, finder, chaindb, 100*time.Millisecond)
-			cs.collectors[j] = collector
+			log := cs.log.With(zap.String("chain_id", tc.id))
+			collector := blockdb.NewCollector(log, tc.finder, chaindb, 100*time.Millisecond)
+			cs.collectors[i] = collector
 			collector.Collect(ctx)
 			return nil
 		})
-		i++
 	}
 
 	return nil
 }
 
+var (
+	prunedBlockDatabasesMu sync.Mutex
+	prunedBlockDatabases   = map[string]bool{}
+)
+
+// firstBlockDatabasePrune reports whether the block database at dbPath has not been pruned yet by this process.
+// Pruning vacuums and checkpoints the database, which fails with SQLITE_BUSY while parallel tests write to it,
+// so it runs only once, before the tests of the process start recording blocks.
+func firstBlockDatabasePrune(dbPath string) bool {
+	prunedBlockDatabasesMu.Lock()
+	defer prunedBlockDatabasesMu.Unlock()
+	if prunedBlockDatabases[dbPath] {
+		return false
+	}
+	prunedBlockDatabases[dbPath] = true
+	return true
+}
+
 // Close frees any resources associated with the chainSet.
 //
 // Currently, it only frees resources from TrackBlocks.
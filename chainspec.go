@@ -24,9 +24,14 @@ type ChainSpec struct {
 	ChainName string
 
 	// Version of the docker image to use.
-	// Must be set.
+	// Must be set, unless the image is built from local source.
 	Version string
 
+	// Build, if set, builds the first image of a cosmos chain from local source,
+	// instead of pulling Version of it.
+	// It is a shorthand for setting Build of the first of ChainConfig.Images.
+	Build *ibc.DockerImageBuild
+
 	// GasAdjustment and NoHostMount are pointers in ChainSpec
 	// so zero-overrides can be detected from omitted overrides.
 	GasAdjustment *float64
@@ -48,9 +53,11 @@ type ChainSpec struct {
 // Config returns the underlying ChainConfig,
 // with any overrides applied.
 func (s *ChainSpec) Config(log *zap.Logger) (*ibc.ChainConfig, error) {
-	if s.Version == "" {
+	if s.Version == "" && s.Build == nil && !s.ChainConfig.IsNative() {
 		// Version must be set at top-level if not set in inlined config.
-		if len(s.ChainConfig.Images) == 0 || s.ChainConfig.Images[0].Version == "" {
+		// Native chains run local binaries and have no images to version,
+		// and built images are versioned by their content.
+		if len(s.ChainConfig.Images) == 0 || (s.ChainConfig.Images[0].Version == "" && s.ChainConfig.Images[0].Build == nil) {
 			return nil, errors.New("ChainSpec.Version must not be empty")
 		}
 	}
@@ -150,56 +157,12 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 	cfg.UsingChainIDFlagCLI = s.UsingChainIDFlagCLI
 
 	// Set the version depending on the chain type.
-	switch cfg.Type {
-	case "cosmos":
-		if s.Version != "" && len(cfg.Images) > 0 {
-			cfg.Images[0].Version = s.Version
-		}
-	case "penumbra":
-		versionSplit := strings.Split(s.Version, ",")
-		if len(versionSplit) != 2 {
-			return nil, errors.New("penumbra version should be comma separated penumbra_version,tendermint_version")
-		}
-		cfg.Images[0].Version = versionSplit[1]
-		cfg.Images[1].Version = versionSplit[0]
-	case "polkadot":
-		// Only set if ChainSpec's Version is set, if not, Version from Images must be set.
-		if s.Version != "" {
-			versionSplit := strings.Split(s.Version, ",")
-			relayChainImageSplit := strings.Split(versionSplit[0], ":")
-			var relayChainVersion string
-			if len(relayChainImageSplit) > 1 {
-				if relayChainImageSplit[0] != "seunlanlege/centauri-polkadot" &&
-					relayChainImageSplit[0] != "polkadot" {
-					return nil, fmt.Errorf("only polkadot is supported as the relay chain node. got: %s", relayChainImageSplit[0])
-				}
-				relayChainVersion = relayChainImageSplit[1]
-			} else {
-				relayChainVersion = relayChainImageSplit[0]
-			}
-			cfg.Images[0].Version = relayChainVersion
-			switch {
-			case strings.Contains(s.Name, "composable"):
-				if len(versionSplit) != 2 {
-					return nil, fmt.Errorf("unexpected composable version: %s. should be comma separated polkadot:version,composable:version", s.Version)
-				}
-				imageSplit := strings.Split(versionSplit[1], ":")
-				if len(imageSplit) != 2 {
-					return nil, fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
-				}
-				if !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
-					return nil, fmt.Errorf("unexpected parachain: %s", imageSplit[0])
-				}
-				cfg.Images[1].Version = imageSplit[1]
-			default:
-				return nil, fmt.Errorf("unexpected parachain: %s", s.Name)
-			}
-		} else {
-			// Ensure there are at least two images and check the 2nd version is populated
-			if len(s.ChainConfig.Images) < 2 || s.ChainConfig.Images[1].Version == "" {
-				return nil, fmt.Errorf("ChainCongfig.Images must be >1 and ChainConfig.Images[1].Version must not be empty")
-			}
-		}
+	applyVersion := applyImageVersion
+	if t, ok := chainType(cfg.Type); ok && t.ApplyVersion != nil {
+		applyVersion = t.ApplyVersion
+	}
+	if err := applyVersion(s, &cfg); err != nil {
+		return nil, err
 	}
 
 	return &cfg, nil
@@ -47,6 +47,21 @@ func TestChainSpec_Config(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("build instead of version", func(t *testing.T) {
+		b := &ibc.DockerImageBuild{Context: "../gaia", Args: map[string]string{"GO_VERSION": "1.20"}}
+		s := interchaintest.ChainSpec{
+			Name: "gaia",
+
+			Build: b,
+		}
+
+		cfg, err := s.Config(zaptest.NewLogger(t))
+		require.NoError(t, err)
+
+		require.Equal(t, b, cfg.Images[0].Build)
+		require.Equal(t, "ghcr.io/strangelove-ventures/heighliner/gaia", cfg.Images[0].Repository)
+	})
+
 	t.Run("consistently generated config", func(t *testing.T) {
 		s := interchaintest.ChainSpec{
 			Name: "gaia",
@@ -0,0 +1,225 @@
+package interchaintest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/penumbra"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/polkadot"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// ChainType describes how BuiltinChainFactory and ChainSpec handle the chains of a ChainConfig.Type.
+// Register a ChainType with RegisterChainType to build custom chain implementations.
+type ChainType struct {
+	// NewChain returns an uninitialized chain of cfg,
+	// with numValidators validators and numFullNodes full nodes.
+	// typeConfig is the result of ParseConfig, or nil if ParseConfig is nil.
+	NewChain func(log *zap.Logger, testName string, cfg ibc.ChainConfig, typeConfig any, numValidators, numFullNodes int) (ibc.Chain, error)
+
+	// ParseConfig parses the settings specific to the chain type from cfg.TypeConfig,
+	// which holds the type-config of the chain's YAML configuration or ChainSpec.
+	// If nil, cfg.TypeConfig is ignored.
+	ParseConfig func(cfg ibc.ChainConfig) (any, error)
+
+	// ApplyVersion sets the versions of the images of cfg from the Version and Build of the ChainSpec.
+	// If nil, they are set on the first image, as for cosmos chains.
+	ApplyVersion func(s *ChainSpec, cfg *ibc.ChainConfig) error
+
+	// Native reports whether chains of this type can run on the native runtime.
+	Native bool
+}
+
+var (
+	chainTypesMu sync.RWMutex
+	chainTypes   = map[string]ChainType{}
+)
+
+// RegisterChainType registers t as the implementation of the chains with ChainConfig.Type typ,
+// replacing any previous registration of typ, including the built-in cosmos, penumbra and polkadot types.
+// It is typically called from an init function of the package implementing the chain.
+func RegisterChainType(typ string, t ChainType) {
+	if t.NewChain == nil {
+		panic(fmt.Errorf("chain type %s registered without NewChain", typ))
+	}
+	chainTypesMu.Lock()
+	defer chainTypesMu.Unlock()
+	chainTypes[typ] = t
+}
+
+func chainType(typ string) (ChainType, bool) {
+	chainTypesMu.RLock()
+	defer chainTypesMu.RUnlock()
+	t, ok := chainTypes[typ]
+	return t, ok
+}
+
+func init() {
+	RegisterChainType("cosmos", ChainType{
+		NewChain: func(log *zap.Logger, testName string, cfg ibc.ChainConfig, _ any, nv, nf int) (ibc.Chain, error) {
+			return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
+		},
+		Native: true,
+	})
+	RegisterChainType("penumbra", ChainType{
+		NewChain: func(log *zap.Logger, testName string, cfg ibc.ChainConfig, _ any, nv, nf int) (ibc.Chain, error) {
+			return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
+		},
+		ApplyVersion: applyPenumbraVersion,
+	})
+	RegisterChainType("polkadot", ChainType{
+		NewChain:     newPolkadotChain,
+		ParseConfig:  parsePolkadotConfig,
+		ApplyVersion: applyPolkadotVersion,
+	})
+}
+
+// applyImageVersion sets the Version and Build of the ChainSpec on the first image of cfg.
+func applyImageVersion(s *ChainSpec, cfg *ibc.ChainConfig) error {
+	if s.Version != "" && len(cfg.Images) > 0 {
+		cfg.Images[0].Version = s.Version
+	}
+	if s.Build != nil {
+		if len(cfg.Images) == 0 {
+			return errors.New("ChainSpec.Build requires an image to build")
+		}
+		cfg.Images[0].Build = s.Build
+	}
+	return nil
+}
+
+func applyPenumbraVersion(s *ChainSpec, cfg *ibc.ChainConfig) error {
+	versionSplit := strings.Split(s.Version, ",")
+	if len(versionSplit) != 2 {
+		return errors.New("penumbra version should be comma separated penumbra_version,tendermint_version")
+	}
+	cfg.Images[0].Version = versionSplit[1]
+	cfg.Images[1].Version = versionSplit[0]
+	return nil
+}
+
+// polkadotTypeConfig is the type-config of polkadot chains.
+type polkadotTypeConfig struct {
+	// Parachains are run along the relay chain.
+	// If empty, the composable parachain of defaultParachains is run.
+	Parachains []parachainTypeConfig `yaml:"parachains"`
+}
+
+// parachainTypeConfig configures a parachain of a polkadot chain.
+type parachainTypeConfig struct {
+	ChainID string `yaml:"chain-id"`
+	Bin     string `yaml:"bin"`
+	// ImageIndex is the index of the parachain image in the images of the chain.
+	// The first image is the relay chain image, so it must be at least 1.
+	ImageIndex int `yaml:"image-index"`
+	// NumNodes defaults to the number of full nodes of the chain.
+	NumNodes        int      `yaml:"num-nodes"`
+	Flags           []string `yaml:"flags"`
+	RelayChainFlags []string `yaml:"relay-chain-flags"`
+}
+
+// defaultParachains are the parachains of polkadot chains without parachains in their type-config,
+// i.e. the composable parachain on the second image.
+var defaultParachains = []parachainTypeConfig{{
+	//Bin:             "composable",
+	Bin:     "parachain-node",
+	ChainID: "dev-2000",
+	//ChainID:         "dali-dev",
+	ImageIndex:      1,
+	Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
+	RelayChainFlags: []string{"--execution=wasm"},
+}}
+
+// parsePolkadotConfig returns the parachains of cfg.TypeConfig, as []polkadot.ParachainConfig.
+// Their NumNodes are zero unless set, for newPolkadotChain to default them.
+func parsePolkadotConfig(cfg ibc.ChainConfig) (any, error) {
+	var tc polkadotTypeConfig
+	if cfg.TypeConfig != nil {
+		bz, err := yaml.Marshal(cfg.TypeConfig)
+		if err != nil {
+			return nil, err
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(bz))
+		dec.KnownFields(true)
+		if err := dec.Decode(&tc); err != nil {
+			return nil, fmt.Errorf("invalid type-config of polkadot chain %s: %w", cfg.Name, err)
+		}
+	}
+	if len(tc.Parachains) == 0 {
+		tc.Parachains = defaultParachains
+	}
+
+	parachains := make([]polkadot.ParachainConfig, len(tc.Parachains))
+	for i, p := range tc.Parachains {
+		if p.ImageIndex < 1 || p.ImageIndex >= len(cfg.Images) {
+			return nil, fmt.Errorf("parachain %s of chain %s: image-index %d must refer to an image after the relay chain image, and the chain has %d images",
+				p.ChainID, cfg.Name, p.ImageIndex, len(cfg.Images))
+		}
+		if p.ChainID == "" || p.Bin == "" {
+			return nil, fmt.Errorf("parachain %d of chain %s: chain-id and bin are required", i, cfg.Name)
+		}
+		parachains[i] = polkadot.ParachainConfig{
+			ChainID:         p.ChainID,
+			Bin:             p.Bin,
+			Image:           cfg.Images[p.ImageIndex],
+			NumNodes:        p.NumNodes,
+			Flags:           p.Flags,
+			RelayChainFlags: p.RelayChainFlags,
+		}
+	}
+	return parachains, nil
+}
+
+func newPolkadotChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, typeConfig any, nv, nf int) (ibc.Chain, error) {
+	parachains := append([]polkadot.ParachainConfig(nil), typeConfig.([]polkadot.ParachainConfig)...)
+	for i := range parachains {
+		if parachains[i].NumNodes == 0 {
+			parachains[i].NumNodes = nf
+		}
+	}
+	return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
+}
+
+func applyPolkadotVersion(s *ChainSpec, cfg *ibc.ChainConfig) error {
+	// Only set if ChainSpec's Version is set, if not, Version from Images must be set.
+	if s.Version == "" {
+		// Ensure there are at least two images and check the 2nd version is populated
+		if len(s.ChainConfig.Images) < 2 || s.ChainConfig.Images[1].Version == "" {
+			return fmt.Errorf("ChainCongfig.Images must be >1 and ChainConfig.Images[1].Version must not be empty")
+		}
+		return nil
+	}
+
+	versionSplit := strings.Split(s.Version, ",")
+	relayChainImageSplit := strings.Split(versionSplit[0], ":")
+	var relayChainVersion string
+	if len(relayChainImageSplit) > 1 {
+		if relayChainImageSplit[0] != "seunlanlege/centauri-polkadot" &&
+			relayChainImageSplit[0] != "polkadot" {
+			return fmt.Errorf("only polkadot is supported as the relay chain node. got: %s", relayChainImageSplit[0])
+		}
+		relayChainVersion = relayChainImageSplit[1]
+	} else {
+		relayChainVersion = relayChainImageSplit[0]
+	}
+	cfg.Images[0].Version = relayChainVersion
+
+	if len(versionSplit) != 2 {
+		return fmt.Errorf("unexpected polkadot version: %s. should be comma separated polkadot:version,parachain_name:version", s.Version)
+	}
+	imageSplit := strings.Split(versionSplit[1], ":")
+	if len(imageSplit) != 2 {
+		return fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
+	}
+	if len(cfg.Images) < 2 || !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
+		return fmt.Errorf("unexpected parachain: %s", imageSplit[0])
+	}
+	cfg.Images[1].Version = imageSplit[1]
+	return nil
+}
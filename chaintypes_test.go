@@ -0,0 +1,151 @@
+package interchaintest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+// fakeChain is a chain of the "fake" chain type registered by TestRegisterChainType.
+type fakeChain struct {
+	ibc.Chain
+
+	cfg                         ibc.ChainConfig
+	sidecarFlags                []string
+	numValidators, numFullNodes int
+}
+
+func (c *fakeChain) Config() ibc.ChainConfig { return c.cfg }
+
+func TestRegisterChainType(t *testing.T) {
+	interchaintest.RegisterChainType("fake", interchaintest.ChainType{
+		NewChain: func(_ *zap.Logger, _ string, cfg ibc.ChainConfig, typeConfig any, nv, nf int) (ibc.Chain, error) {
+			return &fakeChain{cfg: cfg, sidecarFlags: typeConfig.([]string), numValidators: nv, numFullNodes: nf}, nil
+		},
+		ParseConfig: func(cfg ibc.ChainConfig) (any, error) {
+			flags, _ := cfg.TypeConfig["sidecar-flags"].([]any)
+			out := make([]string, len(flags))
+			for i, f := range flags {
+				out[i] = fmt.Sprint(f)
+			}
+			return out, nil
+		},
+		ApplyVersion: func(s *interchaintest.ChainSpec, cfg *ibc.ChainConfig) error {
+			// The fake chain runs a node and a sidecar, versioned node_version,sidecar_version.
+			if s.Version == "" {
+				return nil
+			}
+			for i, v := range strings.Split(s.Version, ",") {
+				cfg.Images[i].Version = v
+			}
+			return nil
+		},
+	})
+
+	fakeConfig := ibc.ChainConfig{
+		Type:           "fake",
+		Name:           "fake",
+		ChainID:        "fake-1",
+		Bin:            "faked",
+		Bech32Prefix:   "fake",
+		Denom:          "ufake",
+		GasPrices:      "0.01ufake",
+		TrustingPeriod: "336h",
+		Images: []ibc.DockerImage{
+			{Repository: "fake/node"},
+			{Repository: "fake/sidecar"},
+		},
+		TypeConfig: map[string]any{"sidecar-flags": []any{"--verbose"}},
+	}
+
+	numValidators := 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Version: "v1.0.0,v0.1.0", ChainConfig: fakeConfig, NumValidators: &numValidators},
+	})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	c := chains[0].(*fakeChain)
+	require.Equal(t, 1, c.numValidators)
+	require.Equal(t, 1, c.numFullNodes)
+	require.Equal(t, "v1.0.0", c.cfg.Images[0].Version)
+	require.Equal(t, "v0.1.0", c.cfg.Images[1].Version)
+	require.Equal(t, []string{"--verbose"}, c.sidecarFlags)
+
+	t.Run("native runtime not supported", func(t *testing.T) {
+		cfg := fakeConfig.Clone()
+		cfg.Runtime = ibc.NativeRuntime
+		cfg.Images = nil
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{{ChainName: "fake", ChainConfig: cfg}})
+		_, err := cf.Chains(t.Name())
+		require.EqualError(t, err, "the native runtime is not supported for chain type fake of chain fake")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		cfg := fakeConfig.Clone()
+		cfg.Type = "unknown"
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{{ChainName: "fake", Version: "v1.0.0", ChainConfig: cfg}})
+		_, err := cf.Chains(t.Name())
+		require.EqualError(t, err, "unexpected error, unknown chain type: unknown for chain: fake")
+	})
+}
+
+func TestPolkadotParachainVersion(t *testing.T) {
+	s := &interchaintest.ChainSpec{
+		Version: "polkadot:v0.9.39,fakepara:v1.2.3",
+		ChainConfig: ibc.ChainConfig{
+			Type:           "polkadot",
+			Name:           "fakepara",
+			ChainID:        "fakepara-1",
+			Bin:            "polkadot",
+			Bech32Prefix:   "fakepara",
+			Denom:          "uDOT",
+			GasPrices:      "0.0uDOT",
+			TrustingPeriod: "336h",
+			Images: []ibc.DockerImage{
+				{Repository: "ghcr.io/strangelove-ventures/heighliner/polkadot"},
+				{Repository: "ghcr.io/example/fakepara"},
+			},
+			TypeConfig: map[string]any{
+				"parachains": []any{
+					map[string]any{"chain-id": "dev-2001", "bin": "fakepara-node", "image-index": 1},
+				},
+			},
+		},
+	}
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{s})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	cfg := chains[0].Config()
+	require.Equal(t, "v0.9.39", cfg.Images[0].Version)
+	require.Equal(t, "v1.2.3", cfg.Images[1].Version)
+
+	// The built-in composable config defines its parachain in its type-config.
+	cf = interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "composable", Version: "polkadot:v0.9.39,composable:v1.2.3"},
+	})
+	_, err = cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	s = &interchaintest.ChainSpec{Name: "composable", Version: "polkadot:v0.9.39,fakepara:v1.2.3"}
+	_, err = s.Config(zaptest.NewLogger(t))
+	require.EqualError(t, err, "unexpected parachain: fakepara")
+
+	t.Run("invalid type-config", func(t *testing.T) {
+		cfg := s.ChainConfig.Clone()
+		cfg.Name = "badpara"
+		cfg.TypeConfig = map[string]any{"parachains": []any{map[string]any{"chain-id": "dev-2001", "bin": "x", "image-index": 2}}}
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "composable", ChainName: "badpara", Version: "polkadot:v0.9.39,composable:v1.2.3", ChainConfig: cfg},
+		})
+		_, err := cf.Chains(t.Name())
+		require.ErrorContains(t, err, "image-index 2 must refer to an image after the relay chain image")
+	})
+}
@@ -0,0 +1,206 @@
+package interchaintest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+)
+
+// blockDBOptions are the flags of the blockdb subcommand.
+type blockDBOptions struct {
+	DatabaseFile string
+
+	List   string // test-cases, chains or queries
+	Export string // a blockdb.Dataset
+	SQL    string
+	Query  string // name of a saved query
+	Save   string // name to save SQL as
+	Prune  blockdb.RetentionPolicy
+
+	TestCaseID int64
+	QueriesDir string
+	Format     string
+	Out        string
+}
+
+// defaultQueriesDir is where saved queries are stored, one NAME.sql file per query.
+func defaultQueriesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		panic(err)
+	}
+	return filepath.Join(home, ".interchaintest", "queries")
+}
+
+// testCaseParam is the named parameter ad-hoc and saved queries can use to refer to the -test-case flag.
+const testCaseParam = "test_case"
+
+// runBlockDB lists, exports, or queries the block database according to opts.
+// Results are written to opts.Out, or to stdout if not set.
+func runBlockDB(ctx context.Context, opts blockDBOptions, stdout io.Writer) error {
+	var modes []string
+	for flag, v := range map[string]string{"-list": opts.List, "-export": opts.Export, "-sql": opts.SQL, "-query": opts.Query} {
+		if v != "" {
+			modes = append(modes, flag)
+		}
+	}
+	if !opts.Prune.IsZero() {
+		modes = append(modes, "-prune-*")
+	}
+	if len(modes) != 1 {
+		sort.Strings(modes)
+		return fmt.Errorf("exactly one of -list, -export, -sql, -query or -prune-* is required, got %d: %s", len(modes), strings.Join(modes, " "))
+	}
+	if opts.Save != "" && opts.SQL == "" {
+		return errors.New("-save requires -sql")
+	}
+
+	if opts.List == "queries" {
+		return listSavedQueries(opts.QueriesDir, stdout)
+	}
+
+	format, err := exportFormat(opts.Format, opts.Out)
+	if err != nil {
+		return err
+	}
+
+	query := opts.SQL
+	if opts.Query != "" {
+		bz, err := os.ReadFile(savedQueryPath(opts.QueriesDir, opts.Query))
+		if err != nil {
+			return fmt.Errorf("read saved query: %w", err)
+		}
+		query = string(bz)
+	}
+
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates and migrates a sqlite file.
+	if _, err := os.Stat(opts.DatabaseFile); err != nil {
+		return err
+	}
+	db, err := blockdb.ConnectDB(ctx, opts.DatabaseFile)
+	if err != nil {
+		return fmt.Errorf("connect to database %s: %w", opts.DatabaseFile, err)
+	}
+	defer db.Close()
+	if err := blockdb.Migrate(db, version.GitSha); err != nil {
+		return fmt.Errorf("migrate database %s: %w", opts.DatabaseFile, err)
+	}
+
+	if !opts.Prune.IsZero() {
+		res, err := blockdb.Prune(ctx, db, opts.Prune)
+		if err != nil {
+			return fmt.Errorf("prune database %s: %w", opts.DatabaseFile, err)
+		}
+		fmt.Fprintf(stdout, "Deleted %d test cases; database size %d -> %d bytes\n", res.TestCases, res.SizeBefore, res.SizeAfter)
+		return nil
+	}
+
+	w := stdout
+	if opts.Out != "" {
+		f, err := os.Create(opts.Out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	q := blockdb.NewQuery(db)
+	var n int
+	switch {
+	case opts.List != "":
+		if opts.List != string(blockdb.DatasetTestCases) && opts.List != string(blockdb.DatasetChains) {
+			return fmt.Errorf("unknown list %q, must be one of test-cases, chains or queries", opts.List)
+		}
+		n, err = q.ExportDataset(ctx, w, format, blockdb.Dataset(opts.List), opts.TestCaseID)
+	case opts.Export != "":
+		dataset, perr := blockdb.ParseDataset(opts.Export)
+		if perr != nil {
+			return perr
+		}
+		n, err = q.ExportDataset(ctx, w, format, dataset, opts.TestCaseID)
+	default:
+		var args []any
+		if strings.Contains(query, ":"+testCaseParam) {
+			args = append(args, sql.Named(testCaseParam, opts.TestCaseID))
+		}
+		n, err = q.Export(ctx, w, format, query, args...)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.Save != "" {
+		if err := saveQuery(opts.QueriesDir, opts.Save, query); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Saved query as %s\n", opts.Save)
+	}
+	if opts.Out != "" {
+		fmt.Fprintf(os.Stderr, "Wrote %d rows to %s\n", n, opts.Out)
+	}
+	return nil
+}
+
+// exportFormat parses format, which defaults to the extension of out,
+// or to a table if out is not set.
+func exportFormat(format, out string) (blockdb.ExportFormat, error) {
+	if format != "" {
+		return blockdb.ParseExportFormat(format)
+	}
+	if out == "" {
+		return blockdb.ExportTable, nil
+	}
+	ext := strings.TrimPrefix(filepath.Ext(out), ".")
+	f, err := blockdb.ParseExportFormat(ext)
+	if err != nil {
+		return "", fmt.Errorf("cannot infer format from %s, set -format", out)
+	}
+	return f, nil
+}
+
+func savedQueryPath(dir, name string) string {
+	return filepath.Join(dir, name+".sql")
+}
+
+func saveQuery(dir, name, query string) error {
+	if strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid query name %q", name)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(savedQueryPath(dir, name), []byte(strings.TrimSpace(query)+"\n"), 0o644)
+}
+
+func listSavedQueries(dir string, w io.Writer) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Fprintf(os.Stderr, "No saved queries in %s\n", dir)
+		return nil
+	}
+	for _, p := range paths {
+		fmt.Fprintln(w, strings.TrimSuffix(filepath.Base(p), ".sql"))
+	}
+	return nil
+}
+
+func datasetNames() string {
+	names := make([]string, len(blockdb.Datasets))
+	for i, d := range blockdb.Datasets {
+		names[i] = string(d)
+	}
+	return strings.Join(names, "|")
+}
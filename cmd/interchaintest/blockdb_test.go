@@ -0,0 +1,112 @@
+package interchaintest
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBlockDB(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "block.db")
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, blockdb.Migrate(db, "sha"))
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestRunBlockDB", "sha")
+	require.NoError(t, err)
+	c, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, c.SaveBlock(ctx, 3, []blockdb.Tx{{Data: []byte("tx1")}}))
+	require.NoError(t, db.Close())
+
+	opts := blockDBOptions{DatabaseFile: dbPath, QueriesDir: filepath.Join(dir, "queries")}
+
+	t.Run("list chains", func(t *testing.T) {
+		o := opts
+		o.List = "chains"
+		var buf bytes.Buffer
+		require.NoError(t, runBlockDB(ctx, o, &buf))
+		require.Contains(t, buf.String(), "chain-a")
+	})
+
+	t.Run("export to file", func(t *testing.T) {
+		o := opts
+		o.Export = "txs"
+		o.TestCaseID = 1
+		o.Out = filepath.Join(dir, "txs.jsonl")
+		require.NoError(t, runBlockDB(ctx, o, new(bytes.Buffer)))
+
+		bz, err := os.ReadFile(o.Out)
+		require.NoError(t, err)
+		require.Contains(t, string(bz), `"tx":"tx1"`)
+	})
+
+	t.Run("save and run query", func(t *testing.T) {
+		o := opts
+		o.SQL = "SELECT block_height FROM v_tx_flattened WHERE test_case_id = :test_case"
+		o.Save = "heights"
+		o.TestCaseID = 1
+		o.Format = "csv"
+		var buf bytes.Buffer
+		require.NoError(t, runBlockDB(ctx, o, &buf))
+		require.Equal(t, "block_height\n3\n", buf.String())
+
+		o = opts
+		o.List = "queries"
+		buf.Reset()
+		require.NoError(t, runBlockDB(ctx, o, &buf))
+		require.Equal(t, "heights", strings.TrimSpace(buf.String()))
+
+		o = opts
+		o.Query = "heights"
+		o.TestCaseID = 2
+		o.Format = "csv"
+		buf.Reset()
+		require.NoError(t, runBlockDB(ctx, o, &buf))
+		require.Equal(t, "block_height\n", buf.String())
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		o := opts
+		o.Prune.GitShas = []string{"other"}
+		var buf bytes.Buffer
+		require.NoError(t, runBlockDB(ctx, o, &buf))
+		require.Contains(t, buf.String(), "Deleted 0 test cases")
+
+		o.Prune.GitShas = []string{"sha"}
+		buf.Reset()
+		require.NoError(t, runBlockDB(ctx, o, &buf))
+		require.Contains(t, buf.String(), "Deleted 1 test cases")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.ErrorContains(t, runBlockDB(ctx, opts, new(bytes.Buffer)), "exactly one of")
+
+		o := opts
+		o.List, o.SQL = "chains", "SELECT 1"
+		require.ErrorContains(t, runBlockDB(ctx, o, new(bytes.Buffer)), "got 2: -list -sql")
+
+		o = opts
+		o.Export = "txs"
+		o.Prune.MaxAge = time.Hour
+		require.ErrorContains(t, runBlockDB(ctx, o, new(bytes.Buffer)), "got 2: -export -prune-*")
+
+		o = opts
+		o.Export = "txs"
+		o.Out = filepath.Join(dir, "txs.xlsx")
+		require.ErrorContains(t, runBlockDB(ctx, o, new(bytes.Buffer)), "cannot infer format")
+
+		o = opts
+		o.Export, o.Save = "txs", "name"
+		require.EqualError(t, runBlockDB(ctx, o, new(bytes.Buffer)), "-save requires -sql")
+	})
+}
@@ -0,0 +1,52 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/client"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+)
+
+// cleanupOptions are the flags of the cleanup subcommand.
+type cleanupOptions struct {
+	DryRun    bool
+	TestNames []string
+	All       bool
+}
+
+// runCleanup removes the docker resources left behind by killed test processes,
+// writing each resource it removes, or would remove with -dry-run, to w.
+// Unless -dry-run is set, either -test or -all is required,
+// as removing the resources of all tests also removes those of running tests.
+func runCleanup(ctx context.Context, opts cleanupOptions, w io.Writer) error {
+	if opts.All && len(opts.TestNames) > 0 {
+		return errors.New("-all and -test are mutually exclusive")
+	}
+	if !opts.All && len(opts.TestNames) == 0 && !opts.DryRun {
+		return errors.New("-test or -all is required: removing the resources of all tests also removes those of running tests")
+	}
+
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return fmt.Errorf("create docker client: %w", err)
+	}
+	cli.NegotiateAPIVersion(ctx)
+
+	rs, err := interchaintest.CleanupDocker(ctx, cli, opts.DryRun, opts.TestNames...)
+
+	verb := "Removed"
+	if opts.DryRun {
+		verb = "Would remove"
+	}
+	for _, r := range rs {
+		fmt.Fprintf(w, "%s %s\n", verb, r)
+	}
+	if len(rs) == 0 && err == nil {
+		fmt.Fprintln(w, "No docker resources of interchaintest found")
+	}
+
+	return err
+}
@@ -0,0 +1,20 @@
+package interchaintest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCleanup_RequiresTestOrAll(t *testing.T) {
+	var out bytes.Buffer
+	err := runCleanup(context.Background(), cleanupOptions{}, &out)
+	require.ErrorContains(t, err, "-test or -all is required")
+
+	err = runCleanup(context.Background(), cleanupOptions{All: true, TestNames: []string{"TestFoo"}}, &out)
+	require.EqualError(t, err, "-all and -test are mutually exclusive")
+
+	require.Empty(t, out.String())
+}
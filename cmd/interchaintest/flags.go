@@ -19,6 +19,18 @@ type mainFlags struct {
 	MatrixFile        string
 	ReportFile        string
 	BlockDatabaseFile string
+	DebugLive         bool
+	DebugRefresh      time.Duration
+	MatrixReportFile  string
+	MatrixOutPrefix   string
+	HTMLReportFile    string
+	HTMLOutFile       string
+	ReproBundle       bool
+	PauseOnFailure    time.Duration
+	RelaunchBundleDir string
+	BlockDB           blockDBOptions
+	Cleanup           cleanupOptions
+	ImportRegistry    importRegistryOptions
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
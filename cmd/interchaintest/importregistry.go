@@ -0,0 +1,151 @@
+package interchaintest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"gopkg.in/yaml.v3"
+)
+
+// importRegistryOptions are the flags of the import-registry subcommand.
+type importRegistryOptions struct {
+	ChainFile     string
+	AssetListFile string
+	Out           string
+	Name          string
+}
+
+// configuredChain is a ChainConfig in the layout of configuredChains.yaml.
+type configuredChain struct {
+	Name           string            `yaml:"name"`
+	Type           string            `yaml:"type"`
+	Bin            string            `yaml:"bin"`
+	Bech32Prefix   string            `yaml:"bech32-prefix"`
+	Denom          string            `yaml:"denom"`
+	GasPrices      string            `yaml:"gas-prices"`
+	GasAdjustment  float64           `yaml:"gas-adjustment"`
+	CoinType       string            `yaml:"coin-type,omitempty"`
+	TrustingPeriod string            `yaml:"trusting-period"`
+	Images         []configuredImage `yaml:"images"`
+	NoHostMount    bool              `yaml:"no-host-mount"`
+}
+
+type configuredImage struct {
+	Repository string `yaml:"repository"`
+	UidGid     string `yaml:"uid-gid"`
+}
+
+// runImportRegistry converts a chain of the cosmos chain registry and appends it to the configured chains file,
+// writing the version to use and the fields that were not mapped to w.
+func runImportRegistry(opts importRegistryOptions, w io.Writer) error {
+	if opts.ChainFile == "" {
+		return errors.New("the -chain flag is required")
+	}
+	out := opts.Out
+	if out == "" {
+		out = os.Getenv("IBCTEST_CONFIGURED_CHAINS")
+	}
+	if out == "" {
+		return errors.New("the -out flag is required when IBCTEST_CONFIGURED_CHAINS is not set")
+	}
+
+	chainJSON, err := os.ReadFile(opts.ChainFile)
+	if err != nil {
+		return err
+	}
+	var assetListJSON []byte
+	if opts.AssetListFile != "" {
+		if assetListJSON, err = os.ReadFile(opts.AssetListFile); err != nil {
+			return err
+		}
+	}
+
+	imp, err := interchaintest.ImportChainRegistry(chainJSON, assetListJSON)
+	if err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = imp.Spec.ChainConfig.Name
+	}
+
+	if err := appendConfiguredChain(out, name, imp.Spec.ChainConfig); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Appended %s to %s\n", name, out)
+	if imp.Spec.Version != "" {
+		fmt.Fprintf(w, "Recommended version: %s\n", imp.Spec.Version)
+	}
+	if len(imp.Unmapped) > 0 {
+		fmt.Fprintln(w, "Not mapped:")
+		for _, f := range imp.Unmapped {
+			fmt.Fprintf(w, "  %s\n", f)
+		}
+	}
+	return nil
+}
+
+// appendConfiguredChain appends cfg under name to the configured chains file at path, creating it if necessary.
+func appendConfiguredChain(path, name string, cfg ibc.ChainConfig) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	var chains map[string]yaml.Node
+	if err := yaml.Unmarshal(existing, &chains); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if _, ok := chains[name]; ok {
+		return fmt.Errorf("%s already configures a chain named %s", path, name)
+	}
+
+	c := configuredChain{
+		Name:           cfg.Name,
+		Type:           cfg.Type,
+		Bin:            cfg.Bin,
+		Bech32Prefix:   cfg.Bech32Prefix,
+		Denom:          cfg.Denom,
+		GasPrices:      cfg.GasPrices,
+		GasAdjustment:  cfg.GasAdjustment,
+		CoinType:       cfg.CoinType,
+		TrustingPeriod: cfg.TrustingPeriod,
+		NoHostMount:    cfg.NoHostMount,
+	}
+	for _, img := range cfg.Images {
+		c.Images = append(c.Images, configuredImage{Repository: img.Repository, UidGid: img.UidGid})
+	}
+
+	var buf bytes.Buffer
+	if len(existing) > 0 {
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+	}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string]configuredChain{name: c}); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,61 @@
+package interchaintest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRunImportRegistry(t *testing.T) {
+	dir := t.TempDir()
+	chainFile := filepath.Join(dir, "chain.json")
+	require.NoError(t, os.WriteFile(chainFile, []byte(`{
+  "chain_name": "juno",
+  "chain_id": "juno-1",
+  "bech32_prefix": "juno",
+  "daemon_name": "junod",
+  "slip44": 118,
+  "fees": {"fee_tokens": [{"denom": "ujuno", "low_gas_price": 0.075}]},
+  "staking": {"staking_tokens": [{"denom": "ujuno"}], "lock_duration": {"time": "2419200s"}},
+  "codebase": {"recommended_version": "v15.0.0"}
+}`), 0o644))
+
+	out := filepath.Join(dir, "configuredChains.yaml")
+	require.NoError(t, os.WriteFile(out, []byte("gaia:\n  name: gaia\n  type: cosmos\n"), 0o644))
+
+	var w bytes.Buffer
+	opts := importRegistryOptions{ChainFile: chainFile, Out: out}
+	require.NoError(t, runImportRegistry(opts, &w))
+	require.Equal(t, "Appended juno to "+out+"\nRecommended version: v15.0.0\nNot mapped:\n  chain.json: chain_id\n", w.String())
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	var chains map[string]ibc.ChainConfig
+	require.NoError(t, yaml.Unmarshal(b, &chains))
+	require.Len(t, chains, 2)
+	require.Equal(t, ibc.ChainConfig{
+		Type:           "cosmos",
+		Name:           "juno",
+		Bin:            "junod",
+		Bech32Prefix:   "juno",
+		Denom:          "ujuno",
+		GasPrices:      "0.075ujuno",
+		GasAdjustment:  1.3,
+		TrustingPeriod: "672h",
+		Images: []ibc.DockerImage{{
+			Repository: "ghcr.io/strangelove-ventures/heighliner/juno",
+			UidGid:     "1025:1025",
+		}},
+	}, chains["juno"])
+
+	require.EqualError(t, runImportRegistry(opts, &w), out+" already configures a chain named juno")
+
+	opts.Name = "juno2"
+	require.NoError(t, runImportRegistry(opts, &w))
+}
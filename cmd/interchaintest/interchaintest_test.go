@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,30 @@ func init() {
 `)
 		debugFlagSet.PrintDefaults()
 		fmt.Fprint(out, `
+  blockdb  List test cases and chains, export their data, run SQL against the block database views, or prune old test cases.
+`)
+		blockDBFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  matrix  Write the relayer and chain conformance matrix of a test report as JSON, Markdown and HTML.
+`)
+		matrixFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  report  Render a test report as a self-contained HTML page.
+`)
+		reportFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  relaunch  Relaunch the chains of a failed test from the reproduction bundle written with -repro-bundle.
+`)
+		relaunchFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  cleanup  Stop and remove the containers, volumes and networks left behind by killed test processes.
+`)
+		cleanupFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  import-registry  Convert a chain of the cosmos chain registry and append it to a configured chains YAML file.
+`)
+		importRegistryFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
   version  Prints git commit that produced executable.
 `)
 	}
@@ -48,7 +73,16 @@ var testMatrix struct {
 	ChainSets [][]*interchaintest.ChainSpec
 }
 
-var debugFlagSet = flag.NewFlagSet("debug", flag.ExitOnError)
+var (
+	debugFlagSet    = flag.NewFlagSet("debug", flag.ExitOnError)
+	blockDBFlagSet  = flag.NewFlagSet("blockdb", flag.ExitOnError)
+	matrixFlagSet   = flag.NewFlagSet("matrix", flag.ExitOnError)
+	reportFlagSet   = flag.NewFlagSet("report", flag.ExitOnError)
+	relaunchFlagSet = flag.NewFlagSet("relaunch", flag.ExitOnError)
+	cleanupFlagSet  = flag.NewFlagSet("cleanup", flag.ExitOnError)
+
+	importRegistryFlagSet = flag.NewFlagSet("import-registry", flag.ExitOnError)
+)
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
@@ -64,11 +98,82 @@ func TestMain(m *testing.M) {
 			os.Exit(1)
 		}
 		os.Exit(0)
+	case "blockdb":
+		if err := runBlockDB(ctx, extraFlags.BlockDB, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to run blockdb: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "matrix":
+		if extraFlags.MatrixReportFile == "" {
+			fmt.Fprintln(os.Stderr, "The -report flag is required")
+			os.Exit(1)
+		}
+		paths, err := writeMatrixReports(extraFlags.MatrixReportFile, extraFlags.MatrixOutPrefix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write matrix: %v\n", err)
+			os.Exit(1)
+		}
+		for _, p := range paths {
+			fmt.Fprintf(os.Stderr, "Wrote matrix to %s\n", p)
+		}
+		os.Exit(0)
+	case "report":
+		if extraFlags.HTMLReportFile == "" {
+			fmt.Fprintln(os.Stderr, "The -report flag is required")
+			os.Exit(1)
+		}
+		out := extraFlags.HTMLOutFile
+		if out == "" {
+			out = strings.TrimSuffix(extraFlags.HTMLReportFile, ".json") + ".html"
+		}
+		if err := writeReport(extraFlags.HTMLReportFile, out, reportFormatHTML); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote report to %s\n", out)
+		os.Exit(0)
+	case "relaunch":
+		if extraFlags.RelaunchBundleDir == "" {
+			fmt.Fprintln(os.Stderr, "The -bundle flag is required")
+			os.Exit(1)
+		}
+		lc, err := extraFlags.Logger()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create logger: %v\n", err)
+			os.Exit(1)
+		}
+		err = runRelaunch(ctx, lc.Logger, extraFlags.RelaunchBundleDir)
+		_ = lc.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to relaunch: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "cleanup":
+		if err := runCleanup(ctx, extraFlags.Cleanup, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to clean up: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "import-registry":
+		if err := runImportRegistry(extraFlags.ImportRegistry, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to import chain: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
 	case "version":
 		fmt.Fprintln(os.Stderr, version.GitSha)
 		os.Exit(0)
 	}
 
+	if extraFlags.ReproBundle {
+		interchaintest.WriteReproBundleOnFailure(true)
+	}
+	if extraFlags.PauseOnFailure > 0 {
+		interchaintest.PauseOnFailure(extraFlags.PauseOnFailure)
+	}
+
 	if err := setUpTestMatrix(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to build test matrix: %v\n", err)
 		os.Exit(1)
@@ -89,6 +194,20 @@ func TestMain(m *testing.M) {
 	if err := reporter.Close(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failure closing test reporter: %v\n", err)
 		// Don't os.Exit here, since we already have an exit code from running the tests.
+	} else {
+		if paths, err := writeMatrixReports(reportPath, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "Failure writing conformance matrix: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Wrote conformance matrix to %s\n", strings.Join(paths, ", "))
+		}
+
+		if convertedReportPath != "" {
+			if err := writeReport(reportPath, convertedReportPath, convertedReportFormat); err != nil {
+				fmt.Fprintf(os.Stderr, "Failure writing %s report: %v\n", convertedReportFormat, err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Wrote %s report to %s\n", convertedReportFormat, convertedReportPath)
+			}
+		}
 	}
 
 	os.Exit(code)
@@ -145,25 +264,48 @@ func validateTestMatrix() error {
 	return nil
 }
 
-var reporter *testreporter.Reporter
+var (
+	reporter *testreporter.Reporter
+
+	// reportPath is the path of the JSON file written by reporter.
+	reportPath string
+
+	// convertedReportPath, if set, is where the report is written in convertedReportFormat once the tests finish.
+	convertedReportPath   string
+	convertedReportFormat reportFormat
+)
 
 func configureTestReporter() error {
-	home, err := os.UserHomeDir()
+	format, fpath, err := parseReportFile(extraFlags.ReportFile)
 	if err != nil {
-		return fmt.Errorf("failed to get user home dir: %w", err)
+		return err
 	}
-	fpath := filepath.Join(home, ".interchaintest", "reports")
-	err = os.MkdirAll(fpath, 0755)
-	if err != nil {
+
+	// The JSON report is always written, as the other formats are derived from it.
+	if format != reportFormatJSON || fpath == "" {
+		if format != reportFormatJSON {
+			convertedReportPath = fpath
+			convertedReportFormat = format
+		}
+
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get user home dir: %w", err)
+		}
+		fpath = filepath.Join(home, ".interchaintest", "reports", fmt.Sprintf("%d.json", time.Now().Unix()))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
 		return fmt.Errorf("mkdirall: %w", err)
 	}
 
-	f, err := os.Create(filepath.Join(fpath, fmt.Sprintf("%d.json", time.Now().Unix())))
+	f, err := os.Create(fpath)
 	if err != nil {
 		return err
 	}
 
 	fmt.Fprintf(os.Stderr, "Writing report to %s\n", f.Name())
+	reportPath = f.Name()
 
 	reporter = testreporter.NewReporter(f)
 	return nil
@@ -176,7 +318,8 @@ func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerF
 	case "hermes":
 		return interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
 	default:
-		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes)", name)
+		// Relayers registered with relayer.Register, e.g. by a package imported into a fork of this command.
+		return interchaintest.NewRelayerFactory(name, logger)
 	}
 }
 
@@ -246,9 +389,52 @@ func addFlags() {
 	flag.StringVar(&extraFlags.LogFile, "log-file", "interchaintest.log", "File to write chain and relayer logs. If a file name, logs written to $HOME/.interchaintest/logs directory. Use 'stderr' or 'stdout' to print logs in line tests.")
 	flag.StringVar(&extraFlags.LogFormat, "log-format", "console", "Chain and relayer log format: console|json")
 	flag.StringVar(&extraFlags.LogLevel, "log-level", "info", "Chain and relayer log level: debug|info|error")
-	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored. Defaults to $HOME/.interchaintest/reports/$TIMESTAMP.json")
+	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored, optionally prefixed with a format: json|junit|html, e.g. junit:report.xml. "+
+		"The JSON report is always written, by default to $HOME/.interchaintest/reports/$TIMESTAMP.json")
+	flag.BoolVar(&extraFlags.ReproBundle, "repro-bundle", false, "Write a reproduction bundle to the artifact directory of every failed test. Relaunch it with the relaunch subcommand.")
+	flag.DurationVar(&extraFlags.PauseOnFailure, "pause-on-failure", 0, "Keep the containers of every failed test running for up to this long, printing how to reach them. Requires a longer -timeout.")
 
 	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	debugFlagSet.BoolVar(&extraFlags.DebugLive, "live", false, "Start in live mode, refreshing the UI while a test is writing to the database. Toggle with the l key.")
+	debugFlagSet.DurationVar(&extraFlags.DebugRefresh, "refresh", time.Second, "Refresh interval of live mode.")
+
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.DatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.List, "list", "", "List test-cases, chains, or saved queries.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.Export, "export", "", "Export a dataset: "+datasetNames()+".")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.SQL, "sql", "", "Run an ad-hoc read-only SQL query. The query may refer to the -test-case flag as :test_case.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.Query, "query", "", "Run the saved query with this name.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.Save, "save", "", "Save the -sql query under this name after running it.")
+	blockDBFlagSet.Int64Var(&extraFlags.BlockDB.TestCaseID, "test-case", 0, "ID of the test case to list or export. Defaults to all test cases.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.QueriesDir, "queries-dir", defaultQueriesDir(), "Directory of saved queries.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.Format, "format", "", "Output format: table|csv|jsonl|parquet. Defaults to the extension of -out, or table.")
+	blockDBFlagSet.StringVar(&extraFlags.BlockDB.Out, "out", "", "Path of the output file. Defaults to stdout.")
+	blockDBFlagSet.DurationVar(&extraFlags.BlockDB.Prune.MaxAge, "prune-max-age", 0, "Delete test cases older than this duration, e.g. 168h, then vacuum the database.")
+	blockDBFlagSet.IntVar(&extraFlags.BlockDB.Prune.KeepPerTestName, "prune-keep-per-test", 0, "Delete all but this many most recent test cases of each test name, then vacuum the database.")
+	blockDBFlagSet.IntVar(&extraFlags.BlockDB.Prune.KeepGitShas, "prune-keep-git-shas", 0, "Delete test cases of all but this many most recently tested git commits, then vacuum the database.")
+	blockDBFlagSet.Func("prune-git-sha", "Delete test cases of this git commit, then vacuum the database. May be repeated or comma separated.", func(v string) error {
+		extraFlags.BlockDB.Prune.GitShas = append(extraFlags.BlockDB.Prune.GitShas, strings.Split(v, ",")...)
+		return nil
+	})
+
+	matrixFlagSet.StringVar(&extraFlags.MatrixReportFile, "report", "", "Path to the test report of a conformance run.")
+	matrixFlagSet.StringVar(&extraFlags.MatrixOutPrefix, "out", "", "Path prefix of the written matrix files. Defaults to the report path without its extension.")
+
+	reportFlagSet.StringVar(&extraFlags.HTMLReportFile, "report", "", "Path to the JSON test report to render.")
+	reportFlagSet.StringVar(&extraFlags.HTMLOutFile, "out", "", "Path of the written HTML page. Defaults to the report path with an .html extension.")
+
+	relaunchFlagSet.StringVar(&extraFlags.RelaunchBundleDir, "bundle", "", "Path to the reproduction bundle directory of a failed test.")
+
+	cleanupFlagSet.BoolVar(&extraFlags.Cleanup.DryRun, "dry-run", false, "Only print the resources that would be removed.")
+	cleanupFlagSet.Func("test", "Only remove the resources of this test and its subtests. May be repeated or comma separated.", func(v string) error {
+		extraFlags.Cleanup.TestNames = append(extraFlags.Cleanup.TestNames, strings.Split(v, ",")...)
+		return nil
+	})
+	cleanupFlagSet.BoolVar(&extraFlags.Cleanup.All, "all", false, "Remove the resources of all tests, including running ones. Required without -test, unless -dry-run is set.")
+
+	importRegistryFlagSet.StringVar(&extraFlags.ImportRegistry.ChainFile, "chain", "", "Path to the chain.json of the chain in a local copy of the chain registry.")
+	importRegistryFlagSet.StringVar(&extraFlags.ImportRegistry.AssetListFile, "assetlist", "", "Path to the assetlist.json of the chain. Optional.")
+	importRegistryFlagSet.StringVar(&extraFlags.ImportRegistry.Out, "out", "", "Path of the configured chains YAML file to append to. Defaults to IBCTEST_CONFIGURED_CHAINS.")
+	importRegistryFlagSet.StringVar(&extraFlags.ImportRegistry.Name, "name", "", "Name of the appended chain. Defaults to the chain_name of the registry.")
 }
 
 func parseFlags() {
@@ -257,6 +443,18 @@ func parseFlags() {
 	case "debug":
 		// Ignore errors because configured with flag.ExitOnError.
 		_ = debugFlagSet.Parse(os.Args[2:])
+	case "blockdb":
+		_ = blockDBFlagSet.Parse(os.Args[2:])
+	case "matrix":
+		_ = matrixFlagSet.Parse(os.Args[2:])
+	case "report":
+		_ = reportFlagSet.Parse(os.Args[2:])
+	case "relaunch":
+		_ = relaunchFlagSet.Parse(os.Args[2:])
+	case "cleanup":
+		_ = cleanupFlagSet.Parse(os.Args[2:])
+	case "import-registry":
+		_ = importRegistryFlagSet.Parse(os.Args[2:])
 	}
 }
 
@@ -299,6 +497,12 @@ func runDebugTerminalUI(ctx context.Context) error {
 
 	app := tview.NewApplication()
 	model := blockdbtui.NewModel(blockdb.NewQuery(db), dbPath, schemaInfo.GitSha, schemaInfo.CreatedAt, testCases)
+	model.SetLive(extraFlags.DebugLive)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go model.Tail(ctx, extraFlags.DebugRefresh, func(f func()) { app.QueueUpdateDraw(f) })
+
 	return app.
 		SetInputCapture(model.Update(ctx)).
 		SetRoot(model.RootView(), true).
@@ -0,0 +1,71 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/docker/docker/client"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"go.uber.org/zap"
+)
+
+// runRelaunch starts the containers of the reproduction bundle in dir,
+// prints where their ports are published, and removes them on interrupt.
+func runRelaunch(ctx context.Context, log *zap.Logger, dir string) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return fmt.Errorf("create docker client: %w", err)
+	}
+	cli.NegotiateAPIVersion(ctx)
+
+	r, err := interchaintest.RelaunchReproBundle(ctx, log, cli, dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.Stop(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to remove relaunched containers: %v\n", err)
+		}
+	}()
+
+	fmt.Fprintf(os.Stderr, "Relaunched %s (failed at %s)\n", r.Bundle.TestName, r.Bundle.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	for _, c := range r.Bundle.Chains {
+		fmt.Fprintf(os.Stderr, "  chain %s (%s)\n", c.Name, c.Config.ChainID)
+		for _, w := range c.RelayerWallets {
+			fmt.Fprintf(os.Stderr, "    relayer %s wallet %s: %s\n", w.Relayer, w.Address, w.Mnemonic)
+		}
+		for _, w := range c.ValidatorWallets {
+			fmt.Fprintf(os.Stderr, "    validator wallet %s\n", w.Address)
+		}
+		for _, w := range c.UserWallets {
+			fmt.Fprintf(os.Stderr, "    user %s wallet %s: %s\n", w.KeyName, w.Address, w.Mnemonic)
+		}
+	}
+	for _, tc := range r.Bundle.Topology.Containers {
+		ports := r.HostPorts[tc.Name]
+		keys := make([]string, 0, len(ports))
+		for p := range ports {
+			keys = append(keys, p)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(os.Stderr, "  container %s (%s)\n", tc.Name, tc.Image)
+		for _, p := range keys {
+			fmt.Fprintf(os.Stderr, "    %s -> %s\n", p, ports[p])
+		}
+	}
+	if r.Bundle.BlockDatabaseFile != "" {
+		fmt.Fprintf(os.Stderr, "Inspect blocks with: interchaintest debug -block-db %s\n", filepath.Join(dir, r.Bundle.BlockDatabaseFile))
+	}
+
+	fmt.Fprintln(os.Stderr, "Press Ctrl-C to stop and remove the containers.")
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+	return nil
+}
@@ -0,0 +1,128 @@
+package interchaintest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/conformance"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+)
+
+// writeMatrixReports reads the test report at reportPath
+// and writes the conformance matrix next to it,
+// as <prefix>.matrix.json, <prefix>.matrix.md and <prefix>.matrix.html.
+// If prefix is empty, the report path without its extension is used.
+// The paths of the written files are returned.
+func writeMatrixReports(reportPath, prefix string) ([]string, error) {
+	f, err := os.Open(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	msgs, err := testreporter.ReadMessages(f)
+	if err != nil {
+		return nil, fmt.Errorf("read report %s: %w", reportPath, err)
+	}
+	mx := conformance.NewMatrix(msgs)
+
+	if prefix == "" {
+		prefix = strings.TrimSuffix(reportPath, ".json")
+	}
+
+	var paths []string
+	for _, out := range []struct {
+		ext   string
+		write func(io.Writer) error
+	}{
+		{ext: ".matrix.json", write: mx.WriteJSON},
+		{ext: ".matrix.md", write: mx.WriteMarkdown},
+		{ext: ".matrix.html", write: mx.WriteHTML},
+	} {
+		p := prefix + out.ext
+		if err := writeFile(p, out.write); err != nil {
+			return paths, fmt.Errorf("write %s: %w", p, err)
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type reportFormat string
+
+const (
+	reportFormatJSON  reportFormat = "json"
+	reportFormatJUnit reportFormat = "junit"
+	reportFormatHTML  reportFormat = "html"
+)
+
+// parseReportFile parses the value of the -report-file flag,
+// which is a path optionally prefixed by a format and a colon, e.g. "junit:report.xml" or "html:report.html".
+// Without a format prefix, the JSON format is used.
+func parseReportFile(v string) (reportFormat, string, error) {
+	before, after, found := strings.Cut(v, ":")
+	if !found {
+		return reportFormatJSON, v, nil
+	}
+
+	switch f := reportFormat(before); f {
+	case reportFormatJSON, reportFormatJUnit, reportFormatHTML:
+		if after == "" {
+			return "", "", fmt.Errorf("report file: missing path after format %q", f)
+		}
+		return f, after, nil
+	default:
+		// Not a known format, so treat the whole value as a path.
+		return reportFormatJSON, v, nil
+	}
+}
+
+// writeReport reads the test report at reportPath and writes it to outPath in the given format,
+// which must be reportFormatJUnit or reportFormatHTML.
+func writeReport(reportPath, outPath string, format reportFormat) error {
+	var write func(io.Writer, []testreporter.Message) error
+	switch format {
+	case reportFormatJUnit:
+		write = testreporter.WriteJUnit
+	case reportFormatHTML:
+		write = testreporter.WriteHTML
+	default:
+		return fmt.Errorf("cannot convert report to format %q", format)
+	}
+
+	f, err := os.Open(reportPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	msgs, err := testreporter.ReadMessages(f)
+	if err != nil {
+		return fmt.Errorf("read report %s: %w", reportPath, err)
+	}
+
+	if dir := filepath.Dir(outPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("mkdirall: %w", err)
+		}
+	}
+
+	return writeFile(outPath, func(w io.Writer) error {
+		return write(w, msgs)
+	})
+}
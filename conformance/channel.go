@@ -0,0 +1,315 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+// ChannelCloseOpts are the options for the channel that TestRelayerChannelClose opens
+// and then closes through the relayer's ibc.ChannelCloser implementation.
+//
+// ICS-20 transfer and interchain accounts channels reject user-initiated closing,
+// so the default targets the ibc-go mock application registered in the ibc-go simd chain.
+// Closing this channel is skipped when no application is bound to its port on the chain pair under test;
+// the closing of an ordered channel after a timeout is tested by TestRelayerOrderedChannels.
+var ChannelCloseOpts = ibc.CreateChannelOptions{
+	SourcePortName: "mock",
+	DestPortName:   "mock",
+	Order:          ibc.Unordered,
+	Version:        "mock-version",
+}
+
+// ChannelUpgradeOpts are the upgrade fields proposed by TestRelayerChannelUpgrade
+// against a default ICS-20 transfer channel.
+// The default adds the ICS-29 fee middleware to the channel.
+//
+// ibc-go only accepts the upgrade init from the governance authority of the chain,
+// so the upgrade is proposed in a governance proposal on the first chain, which all its validators vote for.
+// The voting period of the chain must let the proposal pass within pollHeightMax blocks.
+var ChannelUpgradeOpts = ibc.ChannelUpgradeOptions{
+	Version: `{"fee_version":"ics29-1","app_version":"ics20-1"}`,
+}
+
+// upgradeProposalDeposit is the deposit of the proposal initiating the channel upgrade, in the denom of the chain.
+const upgradeProposalDeposit = "1000000000"
+
+// TestRelayerChannelClose asserts that both ends of a channel are reported as closed by GetChannels
+// after the relayer closes a channel described by ChannelCloseOpts through its ibc.ChannelCloser implementation.
+func TestRelayerChannelClose(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	r, c0, c1, pathName := buildLinkedChainPair(t, ctx, cf, rf, rep)
+
+	t.Run("close", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.ChannelClose)
+
+		req := require.New(rep.TestifyT(t))
+		eRep := rep.RelayerExecReporter(t)
+
+		closer, ok := r.(ibc.ChannelCloser)
+		req.Truef(ok, "relayer %s reports the ChannelClose capability but does not implement ibc.ChannelCloser", rf.Name())
+
+		// The transfer channel created while linking the path cannot be closed,
+		// so open another channel on the same connection for an application that allows closing.
+		err := r.CreateChannel(ctx, eRep, pathName, ChannelCloseOpts)
+		if isUnboundPort(err) {
+			rep.TrackSkip(t, "skipping because no application is bound to port %s: %v", ChannelCloseOpts.SourcePortName, err)
+		}
+		req.NoError(err, "failed to create channel on port %s", ChannelCloseOpts.SourcePortName)
+
+		ch0, ch1 := getChannelPair(t, ctx, rep, r, c0, c1, ChannelCloseOpts.SourcePortName)
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch0.State})
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch1.State})
+
+		req.NoError(closer.CloseChannel(ctx, eRep, pathName, ch0.ChannelID, ch0.PortID))
+
+		// Let both chains commit the handshake messages before querying.
+		req.NoError(testutil.WaitForBlocks(ctx, 2, c0, c1))
+
+		closed0, closed1 := getChannelPair(t, ctx, rep, r, c0, c1, ChannelCloseOpts.SourcePortName)
+		req.Equal(ch0.ChannelID, closed0.ChannelID)
+		req.Equal(ch1.ChannelID, closed1.ChannelID)
+		req.Subset([]string{"STATE_CLOSED", "Closed"}, []string{closed0.State})
+		req.Subset([]string{"STATE_CLOSED", "Closed"}, []string{closed1.State})
+	})
+}
+
+// isUnboundPort reports whether err is the rejection of a channel handshake on a port no application is bound to.
+func isUnboundPort(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "could not retrieve module from port-id")
+}
+
+// TestRelayerChannelUpgrade upgrades the default ICS-20 transfer channel with ChannelUpgradeOpts
+// through the relayer's ibc.ChannelUpgrader implementation,
+// and asserts that both channel ends report the new version and remain open.
+func TestRelayerChannelUpgrade(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.ChannelUpgrade)
+
+	req := require.New(rep.TestifyT(t))
+	req.NoError(ChannelUpgradeOpts.Validate())
+
+	r, c0, c1, pathName := buildLinkedChainPair(t, ctx, cf, rf, rep)
+
+	upgrader, ok := r.(ibc.ChannelUpgrader)
+	req.Truef(ok, "relayer %s reports the ChannelUpgrade capability but does not implement ibc.ChannelUpgrader", rf.Name())
+
+	ch0, ch1 := getChannelPair(t, ctx, rep, r, c0, c1, "transfer")
+	req.Equal("ics20-1", ch0.Version)
+	req.Equal("ics20-1", ch1.Version)
+
+	t.Run("upgrade", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		c, ok := c0.(*cosmos.CosmosChain)
+		if !ok {
+			rep.TrackSkip(t, "skipping because channel upgrades are initiated through cosmos governance, and %s is a %T", c0.Config().ChainID, c0)
+		}
+
+		user := interchaintest.GetAndFundTestUsers(t, ctx, "upgrade", userFaucetFund, c)[0]
+		err := initChannelUpgrade(ctx, c, user, ch0, ChannelUpgradeOpts)
+		if errors.Is(err, errChannelUpgradeUnsupported) {
+			rep.TrackSkip(t, "skipping because %s does not support channel upgrades: %v", c0.Config().ChainID, err)
+		}
+		req.NoError(err, "failed to initiate the channel upgrade")
+
+		req.NoError(upgrader.UpgradeChannel(ctx, rep.RelayerExecReporter(t), pathName, ch0.ChannelID, ch0.PortID, ChannelUpgradeOpts))
+
+		// Let both chains commit the handshake messages before querying.
+		req.NoError(testutil.WaitForBlocks(ctx, 2, c0, c1))
+
+		upgraded0, upgraded1 := getChannelPair(t, ctx, rep, r, c0, c1, "transfer")
+		req.Equal(ch0.ChannelID, upgraded0.ChannelID)
+		req.Equal(ch1.ChannelID, upgraded1.ChannelID)
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{upgraded0.State})
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{upgraded1.State})
+		req.Equal(ChannelUpgradeOpts.Version, upgraded0.Version)
+		req.Equal(ChannelUpgradeOpts.Version, upgraded1.Version)
+	})
+}
+
+// errChannelUpgradeUnsupported is returned by initChannelUpgrade for chains without the ibc-go channel upgrade messages.
+var errChannelUpgradeUnsupported = errors.New("MsgChannelUpgradeInit is unknown to the chain")
+
+// initChannelUpgrade initiates the upgrade of ch on c to opts, with a governance proposal submitted by user,
+// and waits for the proposal to pass.
+func initChannelUpgrade(ctx context.Context, c *cosmos.CosmosChain, user ibc.Wallet, ch ibc.ChannelOutput, opts ibc.ChannelUpgradeOptions) error {
+	authority, err := sdk.Bech32ifyAddressBytes(c.Config().Bech32Prefix, authtypes.NewModuleAddress(govtypes.ModuleName))
+	if err != nil {
+		return err
+	}
+
+	ordering := opts.Order
+	if ordering == ibc.Invalid {
+		ordering = ibc.Unordered
+		if strings.Contains(strings.ToLower(ch.Ordering), "ordered") && !strings.Contains(strings.ToLower(ch.Ordering), "unordered") {
+			ordering = ibc.Ordered
+		}
+	}
+	connectionHops := opts.ConnectionHops
+	if len(connectionHops) == 0 {
+		connectionHops = ch.ConnectionHops
+	}
+
+	msg, err := json.Marshal(map[string]any{
+		"@type":      "/ibc.core.channel.v1.MsgChannelUpgradeInit",
+		"port_id":    ch.PortID,
+		"channel_id": ch.ChannelID,
+		"fields": map[string]any{
+			"ordering":        "ORDER_" + strings.ToUpper(ordering.String()),
+			"connection_hops": connectionHops,
+			"version":         opts.Version,
+		},
+		"signer": authority,
+	})
+	if err != nil {
+		return err
+	}
+
+	prop, err := c.SubmitProposal(ctx, user.KeyName(), cosmos.TxProposalv1{
+		Messages: []json.RawMessage{msg},
+		Metadata: "none",
+		Deposit:  upgradeProposalDeposit + c.Config().Denom,
+		Title:    "Upgrade channel " + ch.ChannelID,
+		Summary:  "Upgrade channel " + ch.ChannelID + " on port " + ch.PortID + " to version " + opts.Version,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "unable to resolve type URL") {
+			return fmt.Errorf("%w: %v", errChannelUpgradeUnsupported, err)
+		}
+		return err
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.VoteOnProposalAllValidators(ctx, prop.ProposalID, cosmos.ProposalVoteYes); err != nil {
+		return fmt.Errorf("failed to vote on proposal %s: %w", prop.ProposalID, err)
+	}
+	if _, err := cosmos.PollForProposalStatus(ctx, c, height, height+pollHeightMax, prop.ProposalID, cosmos.ProposalStatusPassed); err != nil {
+		return fmt.Errorf("proposal %s did not pass within %d blocks, check the voting period of the chain: %w", prop.ProposalID, pollHeightMax, err)
+	}
+	return nil
+}
+
+// buildLinkedChainPair starts the two chains from cf and links them with a default transfer channel,
+// returning the relayer and the name of the linked path.
+func buildLinkedChainPair(
+	t *testing.T,
+	ctx context.Context,
+	cf interchaintest.ChainFactory,
+	rf interchaintest.RelayerFactory,
+	rep *testreporter.Reporter,
+) (r ibc.Relayer, c0, c1 ibc.Chain, pathName string) {
+	t.Helper()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 = chains[0], chains[1]
+
+	r = rf.Build(t, client, network)
+
+	pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	req.NoError(ic.Build(ctx, rep.RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		T:         t,
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	return r, c0, c1, pathName
+}
+
+// getChannelPair returns the single channel bound to portID on c0, along with its counterparty on c1.
+func getChannelPair(
+	t *testing.T,
+	ctx context.Context,
+	rep *testreporter.Reporter,
+	r ibc.Relayer,
+	c0, c1 ibc.Chain,
+	portID string,
+) (ch0, ch1 ibc.ChannelOutput) {
+	t.Helper()
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	// GetChannels takes around two seconds with rly,
+	// so getting the channels concurrently is a measurable speedup.
+	eg, egCtx := errgroup.WithContext(ctx)
+	var channels0, channels1 []ibc.ChannelOutput
+	eg.Go(func() error {
+		var err error
+		channels0, err = r.GetChannels(egCtx, eRep, c0.Config().ChainID)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		channels1, err = r.GetChannels(egCtx, eRep, c1.Config().ChainID)
+		return err
+	})
+	req.NoError(eg.Wait(), "failure retrieving channels")
+
+	var found bool
+	for _, c := range channels0 {
+		if c.PortID == portID {
+			req.False(found, "found multiple channels on port %s", portID)
+			ch0, found = c, true
+		}
+	}
+	req.True(found, "no channel on port %s found on %s", portID, c0.Config().ChainID)
+
+	found = false
+	for _, c := range channels1 {
+		if c.PortID == ch0.Counterparty.PortID && c.ChannelID == ch0.Counterparty.ChannelID {
+			ch1, found = c, true
+		}
+	}
+	req.True(found, "counterparty of channel %s not found on %s", ch0.ChannelID, c1.Config().ChainID)
+
+	return ch0, ch1
+}
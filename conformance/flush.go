@@ -54,6 +54,7 @@ func TestRelayerFlushing(t *testing.T, ctx context.Context, cf interchaintest.Ch
 
 	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
 		TestName:  t.Name(),
+		T:         t,
 		Client:    client,
 		NetworkID: network,
 	}))
@@ -0,0 +1,418 @@
+package conformance
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+)
+
+// chainPairsTestName is the name of the subtest of Test under which all chain pair tests run.
+// Go replaces spaces in subtest names with underscores.
+const chainPairsTestName = "chain_pairs"
+
+// MatrixResult is the outcome of a single test case for a relayer and chain pair.
+type MatrixResult string
+
+const (
+	MatrixPass MatrixResult = "pass"
+	MatrixFail MatrixResult = "fail"
+	MatrixSkip MatrixResult = "skip"
+)
+
+// MatrixEntry is the result of one test case, run by one relayer against one chain pair.
+type MatrixEntry struct {
+	Relayer   string
+	ChainPair string
+	TestCase  string
+
+	Result MatrixResult
+
+	// MissingCapabilities lists the relayer capabilities whose absence caused the test case to be skipped.
+	MissingCapabilities []string `json:",omitempty"`
+
+	// Message is the skip reason for skipped test cases,
+	// or the first error reported by a failed test case.
+	Message string `json:",omitempty"`
+
+	Duration time.Duration
+}
+
+// Matrix is a relayer by chain pair by test case view of a conformance run.
+// Build a Matrix from the messages of a testreporter.Reporter passed to Test, with NewMatrix.
+type Matrix struct {
+	Relayers   []string
+	ChainPairs []string
+	TestCases  []string
+
+	// Entries are sorted by chain pair, test case, then relayer.
+	Entries []MatrixEntry
+}
+
+// NewMatrix builds a Matrix from the messages tracked during a call to Test,
+// as returned by testreporter.ReadMessages.
+//
+// Each conformance test function run under a relayer, such as "conformance" or "channel_close", is a test case.
+// Its result rolls up the results of its subtests:
+// it fails if any of them failed, and is skipped if it or all of its innermost subtests were skipped.
+// Tests that were not run under Test are ignored.
+func NewMatrix(msgs []testreporter.Message) Matrix {
+	type testState struct {
+		begun       time.Time
+		finish      *testreporter.FinishTestMessage
+		skipMsg     string
+		firstErr    string
+		firstErrIdx int
+	}
+
+	var order []string
+	tests := make(map[string]*testState)
+	get := func(name string) *testState {
+		s, ok := tests[name]
+		if !ok {
+			s = new(testState)
+			tests[name] = s
+			order = append(order, name)
+		}
+		return s
+	}
+
+	for i, m := range msgs {
+		switch m := m.(type) {
+		case testreporter.BeginTestMessage:
+			get(m.Name).begun = m.StartedAt
+		case testreporter.FinishTestMessage:
+			get(m.Name).finish = &m
+		case testreporter.TestSkipMessage:
+			get(m.Name).skipMsg = m.Message
+		case testreporter.TestErrorMessage:
+			if s := get(m.Name); s.firstErr == "" {
+				s.firstErr = m.Message
+				s.firstErrIdx = i
+			}
+		}
+	}
+
+	// A subtest is innermost if no other tracked test is nested within it,
+	// possibly through intermediate subtests that were not tracked.
+	hasChildren := make(map[string]bool)
+	for _, name := range order {
+		for i := range name {
+			if name[i] == '/' {
+				hasChildren[name[:i]] = true
+			}
+		}
+	}
+
+	type caseKey struct {
+		chainPair, relayer, testCase string
+	}
+	type caseState struct {
+		// top is the state of the test case itself, or nil if only its subtests were tracked.
+		top *testState
+		// subtests are the tracked subtests of the test case, by name relative to the test case.
+		subtests     []*testState
+		subtestNames []string
+		innermost    []bool
+	}
+	var caseOrder []caseKey
+	cases := make(map[caseKey]*caseState)
+	for _, name := range order {
+		chainPair, relayerName, rest, ok := splitConformanceTestName(name)
+		if !ok {
+			continue
+		}
+		testCase, subtest, isSubtest := strings.Cut(rest, "/")
+
+		k := caseKey{chainPair: chainPair, relayer: relayerName, testCase: testCase}
+		c, ok := cases[k]
+		if !ok {
+			c = new(caseState)
+			cases[k] = c
+			caseOrder = append(caseOrder, k)
+		}
+		if !isSubtest {
+			c.top = tests[name]
+			continue
+		}
+		c.subtests = append(c.subtests, tests[name])
+		c.subtestNames = append(c.subtestNames, subtest)
+		c.innermost = append(c.innermost, !hasChildren[name])
+	}
+
+	var (
+		mx         Matrix
+		relayers   = make(map[string]bool)
+		chainPairs = make(map[string]bool)
+		testCases  = make(map[string]bool)
+	)
+	for _, k := range caseOrder {
+		c := cases[k]
+		all := c.subtests
+		if c.top != nil {
+			all = append([]*testState{c.top}, all...)
+		}
+
+		var (
+			failed, unfinished bool
+			firstErr           *testState
+			begun, finished    time.Time
+		)
+		for _, s := range all {
+			if s.finish == nil {
+				unfinished = true
+			} else {
+				failed = failed || s.finish.Failed
+				if finished.Before(s.finish.FinishedAt) {
+					finished = s.finish.FinishedAt
+				}
+			}
+			if s.firstErr != "" && (firstErr == nil || s.firstErrIdx < firstErr.firstErrIdx) {
+				firstErr = s
+			}
+			if !s.begun.IsZero() && (begun.IsZero() || s.begun.Before(begun)) {
+				begun = s.begun
+			}
+		}
+
+		var skipped, skipMsgs []string
+		innermost := 0
+		for i, s := range c.subtests {
+			if !c.innermost[i] {
+				continue
+			}
+			innermost++
+			if s.finish != nil && s.finish.Skipped {
+				skipped = append(skipped, c.subtestNames[i])
+				skipMsgs = append(skipMsgs, s.skipMsg)
+			}
+		}
+
+		e := MatrixEntry{
+			Relayer:   k.relayer,
+			ChainPair: k.chainPair,
+			TestCase:  k.testCase,
+		}
+		switch {
+		case failed:
+			e.Result = MatrixFail
+			if firstErr != nil {
+				e.Message = firstErr.firstErr
+			}
+		case unfinished:
+			e.Result = MatrixFail
+			e.Message = "test did not finish"
+		case c.top != nil && c.top.finish.Skipped:
+			e.Result = MatrixSkip
+			e.Message = c.top.skipMsg
+			e.MissingCapabilities = parseMissingCapabilities(c.top.skipMsg)
+		case innermost > 0 && len(skipped) == innermost:
+			e.Result = MatrixSkip
+			e.Message = skipMsgs[0]
+			e.MissingCapabilities = skippedCapabilities(skipMsgs)
+		default:
+			e.Result = MatrixPass
+			if len(skipped) > 0 {
+				e.Message = "skipped " + strings.Join(skipped, ", ")
+			}
+		}
+		if !unfinished && !begun.IsZero() {
+			e.Duration = finished.Sub(begun)
+		}
+
+		mx.Entries = append(mx.Entries, e)
+		relayers[k.relayer] = true
+		chainPairs[k.chainPair] = true
+		testCases[k.testCase] = true
+	}
+
+	mx.Relayers = sortedKeys(relayers)
+	mx.ChainPairs = sortedKeys(chainPairs)
+	mx.TestCases = sortedKeys(testCases)
+
+	sort.SliceStable(mx.Entries, func(i, j int) bool {
+		a, b := mx.Entries[i], mx.Entries[j]
+		if a.ChainPair != b.ChainPair {
+			return a.ChainPair < b.ChainPair
+		}
+		if a.TestCase != b.TestCase {
+			return a.TestCase < b.TestCase
+		}
+		return a.Relayer < b.Relayer
+	})
+
+	return mx
+}
+
+// Entry returns the entry for the given chain pair, test case and relayer,
+// and whether such an entry exists.
+func (mx Matrix) Entry(chainPair, testCase, relayerName string) (MatrixEntry, bool) {
+	for _, e := range mx.Entries {
+		if e.ChainPair == chainPair && e.TestCase == testCase && e.Relayer == relayerName {
+			return e, true
+		}
+	}
+	return MatrixEntry{}, false
+}
+
+// WriteJSON writes the matrix as indented JSON.
+func (mx Matrix) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(mx)
+}
+
+// WriteMarkdown writes one table per chain pair, with a row per test case and a column per relayer.
+func (mx Matrix) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# Conformance matrix\n")
+
+	for _, cp := range mx.ChainPairs {
+		fmt.Fprintf(&sb, "\n## %s\n\n", cp)
+
+		sb.WriteString("| Test case |")
+		for _, r := range mx.Relayers {
+			fmt.Fprintf(&sb, " %s |", r)
+		}
+		sb.WriteString("\n|---|")
+		for range mx.Relayers {
+			sb.WriteString("---|")
+		}
+		sb.WriteString("\n")
+
+		for _, tc := range mx.TestCases {
+			if !mx.hasTestCase(cp, tc) {
+				continue
+			}
+			fmt.Fprintf(&sb, "| %s |", tc)
+			for _, r := range mx.Relayers {
+				e, ok := mx.Entry(cp, tc, r)
+				if !ok {
+					sb.WriteString(" |")
+					continue
+				}
+				fmt.Fprintf(&sb, " %s |", markdownCell(e))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func markdownCell(e MatrixEntry) string {
+	switch e.Result {
+	case MatrixPass:
+		return "pass"
+	case MatrixSkip:
+		if len(e.MissingCapabilities) > 0 {
+			return "skip (missing " + strings.Join(e.MissingCapabilities, ", ") + ")"
+		}
+		return "skip"
+	default:
+		return "**fail**"
+	}
+}
+
+//go:embed matrix.html.tmpl
+var matrixHTML string
+
+var matrixTemplate = template.Must(template.New("matrix").Funcs(template.FuncMap{
+	"entry": func(mx Matrix, cp, tc, r string) *MatrixEntry {
+		e, ok := mx.Entry(cp, tc, r)
+		if !ok {
+			return nil
+		}
+		return &e
+	},
+	"hasTestCase": func(mx Matrix, cp, tc string) bool {
+		return mx.hasTestCase(cp, tc)
+	},
+	"join": strings.Join,
+}).Parse(matrixHTML))
+
+// WriteHTML writes the matrix as a self-contained HTML page.
+func (mx Matrix) WriteHTML(w io.Writer) error {
+	return matrixTemplate.Execute(w, mx)
+}
+
+func (mx Matrix) hasTestCase(chainPair, testCase string) bool {
+	for _, e := range mx.Entries {
+		if e.ChainPair == chainPair && e.TestCase == testCase {
+			return true
+		}
+	}
+	return false
+}
+
+// splitConformanceTestName splits a test name such as
+// "TestConformance/chain_pairs/gaia@v7.0.1+osmosis@v7.2.0/rly@v2.4.1/conformance/post_relayer_start/relay_packet"
+// into its chain pair, relayer and remaining parts, e.g. "conformance/post_relayer_start/relay_packet".
+func splitConformanceTestName(name string) (chainPair, relayerName, rest string, ok bool) {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		if p != chainPairsTestName {
+			continue
+		}
+		if len(parts) < i+4 {
+			return "", "", "", false
+		}
+		return parts[i+1], parts[i+2], strings.Join(parts[i+3:], "/"), true
+	}
+	return "", "", "", false
+}
+
+// parseMissingCapabilities returns the capability names from a skip message tracked by requireCapabilities,
+// or nil if the message was not produced by requireCapabilities.
+func parseMissingCapabilities(msg string) []string {
+	prefix, _, _ := strings.Cut(missingCapabilitiesSkipFormat, "%s")
+	if !strings.HasPrefix(msg, prefix) {
+		return nil
+	}
+	rest := strings.TrimPrefix(msg, prefix)
+
+	known := make(map[string]bool, len(relayer.FullCapabilities()))
+	for c := range relayer.FullCapabilities() {
+		known[c.String()] = true
+	}
+
+	var caps []string
+	for _, f := range strings.Fields(strings.Trim(rest, "[]")) {
+		if known[f] {
+			caps = append(caps, f)
+		}
+	}
+	return caps
+}
+
+// skippedCapabilities returns the capability names from the skip messages of subtests, without duplicates.
+func skippedCapabilities(skipMsgs []string) []string {
+	var caps []string
+	seen := make(map[string]bool)
+	for _, msg := range skipMsgs {
+		for _, c := range parseMissingCapabilities(msg) {
+			if !seen[c] {
+				seen[c] = true
+				caps = append(caps, c)
+			}
+		}
+	}
+	return caps
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
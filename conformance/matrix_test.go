@@ -0,0 +1,145 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMatrix(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	const (
+		pair  = "gaia@v7.0.1+osmosis@v7.2.0"
+		root  = "TestConformance/chain_pairs/" + pair
+		rly   = root + "/rly@v2.4.1"
+		herm  = root + "/hermes@v1.4.0"
+		other = "TestOther/foo"
+	)
+
+	begin := func(name string) testreporter.Message {
+		return testreporter.BeginTestMessage{Name: name, StartedAt: start}
+	}
+	finish := func(name string, failed, skipped bool) testreporter.Message {
+		return testreporter.FinishTestMessage{Name: name, FinishedAt: start.Add(time.Minute), Failed: failed, Skipped: skipped}
+	}
+
+	msgs := []testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: start},
+		begin(rly),
+		begin(rly + "/conformance"),
+		// post_relayer_start is not tracked, but its subtests must still roll up into conformance.
+		begin(rly + "/conformance/post_relayer_start/relay_packet"),
+		finish(rly+"/conformance/post_relayer_start/relay_packet", false, false),
+		begin(rly + "/conformance/post_relayer_start/height_timeout"),
+		testreporter.TestErrorMessage{Name: rly + "/conformance/post_relayer_start/height_timeout", Message: "first"},
+		testreporter.TestErrorMessage{Name: rly + "/conformance/post_relayer_start/height_timeout", Message: "second"},
+		finish(rly+"/conformance/post_relayer_start/height_timeout", true, false),
+		finish(rly+"/conformance", true, false),
+		finish(rly, true, false),
+
+		begin(herm),
+		begin(herm + "/conformance/post_relayer_start/relay_packet"),
+		finish(herm+"/conformance/post_relayer_start/relay_packet", false, false),
+		begin(herm + "/conformance/post_relayer_start/height_timeout"),
+		testreporter.TestSkipMessage{
+			Name:    herm + "/conformance/post_relayer_start/height_timeout",
+			Message: fmt.Sprintf(missingCapabilitiesSkipFormat, []relayer.Capability{relayer.HeightTimeout}),
+		},
+		finish(herm+"/conformance/post_relayer_start/height_timeout", false, true),
+		begin(herm + "/flushing"),
+		// All subtests of channel_close were skipped, so the test case is skipped.
+		begin(herm + "/channel_close"),
+		begin(herm + "/channel_close/close"),
+		testreporter.TestSkipMessage{
+			Name:    herm + "/channel_close/close",
+			Message: fmt.Sprintf(missingCapabilitiesSkipFormat, []relayer.Capability{relayer.ChannelClose}),
+		},
+		finish(herm+"/channel_close/close", false, true),
+		begin(herm + "/channel_close/close_after_ordered_timeout"),
+		testreporter.TestSkipMessage{
+			Name:    herm + "/channel_close/close_after_ordered_timeout",
+			Message: fmt.Sprintf(missingCapabilitiesSkipFormat, []relayer.Capability{relayer.TimestampTimeout}),
+		},
+		finish(herm+"/channel_close/close_after_ordered_timeout", false, true),
+		finish(herm+"/channel_close", false, false),
+		finish(herm, false, false),
+
+		begin(other),
+		finish(other, false, false),
+	}
+
+	mx := NewMatrix(msgs)
+
+	require.Equal(t, []string{"hermes@v1.4.0", "rly@v2.4.1"}, mx.Relayers)
+	require.Equal(t, []string{pair}, mx.ChainPairs)
+	require.Equal(t, []string{"channel_close", "conformance", "flushing"}, mx.TestCases)
+	require.Len(t, mx.Entries, 4)
+
+	// The failure of a subtest fails the test case, with the first error of its subtests.
+	e, ok := mx.Entry(pair, "conformance", "rly@v2.4.1")
+	require.True(t, ok)
+	require.Equal(t, MatrixFail, e.Result)
+	require.Equal(t, "first", e.Message)
+	require.Equal(t, time.Minute, e.Duration)
+
+	// Skipped subtests of a passed test case are listed in its message.
+	e, ok = mx.Entry(pair, "conformance", "hermes@v1.4.0")
+	require.True(t, ok)
+	require.Equal(t, MatrixPass, e.Result)
+	require.Equal(t, "skipped post_relayer_start/height_timeout", e.Message)
+	require.Empty(t, e.MissingCapabilities)
+
+	e, ok = mx.Entry(pair, "channel_close", "hermes@v1.4.0")
+	require.True(t, ok)
+	require.Equal(t, MatrixSkip, e.Result)
+	require.Equal(t, []string{"ChannelClose", "TimestampTimeout"}, e.MissingCapabilities)
+
+	e, ok = mx.Entry(pair, "flushing", "hermes@v1.4.0")
+	require.True(t, ok)
+	require.Equal(t, MatrixFail, e.Result)
+	require.Equal(t, "test did not finish", e.Message)
+
+	_, ok = mx.Entry(pair, "flushing", "rly@v2.4.1")
+	require.False(t, ok)
+}
+
+func TestMatrix_Write(t *testing.T) {
+	mx := Matrix{
+		Relayers:   []string{"hermes", "rly"},
+		ChainPairs: []string{"a+b"},
+		TestCases:  []string{"relay", "timeout"},
+		Entries: []MatrixEntry{
+			{ChainPair: "a+b", TestCase: "relay", Relayer: "hermes", Result: MatrixPass},
+			{ChainPair: "a+b", TestCase: "relay", Relayer: "rly", Result: MatrixFail, Message: "<boom>"},
+			{ChainPair: "a+b", TestCase: "timeout", Relayer: "hermes", Result: MatrixSkip, MissingCapabilities: []string{"HeightTimeout"}},
+		},
+	}
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, mx.WriteJSON(&jsonBuf))
+	var decoded Matrix
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &decoded))
+	require.Equal(t, mx, decoded)
+
+	var md bytes.Buffer
+	require.NoError(t, mx.WriteMarkdown(&md))
+	require.Contains(t, md.String(), "## a+b")
+	require.Contains(t, md.String(), "| Test case | hermes | rly |")
+	require.Contains(t, md.String(), "| relay | pass | **fail** |")
+	require.Contains(t, md.String(), "| timeout | skip (missing HeightTimeout) | |")
+
+	var html bytes.Buffer
+	require.NoError(t, mx.WriteHTML(&html))
+	require.Contains(t, html.String(), "<h2>a&#43;b</h2>")
+	require.Contains(t, html.String(), `<td class="skip"`)
+	require.Contains(t, html.String(), "missing HeightTimeout")
+	require.Contains(t, html.String(), "&lt;boom&gt;")
+	require.NotContains(t, html.String(), "<boom>")
+}
@@ -0,0 +1,562 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+// orderedBatchSize is the number of packets sent in a single batch over an ordered channel.
+const orderedBatchSize = 6
+
+// OrderedChannelApp is an IBC application bound to ordered channels.
+// It drives the packets sent by TestRelayerOrderedChannels.
+//
+// Each call to Open is made with a fresh pair of users,
+// and must result in a new ordered channel whose controller side is owned by controllerUser.
+type OrderedChannelApp interface {
+	// Open initiates the handshake of an ordered channel over connectionID,
+	// returning the port ID of the new channel end on the controller chain.
+	// The handshake is completed by the running relayer.
+	Open(ctx context.Context, controller, host ibc.Chain, controllerUser, hostUser ibc.Wallet, connectionID string) (portID string, err error)
+
+	// SendPacket sends a single packet over the open channel previously created by Open for the same users.
+	SendPacket(ctx context.Context, controller, host ibc.Chain, controllerUser, hostUser ibc.Wallet, connectionID string) error
+
+	// Executed returns how many of the packets sent by SendPacket for the same users were executed on the host chain.
+	Executed(ctx context.Context, controller, host ibc.Chain, controllerUser, hostUser ibc.Wallet, connectionID string) (int, error)
+
+	// PacketTimeout is the relative timeout of the packets sent by SendPacket.
+	PacketTimeout() time.Duration
+}
+
+// OrderedChannelApplication is the application used by TestRelayerOrderedChannels.
+//
+// The default uses the ibc-go interchain accounts controller module,
+// with the interchain accounts host module on the counterparty.
+// If the controller chain does not support interchain accounts, the ordered channel tests are skipped.
+var OrderedChannelApplication OrderedChannelApp = ICAApp{}
+
+// icaFunds is the amount sent to a new interchain account, so that the packets sent by SendPacket succeed.
+const icaFunds = int64(1_000_000)
+
+// ICAApp is an OrderedChannelApp sending interchain accounts packets through the ibc-go interchain accounts controller module.
+// Each packet sends one token from the interchain account to the host user.
+// Both chains must be cosmos chains.
+type ICAApp struct{}
+
+var _ OrderedChannelApp = ICAApp{}
+
+// Open registers a new interchain account on host, owned by controllerUser.
+func (ICAApp) Open(ctx context.Context, controller, _ ibc.Chain, controllerUser, _ ibc.Wallet, connectionID string) (string, error) {
+	c, ok := controller.(*cosmos.CosmosChain)
+	if !ok {
+		return "", fmt.Errorf("interchain accounts require a cosmos controller chain, got %T", controller)
+	}
+
+	if _, err := c.Validators[0].RegisterInterchainAccount(ctx, controllerUser.KeyName(), connectionID); err != nil {
+		return "", fmt.Errorf("failed to register interchain account: %w", err)
+	}
+
+	return "icacontroller-" + controllerUser.FormattedAddress(), nil
+}
+
+// SendPacket sends a bank transfer of one token from the interchain account to hostUser,
+// funding the interchain account first if necessary.
+func (a ICAApp) SendPacket(ctx context.Context, controller, host ibc.Chain, controllerUser, hostUser ibc.Wallet, connectionID string) error {
+	c, icaAddr, err := a.interchainAccount(ctx, controller, controllerUser, connectionID)
+	if err != nil {
+		return err
+	}
+
+	if err := fundInterchainAccount(ctx, host, hostUser, icaAddr); err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(map[string]any{
+		"@type":        "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": icaAddr,
+		"to_address":   hostUser.FormattedAddress(),
+		"amount":       []map[string]any{{"denom": host.Config().Denom, "amount": "1"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Validators[0].SendInterchainAccountTx(ctx, controllerUser.KeyName(), connectionID, a.PacketTimeout(), msg)
+	return err
+}
+
+// Executed returns how many tokens the interchain account has sent.
+func (a ICAApp) Executed(ctx context.Context, controller, host ibc.Chain, controllerUser, _ ibc.Wallet, connectionID string) (int, error) {
+	_, icaAddr, err := a.interchainAccount(ctx, controller, controllerUser, connectionID)
+	if err != nil {
+		return 0, err
+	}
+	return interchainAccountSends(ctx, host, icaAddr)
+}
+
+// PacketTimeout returns the relative timeout that SendPacket sets on the packets.
+func (ICAApp) PacketTimeout() time.Duration {
+	return time.Minute
+}
+
+func (ICAApp) interchainAccount(ctx context.Context, controller ibc.Chain, controllerUser ibc.Wallet, connectionID string) (*cosmos.CosmosChain, string, error) {
+	c, ok := controller.(*cosmos.CosmosChain)
+	if !ok {
+		return nil, "", fmt.Errorf("interchain accounts require a cosmos controller chain, got %T", controller)
+	}
+
+	icaAddr, err := c.Validators[0].QueryInterchainAccount(ctx, connectionID, controllerUser.FormattedAddress())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query interchain account: %w", err)
+	}
+	return c, icaAddr, nil
+}
+
+// IntertxApp is an OrderedChannelApp sending interchain accounts packets through the intertx module
+// of the interchain accounts demo chain (icad).
+// Both chains must be cosmos chains, and the controller chain must include the intertx module.
+type IntertxApp struct{}
+
+var _ OrderedChannelApp = IntertxApp{}
+
+// Open registers a new interchain account on host, controlled by controllerUser.
+func (IntertxApp) Open(ctx context.Context, controller, _ ibc.Chain, controllerUser, _ ibc.Wallet, connectionID string) (string, error) {
+	c, ok := controller.(*cosmos.CosmosChain)
+	if !ok {
+		return "", fmt.Errorf("intertx requires a cosmos controller chain, got %T", controller)
+	}
+
+	if _, err := c.Validators[0].RegisterICA(ctx, controllerUser.KeyName(), connectionID); err != nil {
+		return "", fmt.Errorf("failed to register interchain account: %w", err)
+	}
+
+	return "icacontroller-" + controllerUser.FormattedAddress(), nil
+}
+
+// SendPacket sends a bank transfer of one token from the interchain account to hostUser,
+// funding the interchain account first if necessary.
+func (IntertxApp) SendPacket(ctx context.Context, controller, host ibc.Chain, controllerUser, hostUser ibc.Wallet, connectionID string) error {
+	c, ok := controller.(*cosmos.CosmosChain)
+	if !ok {
+		return fmt.Errorf("intertx requires a cosmos controller chain, got %T", controller)
+	}
+
+	icaAddr, err := c.Validators[0].QueryICA(ctx, connectionID, controllerUser.FormattedAddress())
+	if err != nil {
+		return fmt.Errorf("failed to query interchain account: %w", err)
+	}
+
+	if err := fundInterchainAccount(ctx, host, hostUser, icaAddr); err != nil {
+		return err
+	}
+
+	return c.Validators[0].SendICABankTransferFromAccount(ctx, connectionID, controllerUser.FormattedAddress(), icaAddr, ibc.WalletAmount{
+		Address: hostUser.FormattedAddress(),
+		Denom:   host.Config().Denom,
+		Amount:  math.OneInt(),
+	})
+}
+
+// Executed returns how many tokens the interchain account has sent.
+func (IntertxApp) Executed(ctx context.Context, controller, host ibc.Chain, controllerUser, _ ibc.Wallet, connectionID string) (int, error) {
+	c, ok := controller.(*cosmos.CosmosChain)
+	if !ok {
+		return 0, fmt.Errorf("intertx requires a cosmos controller chain, got %T", controller)
+	}
+
+	icaAddr, err := c.Validators[0].QueryICA(ctx, connectionID, controllerUser.FormattedAddress())
+	if err != nil {
+		return 0, fmt.Errorf("failed to query interchain account: %w", err)
+	}
+	return interchainAccountSends(ctx, host, icaAddr)
+}
+
+// PacketTimeout returns the fixed timeout applied by intertx to submitted transactions.
+func (IntertxApp) PacketTimeout() time.Duration {
+	return time.Minute
+}
+
+// fundInterchainAccount sends icaFunds from hostUser to the interchain account, unless it already has a balance.
+func fundInterchainAccount(ctx context.Context, host ibc.Chain, hostUser ibc.Wallet, icaAddr string) error {
+	denom := host.Config().Denom
+	bal, err := host.GetBalance(ctx, icaAddr, denom)
+	if err != nil {
+		return fmt.Errorf("failed to get interchain account balance: %w", err)
+	}
+	if !bal.IsZero() {
+		return nil
+	}
+	if err := host.SendFunds(ctx, hostUser.KeyName(), ibc.WalletAmount{
+		Address: icaAddr,
+		Denom:   denom,
+		Amount:  math.NewInt(icaFunds),
+	}); err != nil {
+		return fmt.Errorf("failed to fund interchain account: %w", err)
+	}
+	return nil
+}
+
+// interchainAccountSends returns how many tokens the interchain account funded by fundInterchainAccount has sent.
+func interchainAccountSends(ctx context.Context, host ibc.Chain, icaAddr string) (int, error) {
+	bal, err := host.GetBalance(ctx, icaAddr, host.Config().Denom)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get interchain account balance: %w", err)
+	}
+	if bal.IsZero() {
+		return 0, fmt.Errorf("interchain account %s is not funded", icaAddr)
+	}
+	return int(math.NewInt(icaFunds).Sub(bal).Int64()), nil
+}
+
+// orderedChannels opens channels with OrderedChannelApplication on a linked chain pair, with a running relayer.
+type orderedChannels struct {
+	rep      *testreporter.Reporter
+	r        ibc.Relayer
+	c0, c1   ibc.Chain
+	pathName string
+
+	connectionID string
+	app          OrderedChannelApp
+}
+
+// startOrderedChannels starts the relayer on pathName, which links c0 and c1,
+// to complete the handshakes of the channels opened on the first connection of c0.
+func startOrderedChannels(t *testing.T, ctx context.Context, rep *testreporter.Reporter, r ibc.Relayer, c0, c1 ibc.Chain, pathName string) *orderedChannels {
+	t.Helper()
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	connections, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.NotEmpty(connections)
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		interchaintest.PauseBeforeCleanup(t)
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	return &orderedChannels{
+		rep:      rep,
+		r:        r,
+		c0:       c0,
+		c1:       c1,
+		pathName: pathName,
+
+		connectionID: connections[0].ID,
+		app:          OrderedChannelApplication,
+	}
+}
+
+// open opens a new ordered channel for a new pair of users,
+// skipping t if the chains do not support the ordered channel application.
+func (o *orderedChannels) open(t *testing.T, ctx context.Context) (users []ibc.Wallet, portID string) {
+	t.Helper()
+
+	req := require.New(o.rep.TestifyT(t))
+
+	users = interchaintest.GetAndFundTestUsers(t, ctx, "ordered", userFaucetFund, o.c0, o.c1)
+
+	portID, err := o.app.Open(ctx, o.c0, o.c1, users[0], users[1], o.connectionID)
+	if err != nil {
+		o.rep.TrackSkip(t, "skipping because chains could not open an ordered channel: %v", err)
+	}
+
+	req.NoError(waitForChannelState(ctx, o.r, o.rep.RelayerExecReporter(t), o.c0, portID, pollHeightMax, "STATE_OPEN", "Open"))
+
+	ch0, ch1 := getChannelPair(t, ctx, o.rep, o.r, o.c0, o.c1, portID)
+	req.Subset([]string{"ORDER_ORDERED", "Ordered"}, []string{ch0.Ordering})
+	req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch1.State})
+
+	return users, portID
+}
+
+// send sends n packets over the channel of users.
+func (o *orderedChannels) send(ctx context.Context, users []ibc.Wallet, n int) error {
+	for i := 0; i < n; i++ {
+		if err := o.app.SendPacket(ctx, o.c0, o.c1, users[0], users[1], o.connectionID); err != nil {
+			return fmt.Errorf("failed to send packet %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// requireExecuted asserts that exactly n packets were executed on the host chain over the channel of users.
+func (o *orderedChannels) requireExecuted(t *testing.T, ctx context.Context, users []ibc.Wallet, n int) {
+	t.Helper()
+
+	req := require.New(o.rep.TestifyT(t))
+	executed, err := o.app.Executed(ctx, o.c0, o.c1, users[0], users[1], o.connectionID)
+	req.NoError(err)
+	req.Equal(n, executed, "unexpected number of packets executed on %s", o.c1.Config().ChainID)
+}
+
+// timeoutCloses sends a packet over a new channel while the relayer is stopped, lets the packet time out,
+// and asserts that the relayer closes both channel ends once restarted.
+func (o *orderedChannels) timeoutCloses(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	req := require.New(o.rep.TestifyT(t))
+	eRep := o.rep.RelayerExecReporter(t)
+
+	users, portID := o.open(t, ctx)
+
+	startHeight, err := o.c0.Height(ctx)
+	req.NoError(err)
+
+	req.NoError(o.r.StopRelayer(ctx, eRep))
+	req.NoError(o.send(ctx, users, 1))
+
+	// Let the packet time out before the relayer is able to deliver it.
+	time.Sleep(o.app.PacketTimeout() + 10*time.Second)
+
+	req.NoError(o.r.StartRelayer(ctx, eRep, o.pathName))
+
+	seqs, err := pollForSequences(ctx, o.c0, startHeight, 1, portID, timedOutPackets(o.c0))
+	req.NoError(err, "failed to find timeout")
+	req.Equal([]uint64{1}, seqs)
+
+	// A timeout on an ordered channel closes both channel ends.
+	req.NoError(waitForChannelState(ctx, o.r, eRep, o.c0, portID, pollHeightMax, "STATE_CLOSED", "Closed"))
+	ch0, ch1 := getChannelPair(t, ctx, o.rep, o.r, o.c0, o.c1, portID)
+	req.Subset([]string{"STATE_CLOSED", "Closed"}, []string{ch0.State})
+	req.Subset([]string{"STATE_CLOSED", "Closed"}, []string{ch1.State})
+
+	o.requireExecuted(t, ctx, users, 0)
+}
+
+// recoverRelayer restarts the relayer if t fails,
+// so that the following subtests start with a running relayer.
+func (o *orderedChannels) recoverRelayer(t *testing.T, ctx context.Context) {
+	eRep := o.rep.RelayerExecReporter(t)
+	t.Cleanup(func() {
+		if t.Failed() {
+			_ = o.r.StopRelayer(ctx, eRep)
+			_ = o.r.StartRelayer(ctx, eRep, o.pathName)
+		}
+	})
+}
+
+// TestRelayerOrderedChannels exercises ordered channels opened by OrderedChannelApplication.
+// Each subtest opens its own channel on a shared chain pair, and asserts that:
+//   - a batch of packets sent while the relayer is stopped is delivered and executed in order;
+//   - a batch interrupted by a relayer restart while its packets are in flight is delivered in order, exactly once;
+//   - a timed out packet causes the relayer to close the ordered channel.
+func TestRelayerOrderedChannels(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	r, c0, c1, pathName := buildLinkedChainPair(t, ctx, cf, rf, rep)
+
+	o := startOrderedChannels(t, ctx, rep, r, c0, c1, pathName)
+
+	t.Run("in order delivery", func(t *testing.T) {
+		rep.TrackTest(t)
+		o.recoverRelayer(t, ctx)
+
+		req := require.New(rep.TestifyT(t))
+
+		users, portID := o.open(t, ctx)
+
+		startHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		// Queue up the whole batch before the relayer can deliver any of it.
+		req.NoError(r.StopRelayer(ctx, rep.RelayerExecReporter(t)))
+		req.NoError(o.send(ctx, users, orderedBatchSize))
+		req.NoError(r.StartRelayer(ctx, rep.RelayerExecReporter(t), pathName))
+
+		seqs, err := pollForSequences(ctx, c0, startHeight, orderedBatchSize, portID, ackedPackets(c0))
+		req.NoError(err, "failed to find acknowledgements")
+		req.Equal(consecutiveSequences(1, orderedBatchSize), seqs, "packets acknowledged out of order")
+
+		o.requireExecuted(t, ctx, users, orderedBatchSize)
+	})
+
+	t.Run("relayer restart mid batch", func(t *testing.T) {
+		rep.TrackTest(t)
+		o.recoverRelayer(t, ctx)
+
+		req := require.New(rep.TestifyT(t))
+
+		users, portID := o.open(t, ctx)
+
+		startHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		// Send the batch while the relayer is running,
+		// and restart the relayer once it has acknowledged the first packets of the batch.
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return o.send(ctx, users, orderedBatchSize)
+		})
+
+		acked, err := pollForSequences(ctx, c0, startHeight, 1, portID, ackedPackets(c0))
+		req.NoError(err, "failed to find the first acknowledgement")
+		req.Less(len(acked), orderedBatchSize, "the whole batch was acknowledged before the relayer restart")
+
+		req.NoError(r.StopRelayer(ctx, rep.RelayerExecReporter(t)))
+		req.NoError(r.StartRelayer(ctx, rep.RelayerExecReporter(t), pathName))
+
+		req.NoError(eg.Wait())
+
+		seqs, err := pollForSequences(ctx, c0, startHeight, orderedBatchSize, portID, ackedPackets(c0))
+		req.NoError(err, "failed to find acknowledgements")
+		req.Equal(consecutiveSequences(1, orderedBatchSize), seqs, "packets acknowledged out of order or more than once")
+
+		ch0, ch1 := getChannelPair(t, ctx, rep, r, c0, c1, portID)
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch0.State})
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch1.State})
+
+		o.requireExecuted(t, ctx, users, orderedBatchSize)
+	})
+
+	t.Run("timeout closes channel", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.TimestampTimeout)
+		o.recoverRelayer(t, ctx)
+
+		o.timeoutCloses(t, ctx)
+	})
+}
+
+// waitForChannelState waits up to maxBlocks blocks on chain for the single channel bound to portID
+// to reach one of the given states.
+func waitForChannelState(
+	ctx context.Context,
+	r ibc.Relayer,
+	eRep ibc.RelayerExecReporter,
+	chain ibc.Chain,
+	portID string,
+	maxBlocks uint64,
+	states ...string,
+) error {
+	var last string
+	for i := uint64(0); i < maxBlocks; i++ {
+		channels, err := r.GetChannels(ctx, eRep, chain.Config().ChainID)
+		if err != nil {
+			return err
+		}
+		for _, c := range channels {
+			if c.PortID != portID {
+				continue
+			}
+			last = c.State
+			for _, s := range states {
+				if c.State == s {
+					return nil
+				}
+			}
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, chain); err != nil {
+			return err
+		}
+	}
+	if last == "" {
+		return fmt.Errorf("no channel on port %s found on %s after %d blocks", portID, chain.Config().ChainID, maxBlocks)
+	}
+	return fmt.Errorf("channel on port %s on %s is in state %s after %d blocks, expected one of %v", portID, chain.Config().ChainID, last, maxBlocks, states)
+}
+
+// packetsAtHeight returns the packets of a kind of packet event, such as acknowledgements, at a height.
+type packetsAtHeight func(ctx context.Context, height uint64) ([]ibc.Packet, error)
+
+func ackedPackets(chain testutil.ChainAcker) packetsAtHeight {
+	return func(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+		acks, err := chain.Acknowledgements(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		packets := make([]ibc.Packet, len(acks))
+		for i, ack := range acks {
+			packets[i] = ack.Packet
+		}
+		return packets, nil
+	}
+}
+
+func timedOutPackets(chain testutil.ChainTimeouter) packetsAtHeight {
+	return func(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+		timeouts, err := chain.Timeouts(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		packets := make([]ibc.Packet, len(timeouts))
+		for i, timeout := range timeouts {
+			packets[i] = timeout.Packet
+		}
+		return packets, nil
+	}
+}
+
+// pollForSequences scans chain from startHeight, for at most pollHeightMax blocks,
+// until want packets sent from portID are found by packetsAt.
+// The sequences are returned in the order they were committed on chain.
+// One more block is scanned after the wanted count is reached,
+// so that a packet relayed twice shows up as a duplicate sequence.
+func pollForSequences(
+	ctx context.Context,
+	chain testutil.ChainHeighter,
+	startHeight uint64,
+	want int,
+	portID string,
+	packetsAt packetsAtHeight,
+) ([]uint64, error) {
+	var seqs []uint64
+	maxHeight := startHeight + pollHeightMax
+	for h := startHeight; h <= maxHeight; h++ {
+		for {
+			cur, err := chain.Height(ctx)
+			if err != nil {
+				return seqs, err
+			}
+			if cur >= h {
+				break
+			}
+			if err := testutil.WaitForBlocks(ctx, 1, chain); err != nil {
+				return seqs, err
+			}
+		}
+
+		packets, err := packetsAt(ctx, h)
+		if err != nil {
+			return seqs, fmt.Errorf("failed to get packets at height %d: %w", h, err)
+		}
+		for _, p := range packets {
+			if p.SourcePort == portID {
+				seqs = append(seqs, p.Sequence)
+			}
+		}
+
+		if len(seqs) >= want && maxHeight > h+1 {
+			maxHeight = h + 1
+		}
+	}
+	if len(seqs) < want {
+		return seqs, fmt.Errorf("found %d of %d packets from port %s after %d blocks", len(seqs), want, portID, pollHeightMax)
+	}
+	return seqs, nil
+}
+
+// consecutiveSequences returns the n packet sequences starting at first.
+func consecutiveSequences(first uint64, n int) []uint64 {
+	seqs := make([]uint64, n)
+	for i := range seqs {
+		seqs[i] = first + uint64(i)
+	}
+	return seqs
+}
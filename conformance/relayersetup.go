@@ -52,6 +52,7 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 
 	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
 		TestName:  t.Name(),
+		T:         t,
 		Client:    client,
 		NetworkID: network,
 
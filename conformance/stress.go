@@ -0,0 +1,367 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+// StressOptions configures the topology and load of TestRelayerStress.
+type StressOptions struct {
+	// ConnectionsPerClient is the number of connections opened on the clients of the linked path.
+	ConnectionsPerClient int
+
+	// ChannelsPerConnection is the number of transfer channels opened on each connection.
+	ChannelsPerConnection int
+
+	// TransfersPerChannel is the number of transfers sent in each direction on each channel.
+	TransfersPerChannel int
+
+	// MaxBlocks is the number of blocks to wait for all packets to be acknowledged or timed out,
+	// counted from the height at which the transfers were sent.
+	MaxBlocks uint64
+
+	// SettleBlocks is the number of blocks still scanned after all packets were acknowledged or timed out,
+	// to detect packets that the relayer acknowledges or times out again.
+	SettleBlocks uint64
+}
+
+// StressOpts are the options used by TestRelayerStress.
+// The defaults send 300 transfers across 6 channels on 2 connections.
+var StressOpts = StressOptions{
+	ConnectionsPerClient:  2,
+	ChannelsPerConnection: 3,
+	TransfersPerChannel:   25,
+	MaxBlocks:             200,
+	SettleBlocks:          10,
+}
+
+// stressGasPerTransfer is the gas requested for each transfer message in a stress test transaction.
+const stressGasPerTransfer = 150_000
+
+// packetID uniquely identifies a packet on its source chain.
+type packetID struct {
+	Port, Channel string
+	Sequence      uint64
+}
+
+func (p packetID) String() string {
+	return fmt.Sprintf("%s/%s/%d", p.Port, p.Channel, p.Sequence)
+}
+
+// TestRelayerStress opens several channels on one connection and several connections on one pair of clients,
+// sends StressOpts.TransfersPerChannel transfers in each direction on every channel at once,
+// and asserts that every packet is acknowledged or timed out exactly once.
+//
+// The time between sending the transfers and the last packet being resolved
+// is recorded as the "completion time" metric in the test report, so that relayers can be compared.
+func TestRelayerStress(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	opts := StressOpts
+	req := require.New(rep.TestifyT(t))
+
+	r, c0, c1, pathName := buildLinkedChainPair(t, ctx, cf, rf, rep)
+
+	cc0, ok0 := c0.(*cosmos.CosmosChain)
+	cc1, ok1 := c1.(*cosmos.CosmosChain)
+	if !ok0 || !ok1 {
+		rep.TrackSkip(t, "skipping because stress transfers require cosmos chains, got %T and %T", c0, c1)
+	}
+
+	pathGen, ok := r.(ibc.ClientPathGenerator)
+	if !ok && opts.ConnectionsPerClient > 1 {
+		rep.TrackSkip(t, "skipping because relayer %s does not implement ibc.ClientPathGenerator", rf.Name())
+	}
+
+	eRep := rep.RelayerExecReporter(t)
+
+	channelOpts := ibc.DefaultChannelOpts()
+	channelOpts.Override = true
+
+	// The linked path already has one channel on its connection.
+	for i := 1; i < opts.ChannelsPerConnection; i++ {
+		req.NoError(r.CreateChannel(ctx, eRep, pathName, channelOpts), "failed to create channel %d on path %s", i, pathName)
+	}
+
+	pathNames := []string{pathName}
+	if opts.ConnectionsPerClient > 1 {
+		conns, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
+		req.NoError(err)
+		req.NotEmpty(conns)
+		srcClientID, dstClientID := conns[0].ClientID, conns[0].Counterparty.ClientId
+
+		for i := 1; i < opts.ConnectionsPerClient; i++ {
+			p := fmt.Sprintf("%s-%d", pathName, i)
+			req.NoError(pathGen.GeneratePathWithClients(ctx, eRep, c0.Config().ChainID, c1.Config().ChainID, srcClientID, dstClientID, p))
+			req.NoError(r.CreateConnections(ctx, eRep, p), "failed to create connection on path %s", p)
+			for j := 0; j < opts.ChannelsPerConnection; j++ {
+				req.NoError(r.CreateChannel(ctx, eRep, p, channelOpts), "failed to create channel %d on path %s", j, p)
+			}
+			pathNames = append(pathNames, p)
+		}
+	}
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	var transferChannels []ibc.ChannelOutput
+	for _, ch := range channels {
+		if ch.PortID == channelOpts.SourcePortName {
+			transferChannels = append(transferChannels, ch)
+		}
+	}
+	req.Len(transferChannels, opts.ConnectionsPerClient*opts.ChannelsPerConnection)
+
+	connIDs := make(map[string]bool)
+	for _, ch := range transferChannels {
+		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch.State})
+		connIDs[ch.ConnectionHops[0]] = true
+	}
+	req.Len(connIDs, opts.ConnectionsPerClient, "channels are not spread over the expected number of connections")
+
+	// Use a separate user per channel and direction,
+	// so that the transactions can be broadcast concurrently without sequence mismatches.
+	users0 := make([]ibc.Wallet, len(transferChannels))
+	users1 := make([]ibc.Wallet, len(transferChannels))
+	for i := range transferChannels {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, fmt.Sprintf("stress-%d", i), userFaucetFund, c0, c1)
+		users0[i], users1[i] = users[0], users[1]
+	}
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathNames...))
+	t.Cleanup(func() {
+		interchaintest.PauseBeforeCleanup(t)
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	start0, err := c0.Height(ctx)
+	req.NoError(err)
+	start1, err := c1.Height(ctx)
+	req.NoError(err)
+
+	var (
+		sent0 = make([][]packetID, len(transferChannels))
+		sent1 = make([][]packetID, len(transferChannels))
+	)
+
+	startedAt := time.Now()
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, ch := range transferChannels {
+		i, ch := i, ch
+		eg.Go(func() (err error) {
+			sent0[i], err = broadcastStressTransfers(egCtx, t, cc0, c1, users0[i], ch.PortID, ch.ChannelID, opts.TransfersPerChannel)
+			return err
+		})
+		eg.Go(func() (err error) {
+			sent1[i], err = broadcastStressTransfers(egCtx, t, cc1, c0, users1[i], ch.Counterparty.PortID, ch.Counterparty.ChannelID, opts.TransfersPerChannel)
+			return err
+		})
+	}
+	req.NoError(eg.Wait(), "failed to send transfers")
+
+	var (
+		resolved0, resolved1 map[packetID]int
+		finishedAt           [2]time.Time
+	)
+	eg, egCtx = errgroup.WithContext(ctx)
+	eg.Go(func() (err error) {
+		resolved0, finishedAt[0], err = pollForResolvedPackets(egCtx, cc0, start0, opts.MaxBlocks, opts.SettleBlocks, flattenPacketIDs(sent0))
+		return err
+	})
+	eg.Go(func() (err error) {
+		resolved1, finishedAt[1], err = pollForResolvedPackets(egCtx, cc1, start1, opts.MaxBlocks, opts.SettleBlocks, flattenPacketIDs(sent1))
+		return err
+	})
+	pollErr := eg.Wait()
+
+	requireResolvedOnce := func(chainID string, sent [][]packetID, resolved map[packetID]int) {
+		for _, p := range flattenPacketIDs(sent) {
+			req.Equalf(1, resolved[p], "packet %s sent from %s was acknowledged or timed out %d times", p, chainID, resolved[p])
+		}
+	}
+	requireResolvedOnce(c0.Config().ChainID, sent0, resolved0)
+	requireResolvedOnce(c1.Config().ChainID, sent1, resolved1)
+	req.NoError(pollErr)
+
+	finished := finishedAt[0]
+	if finishedAt[1].After(finished) {
+		finished = finishedAt[1]
+	}
+	elapsed := finished.Sub(startedAt)
+	t.Logf("relayer %s resolved %d packets in %s", rf.Name(), 2*len(transferChannels)*opts.TransfersPerChannel, elapsed)
+	rep.TrackMetric(t, "completion time", elapsed.Seconds(), "s")
+}
+
+// broadcastStressTransfers sends n transfers from user over the given channel in a single transaction,
+// returning the identifiers of the sent packets.
+func broadcastStressTransfers(
+	ctx context.Context,
+	t *testing.T,
+	src *cosmos.CosmosChain,
+	dst ibc.Chain,
+	user ibc.Wallet,
+	portID, channelID string,
+	n int,
+) ([]packetID, error) {
+	srcCfg := src.Config()
+	receiver := user.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(dst.Config().Bech32Prefix)
+	timeout := uint64(time.Now().Add(10 * time.Minute).UnixNano())
+
+	msgs := make([]sdk.Msg, n)
+	for i := range msgs {
+		msgs[i] = transfertypes.NewMsgTransfer(
+			portID,
+			channelID,
+			sdk.NewInt64Coin(srcCfg.Denom, testCoinAmount),
+			user.FormattedAddress(),
+			receiver,
+			clienttypes.ZeroHeight(),
+			timeout,
+			"",
+		)
+	}
+
+	b := cosmos.NewBroadcaster(t, src)
+	b.ConfigureFactoryOptions(func(f tx.Factory) tx.Factory {
+		return f.WithGas(uint64(n) * stressGasPerTransfer)
+	})
+
+	resp, err := cosmos.BroadcastTx(ctx, b, user.(*cosmos.CosmosWallet), msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transfers on %s: %w", channelID, err)
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("transfers on %s failed with code %d: %s", channelID, resp.Code, resp.RawLog)
+	}
+
+	var sent []packetID
+	for _, e := range resp.Events {
+		if e.Type != "send_packet" {
+			continue
+		}
+		attrs := make(map[string]string, len(e.Attributes))
+		for _, a := range e.Attributes {
+			attrs[a.Key] = a.Value
+		}
+		seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet sequence %q: %w", attrs["packet_sequence"], err)
+		}
+		sent = append(sent, packetID{Port: attrs["packet_src_port"], Channel: attrs["packet_src_channel"], Sequence: seq})
+	}
+	if len(sent) != n {
+		return sent, fmt.Errorf("expected %d send_packet events on %s, got %d", n, channelID, len(sent))
+	}
+	return sent, nil
+}
+
+// packetEventFinder is a chain whose transaction events can be searched for packet lifecycle events.
+type packetEventFinder interface {
+	blockdb.TxFinder
+	Height(ctx context.Context) (uint64, error)
+}
+
+// pollForResolvedPackets scans chain from startHeight, for at most maxBlocks blocks,
+// counting the acknowledge_packet and timeout_packet events for each of the expected packets.
+// Once every expected packet has been resolved, it scans settleBlocks more blocks
+// and returns the time at which the last packet was first resolved.
+// It fails as soon as a packet is resolved more than once.
+func pollForResolvedPackets(
+	ctx context.Context,
+	chain packetEventFinder,
+	startHeight, maxBlocks, settleBlocks uint64,
+	expected []packetID,
+) (map[packetID]int, time.Time, error) {
+	want := make(map[packetID]bool, len(expected))
+	for _, p := range expected {
+		want[p] = true
+	}
+
+	resolved := make(map[packetID]int, len(expected))
+	pending := len(want)
+
+	var (
+		resolvedAt time.Time
+		endHeight  = startHeight + maxBlocks
+	)
+	for h := startHeight; h <= endHeight; h++ {
+		for {
+			cur, err := chain.Height(ctx)
+			if err != nil {
+				return resolved, resolvedAt, err
+			}
+			if cur >= h {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return resolved, resolvedAt, ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+
+		txs, err := chain.FindTxs(ctx, h)
+		if err != nil {
+			return resolved, resolvedAt, fmt.Errorf("failed to find transactions at height %d: %w", h, err)
+		}
+		for _, tx := range txs {
+			for _, e := range tx.Events {
+				if e.Type != "acknowledge_packet" && e.Type != "timeout_packet" {
+					continue
+				}
+				var p packetID
+				for _, a := range e.Attributes {
+					switch a.Key {
+					case "packet_src_port":
+						p.Port = a.Value
+					case "packet_src_channel":
+						p.Channel = a.Value
+					case "packet_sequence":
+						p.Sequence, _ = strconv.ParseUint(a.Value, 10, 64)
+					}
+				}
+				if !want[p] {
+					continue
+				}
+				if resolved[p] == 0 {
+					pending--
+				}
+				resolved[p]++
+				if resolved[p] > 1 {
+					return resolved, resolvedAt, fmt.Errorf("packet %s was acknowledged or timed out %d times, again at height %d", p, resolved[p], h)
+				}
+			}
+		}
+
+		if pending == 0 && resolvedAt.IsZero() {
+			resolvedAt = time.Now()
+			endHeight = h + settleBlocks
+		}
+	}
+	if pending > 0 {
+		return resolved, resolvedAt, fmt.Errorf("%d of %d packets were not resolved after %d blocks", pending, len(want), maxBlocks)
+	}
+	return resolved, resolvedAt, nil
+}
+
+func flattenPacketIDs(ids [][]packetID) []packetID {
+	var out []packetID
+	for _, s := range ids {
+		out = append(out, s...)
+	}
+	return out
+}
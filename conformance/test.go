@@ -104,6 +104,10 @@ var relayerTestCaseConfigs = [...]RelayerTestCaseConfig{
 	},
 }
 
+// missingCapabilitiesSkipFormat is the skip message tracked by requireCapabilities.
+// The matrix report parses it back into the missing capabilities.
+const missingCapabilitiesSkipFormat = "skipping due to missing relayer capabilities +%s"
+
 // requireCapabilities tracks skipping t, if the relayer factory cannot satisfy the required capabilities.
 func requireCapabilities(t *testing.T, rep *testreporter.Reporter, rf interchaintest.RelayerFactory, reqCaps ...relayer.Capability) {
 	t.Helper()
@@ -111,7 +115,7 @@ func requireCapabilities(t *testing.T, rep *testreporter.Reporter, rf interchain
 	missing := missingCapabilities(rf, reqCaps...)
 
 	if len(missing) > 0 {
-		rep.TrackSkip(t, "skipping due to missing relayer capabilities +%s", missing)
+		rep.TrackSkip(t, missingCapabilitiesSkipFormat, missing)
 	}
 }
 
@@ -263,6 +267,34 @@ func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory,
 
 								TestRelayerFlushing(t, ctx, cf, rf, rep)
 							})
+
+							t.Run("channel close", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerChannelClose(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("channel upgrade", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerChannelUpgrade(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("ordered channels", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerOrderedChannels(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("stress", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerStress(t, ctx, cf, rf, rep)
+							})
 						})
 					}
 				})
@@ -0,0 +1,76 @@
+package cosmos_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestNativeSimd runs a chain of the simd binary in PATH, e.g. installed from a local
+// checkout of ibc-go with `go install ./testing/simapp/simd`, without building a docker image.
+func TestNativeSimd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	if _, err := exec.LookPath("simd"); err != nil {
+		t.Skip("simd not found in PATH")
+	}
+
+	ctx := context.Background()
+	interchaintest.NativeSetup(t)
+
+	numVals, numFullNodes := 2, 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainConfig: ibc.ChainConfig{
+				Type:           "cosmos",
+				Name:           "simd",
+				ChainID:        "simd-1",
+				Runtime:        ibc.NativeRuntime,
+				Bin:            "simd",
+				Bech32Prefix:   "cosmos",
+				Denom:          "stake",
+				GasPrices:      "0stake",
+				GasAdjustment:  1.5,
+				TrustingPeriod: "336h",
+			},
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0]
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	// No docker client or network is needed.
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, chain, chain)
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: users[1].FormattedAddress(),
+		Denom:   chain.Config().Denom,
+		Amount:  math.NewInt(1_000),
+	}))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	bal, err := chain.GetBalance(ctx, users[1].FormattedAddress(), chain.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, math.NewInt(10_001_000), bal)
+}
@@ -2,6 +2,8 @@ package ibc
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/docker/docker/client"
@@ -91,3 +93,80 @@ type TransferOptions struct {
 	Timeout *IBCTimeout
 	Memo    string
 }
+
+// ChainExecReporter is the interface of a narrow type returned by testreporter.ChainExecReporter.
+// Like RelayerExecReporter, it avoids chains depending on the testreporter package or a *testing.T.
+type ChainExecReporter interface {
+	TrackChainExec(
+		// The name of the docker container in which the command executed.
+		containerName string,
+
+		// The command line and the environment variables passed to the container.
+		command, env []string,
+
+		// The standard output and standard error that the command produced.
+		stdout, stderr string,
+
+		// The exit code of executing the command.
+		exitCode int,
+
+		// When the command started and finished.
+		startedAt, finishedAt time.Time,
+
+		// Any error that occurred during execution,
+		// including a non-zero exit code.
+		err error,
+	)
+}
+
+// NopChainExecReporter is a no-op ChainExecReporter.
+type NopChainExecReporter struct{}
+
+func (NopChainExecReporter) TrackChainExec(string, []string, []string, string, string, int, time.Time, time.Time, error) {
+}
+
+// ChainExecTracker is implemented by chains whose nodes and sidecars
+// report the commands they execute to a ChainExecReporter.
+// Interchain.Build sets the reporter on every chain implementing ChainExecTracker.
+type ChainExecTracker interface {
+	SetChainExecReporter(rep ChainExecReporter)
+
+	// ChainExecReporter returns the reporter set through SetChainExecReporter,
+	// or a NopChainExecReporter if none was set.
+	ChainExecReporter() ChainExecReporter
+}
+
+// ChainExecReporterHolder implements ChainExecTracker.
+// Embed it in a chain type for the chain to hold the reporter its nodes and sidecars report to.
+// The zero value holds no reporter.
+type ChainExecReporterHolder struct {
+	mu  sync.Mutex
+	rep ChainExecReporter
+}
+
+// SetChainExecReporter implements ChainExecTracker.
+// Commands executed by the chain's nodes and sidecars after this call are reported to rep.
+func (h *ChainExecReporterHolder) SetChainExecReporter(rep ChainExecReporter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rep = rep
+}
+
+// ChainExecReporter implements ChainExecTracker.
+func (h *ChainExecReporterHolder) ChainExecReporter() ChainExecReporter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.rep == nil {
+		return NopChainExecReporter{}
+	}
+	return h.rep
+}
+
+// ChainExecReporterOf returns the ChainExecReporter of c,
+// or a NopChainExecReporter if c does not implement ChainExecTracker.
+func ChainExecReporterOf(c Chain) ChainExecReporter {
+	if t, ok := c.(ChainExecTracker); ok {
+		return t.ChainExecReporter()
+	}
+	return NopChainExecReporter{}
+}
@@ -105,6 +105,38 @@ type Relayer interface {
 	SetClientContractHash(ctx context.Context, rep RelayerExecReporter, cfg ChainConfig, hash string) error
 }
 
+// ChannelCloser is an optional interface that a Relayer may implement
+// to support the channel closing handshake.
+// Relayers implementing ChannelCloser should report the relayer.ChannelClose capability.
+type ChannelCloser interface {
+	// CloseChannel submits ChanCloseInit for the given channel on the source chain of pathName,
+	// followed by ChanCloseConfirm on the counterparty chain.
+	// CloseChannel returns once both channel ends report the closed state.
+	CloseChannel(ctx context.Context, rep RelayerExecReporter, pathName, channelID, portID string) error
+}
+
+// ClientPathGenerator is an optional interface that a Relayer may implement
+// to generate paths that reuse existing light clients.
+type ClientPathGenerator interface {
+	// GeneratePathWithClients generates a new path between the source and destination chains
+	// whose ends use the existing clients srcClientID and dstClientID,
+	// so that CreateConnections on the new path opens another connection on the same clients.
+	GeneratePathWithClients(ctx context.Context, rep RelayerExecReporter, srcChainID, dstChainID, srcClientID, dstClientID, pathName string) error
+}
+
+// ChannelUpgrader is an optional interface that a Relayer may implement
+// to support the ibc-go channel upgrade handshake.
+// Relayers implementing ChannelUpgrader should report the relayer.ChannelUpgrade capability.
+type ChannelUpgrader interface {
+	// UpgradeChannel drives the channel upgrade handshake for the given channel on the source chain of pathName
+	// to the upgrade fields in opts.
+	// ibc-go only accepts ChanUpgradeInit from the authority of the chain,
+	// so the upgrade must already be initiated on the source chain with the fields in opts, e.g. by a governance proposal.
+	// UpgradeChannel relays the remaining ChanUpgradeTry, ChanUpgradeAck, ChanUpgradeConfirm and ChanUpgradeOpen steps,
+	// and returns once both channel ends have completed the upgrade.
+	UpgradeChannel(ctx context.Context, rep RelayerExecReporter, pathName, channelID, portID string, opts ChannelUpgradeOptions) error
+}
+
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
@@ -211,6 +243,10 @@ type CreateChannelOptions struct {
 	Order Order
 
 	Version string
+
+	// Override requests a new channel even if the path already has a channel
+	// with the same ports, for relayers that would otherwise reuse the existing channel.
+	Override bool
 }
 
 // DefaultChannelOpts returns the default settings for creating an ics20 fungible token transfer channel.
@@ -238,6 +274,32 @@ func (opts CreateChannelOptions) Validate() error {
 	return nil
 }
 
+// ChannelUpgradeOptions contains the proposed fields for a channel upgrade.
+type ChannelUpgradeOptions struct {
+	// Version is the new application version, e.g.
+	// {"fee_version":"ics29-1","app_version":"ics20-1"} to add fee middleware to a transfer channel.
+	Version string
+
+	// Order is the new channel ordering.
+	// If left as the zero value, the channel keeps its current ordering.
+	Order Order
+
+	// ConnectionHops optionally moves the channel onto different connections.
+	// If empty, the channel keeps its current connection hops.
+	ConnectionHops []string
+}
+
+// Validate will check that the specified ChannelUpgradeOptions are valid.
+func (opts ChannelUpgradeOptions) Validate() error {
+	switch {
+	case opts.Version == "":
+		return fmt.Errorf("invalid channel upgrade version")
+	case opts.Order != Invalid && opts.Order.Validate() != nil:
+		return chantypes.ErrInvalidChannelOrdering
+	}
+	return nil
+}
+
 // Order represents an IBC channel's ordering.
 type Order int
 
@@ -34,3 +34,21 @@ func TestChannelOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+func TestChannelUpgradeOptsValidate(t *testing.T) {
+	// Only a version is required
+	opts := ChannelUpgradeOptions{Version: `{"fee_version":"ics29-1","app_version":"ics20-1"}`}
+	require.NoError(t, opts.Validate())
+
+	// Test valid ordering change
+	opts.Order = Ordered
+	require.NoError(t, opts.Validate())
+
+	// Test missing version
+	opts = ChannelUpgradeOptions{Order: Unordered}
+	require.Error(t, opts.Validate())
+
+	// Test invalid Order type
+	opts = ChannelUpgradeOptions{Version: "ics20-1", Order: 3}
+	require.Equal(t, chantypes.ErrInvalidChannelOrdering, opts.Validate())
+}
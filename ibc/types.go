@@ -39,21 +39,62 @@ type ChainConfig struct {
 	// When true, will skip validator gentx flow
 	SkipGenTx bool
 	// When provided, will run before performing gentx and genesis file creation steps for validators.
-	PreGenesis func(ChainConfig) error
+	PreGenesis func(ChainConfig) error `json:"-" yaml:"-"`
 	// When provided, genesis file contents will be altered before sharing for genesis.
-	ModifyGenesis func(ChainConfig, []byte) ([]byte, error)
+	ModifyGenesis func(ChainConfig, []byte) ([]byte, error) `json:"-" yaml:"-"`
 	// Modify genesis-amounts
-	ModifyGenesisAmounts func() (sdk.Coin, sdk.Coin)
+	ModifyGenesisAmounts func() (sdk.Coin, sdk.Coin) `json:"-" yaml:"-"`
 	// Override config parameters for files at filepath.
 	ConfigFileOverrides map[string]any
 	// Non-nil will override the encoding config, used for cosmos chains only.
-	EncodingConfig *testutil.TestEncodingConfig
+	EncodingConfig *testutil.TestEncodingConfig `json:"-" yaml:"-"`
 	// Required when the chain uses the new sub commands for genesis (https://github.com/cosmos/cosmos-sdk/pull/14149)
 	UsingNewGenesisCommand bool `yaml:"using-new-genesis-command"`
 	// Required when the chain requires the chain-id field to be populated for certain commands
 	UsingChainIDFlagCLI bool `yaml:"using-chain-id-flag-cli"`
 	// Configuration describing additional sidecar processes.
 	SidecarConfigs []SidecarConfig
+	// Runtime of the chain nodes and sidecar processes, docker by default.
+	Runtime Runtime `yaml:"runtime"`
+	// Resource limits of every container of the chain nodes. Unlimited by default.
+	Resources Resources `yaml:"resources"`
+	// Settings specific to the chain type, such as the parachains of polkadot chains,
+	// parsed by the ParseConfig hook of the interchaintest chain type.
+	TypeConfig map[string]any `yaml:"type-config,omitempty" json:"type-config,omitempty"`
+}
+
+// Resources limits the resources of a docker container, like the corresponding flags of docker run.
+// Zero values mean no limit.
+type Resources struct {
+	// CPUs is the number of CPUs the container may use, e.g. 1.5.
+	CPUs float64 `yaml:"cpus"`
+	// Memory is the memory limit, e.g. "512m" or "2g".
+	// A container exceeding it is killed, which is reported as running out of memory.
+	Memory string `yaml:"memory"`
+	// PidsLimit is the maximum number of processes and threads of the container.
+	PidsLimit int64 `yaml:"pids-limit"`
+	// BlkioWeight is the relative weight of the block I/O of the container, between 10 and 1000.
+	BlkioWeight uint16 `yaml:"blkio-weight"`
+}
+
+// Runtime selects how chain nodes and sidecar processes run.
+type Runtime string
+
+const (
+	// DockerRuntime runs each node, sidecar and command in a docker container, from the chain's Images.
+	// It is the default.
+	DockerRuntime Runtime = "docker"
+
+	// NativeRuntime runs each node, sidecar and command as a host process of a local binary,
+	// e.g. a freshly built simd, with no docker image.
+	// Bin and the sidecar commands are looked up in PATH unless they are paths.
+	// Each node gets its own home directory and host ports, so the chain needs no docker client or network.
+	NativeRuntime Runtime = "native"
+)
+
+// IsNative reports whether c runs its nodes as host processes.
+func (c ChainConfig) IsNative() bool {
+	return c.Runtime == NativeRuntime
 }
 
 func (c ChainConfig) Clone() ChainConfig {
@@ -67,6 +108,13 @@ func (c ChainConfig) Clone() ChainConfig {
 	copy(sidecars, c.SidecarConfigs)
 	x.SidecarConfigs = sidecars
 
+	if c.TypeConfig != nil {
+		x.TypeConfig = make(map[string]any, len(c.TypeConfig))
+		for k, v := range c.TypeConfig {
+			x.TypeConfig[k] = v
+		}
+	}
+
 	return x
 }
 
@@ -164,17 +212,30 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.SidecarConfigs = append([]SidecarConfig(nil), other.SidecarConfigs...)
 	}
 
+	if other.Runtime != "" {
+		c.Runtime = other.Runtime
+	}
+
+	if other.Resources != (Resources{}) {
+		c.Resources = other.Resources
+	}
+
+	if other.TypeConfig != nil {
+		c.TypeConfig = other.TypeConfig
+	}
+
 	return c
 }
 
 // IsFullyConfigured reports whether all required fields have been set on c.
 // It is possible for some fields, such as GasAdjustment and NoHostMount,
 // to be their respective zero values and for IsFullyConfigured to still report true.
+// Images are not required by the native runtime.
 func (c ChainConfig) IsFullyConfigured() bool {
 	return c.Type != "" &&
 		c.Name != "" &&
 		c.ChainID != "" &&
-		len(c.Images) > 0 &&
+		(len(c.Images) > 0 || c.IsNative()) &&
 		c.Bin != "" &&
 		c.Bech32Prefix != "" &&
 		c.Denom != "" &&
@@ -191,12 +252,36 @@ type SidecarConfig struct {
 	StartCmd         []string
 	PreStart         bool
 	ValidatorProcess bool
+	Resources        Resources
 }
 
 type DockerImage struct {
 	Repository string `yaml:"repository"`
 	Version    string `yaml:"version"`
 	UidGid     string `yaml:"uid-gid"`
+
+	// Build, if set, builds the image from local source instead of pulling it.
+	// Version is then set to a tag derived from the content of the build,
+	// so an image is only rebuilt when its source changes.
+	Build *DockerImageBuild `yaml:"build"`
+}
+
+// DockerImageBuild describes how to build a docker image from local source,
+// like the arguments to docker build.
+type DockerImageBuild struct {
+	// Context is the directory sent to docker as the build context.
+	// Relative paths are relative to the working directory, i.e. the package directory of the test.
+	// Files matching the patterns in the .dockerignore file of Context are excluded.
+	Context string `yaml:"context"`
+
+	// Dockerfile is the path of the Dockerfile, relative to Context. Defaults to "Dockerfile".
+	Dockerfile string `yaml:"dockerfile"`
+
+	// Args are the build-time variables of the Dockerfile.
+	Args map[string]string `yaml:"args"`
+
+	// Target is the stage of a multi-stage Dockerfile to build. Defaults to the last stage.
+	Target string `yaml:"target"`
 }
 
 // Ref returns the reference to use when e.g. creating a container.
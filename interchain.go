@@ -3,11 +3,17 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -38,6 +44,14 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// The test running the interchain, set during Build from InterchainBuildOptions.T.
+	t testing.TB
+
+	// Users created on the chains with GetAndFundTestUserWithMnemonic after Build was called,
+	// for the reproduction bundle and pause information.
+	testUsersMu sync.Mutex
+	testUsers   map[ibc.Chain][]ibc.Wallet
 }
 
 type interchainLink struct {
@@ -65,6 +79,8 @@ func NewInterchain() *Interchain {
 		relayers: make(map[ibc.Relayer]string),
 
 		links: make(map[relayerPath]interchainLink),
+
+		testUsers: make(map[ibc.Chain][]ibc.Wallet),
 	}
 }
 
@@ -209,6 +225,19 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// Optional. Deletes old test cases from BlockDatabaseFile before saving this one,
+	// to keep a long-lived database from growing without bound.
+	// The database is pruned by the first test of the process only, as concurrent tests would be writing to it.
+	BlockDatabaseRetention BlockDatabaseRetention
+
+	// Optional. If positive, a failing test keeps its containers running for up to this long
+	// before its cleanup, overriding the global PauseOnFailure setting.
+	PauseOnFailure time.Duration
+
+	// Optional. The test running the interchain, in which Build registers the pause on failure as a cleanup.
+	// See PauseOnFailure.
+	T testing.TB
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
@@ -220,6 +249,12 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		panic(fmt.Errorf("Interchain.Build called more than once"))
 	}
 	ic.built = true
+	ic.t = opts.T
+
+	ic.trackTestUsers()
+	if opts.T != nil {
+		opts.T.Cleanup(ic.untrackTestUsers)
+	}
 
 	chains := make([]ibc.Chain, 0, len(ic.chains))
 	for chain := range ic.chains {
@@ -227,6 +262,35 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 	ic.cs = newChainSet(ic.log, chains)
 
+	// Report the commands chains execute under the same test as the relayer commands,
+	// and reference container logs captured during docker cleanup from the report.
+	if rep != nil {
+		if opts.NetworkID != "" {
+			dockerutil.OnContainerLogsCaptured(opts.NetworkID, func(containerName, path string) {
+				rep.TrackArtifact("container logs", containerName, path)
+			})
+		}
+
+		chainRep := rep.ChainExecReporter()
+		for _, c := range chains {
+			if t, ok := c.(ibc.ChainExecTracker); ok {
+				t.SetChainExecReporter(chainRep)
+			}
+		}
+	}
+
+	if writeReproBundleOnFailure {
+		ic.reproBundleOnFailure(rep, opts)
+	}
+
+	pause := pauseOnFailure
+	if opts.PauseOnFailure > 0 {
+		pause = opts.PauseOnFailure
+	}
+	if pause > 0 {
+		ic.pauseOnFailure(opts, pause)
+	}
+
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
@@ -247,7 +311,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
 
-	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha, opts.BlockDatabaseRetention); err != nil {
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
 
@@ -330,9 +394,23 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 }
 
 // Close cleans up any resources created during Build,
+// including the containers relayers keep for their one-off commands,
 // and returns any relevant errors.
+// If the test failed and Build arranged for it to pause on failure with InterchainBuildOptions.T,
+// Close pauses first, so that the resources are still running during the pause.
 func (ic *Interchain) Close() error {
-	return ic.cs.Close()
+	if ic.t != nil {
+		PauseBeforeCleanup(ic.t)
+	}
+
+	err := ic.cs.Close()
+	ic.untrackTestUsers()
+	for r := range ic.relayers {
+		if c, ok := r.(io.Closer); ok {
+			multierr.AppendInto(&err, c.Close())
+		}
+	}
+	return err
 }
 
 func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][]ibc.WalletAmount, error) {
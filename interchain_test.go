@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -15,6 +16,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"github.com/strangelove-ventures/interchaintest/v7/relayer/rly"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
@@ -378,6 +380,105 @@ func TestInterchain_OmitGitSHA(t *testing.T) {
 	_ = ic.Close()
 }
 
+// TestInterchain_RelayerExecContainer compares the time the relayer commands of a typical setup take
+// when they run in one-off containers and when they run in the reused exec container of the relayer.
+func TestInterchain_RelayerExecContainer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chains[0]).
+		AddChain(chains[1])
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Every run links a new path between the same chains, so it only checks the channel it created.
+	seenChannels := map[string]bool{}
+	setUpRelayer := func(run int, reuse bool) time.Duration {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "relayer", 10_000_000_000, chains...)
+		require.NoError(t, testutil.WaitForBlocks(ctx, 2, chains[0], chains[1]))
+
+		start := time.Now()
+
+		r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t), relayer.ReuseExecContainer(reuse)).Build(
+			t, client, network,
+		)
+		t.Cleanup(func() {
+			_ = r.StopRelayer(ctx, eRep)
+		})
+		for i, c := range chains {
+			require.NoError(t, r.AddChainConfiguration(ctx, eRep, c.Config(), "relayer", c.GetRPCAddress(), c.GetGRPCAddress()))
+			require.NoError(t, r.RestoreKey(ctx, eRep, c.Config(), "relayer", users[i].Mnemonic()))
+		}
+
+		pathName := fmt.Sprintf("p%d", run)
+		require.NoError(t, r.GeneratePath(ctx, eRep, chains[0].Config().ChainID, chains[1].Config().ChainID, pathName))
+		require.NoError(t, r.LinkPath(ctx, eRep, pathName, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts()))
+
+		channels, err := r.GetChannels(ctx, eRep, chains[0].Config().ChainID)
+		require.NoError(t, err)
+
+		elapsed := time.Since(start)
+
+		var created []string
+		for _, ch := range channels {
+			if !seenChannels[ch.ChannelID] {
+				seenChannels[ch.ChannelID] = true
+				created = append(created, ch.ChannelID)
+			}
+		}
+		require.Len(t, created, 1)
+
+		return elapsed
+	}
+
+	// The first run pulls the relayer image and warms up the docker daemon, so it is not timed.
+	setUpRelayer(0, false)
+
+	// Alternate the order of both modes across rounds, so neither benefits from running second.
+	const rounds = 2
+	var oneOff, reused time.Duration
+	for i := 0; i < rounds; i++ {
+		run := 1 + 2*i
+		if i%2 == 0 {
+			oneOff += setUpRelayer(run, false)
+			reused += setUpRelayer(run+1, true)
+		} else {
+			reused += setUpRelayer(run, true)
+			oneOff += setUpRelayer(run+1, false)
+		}
+	}
+	oneOff /= rounds
+	reused /= rounds
+	t.Logf("Relayer setup took %s with one-off containers and %s with the exec container on average over %d runs each (%.1fx)",
+		oneOff.Round(time.Millisecond), reused.Round(time.Millisecond), rounds, oneOff.Seconds()/reused.Seconds())
+}
+
 func TestInterchain_ConflictRejection(t *testing.T) {
 	t.Run("duplicate chain", func(t *testing.T) {
 		cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
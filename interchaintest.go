@@ -1,9 +1,12 @@
 package interchaintest
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 )
 
 // CreateLogFile creates a file with name in dir $HOME/.interchaintest/logs/
@@ -28,3 +31,25 @@ func DefaultBlockDatabaseFilepath() string {
 	}
 	return filepath.Join(home, ".interchaintest", "databases", "block.db")
 }
+
+// BlockDatabaseRetention selects the test cases to delete from a block database,
+// along with their chains, blocks and transactions.
+// Zero fields are ignored, and a test case is deleted if any field selects it.
+type BlockDatabaseRetention = blockdb.RetentionPolicy
+
+// PruneBlockDatabase deletes the test cases selected by retention from the sqlite database at dbPath,
+// then vacuums the database file. It returns the number of deleted test cases.
+func PruneBlockDatabase(ctx context.Context, dbPath string, retention BlockDatabaseRetention) (int64, error) {
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates a sqlite file.
+	if _, err := os.Stat(dbPath); err != nil {
+		return 0, err
+	}
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	if err != nil {
+		return 0, fmt.Errorf("connect to sqlite database %s: %w", dbPath, err)
+	}
+	defer db.Close()
+
+	res, err := blockdb.Prune(ctx, db, retention)
+	return res.TestCases, err
+}
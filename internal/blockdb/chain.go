@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"hash/fnv"
+	"time"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -85,3 +86,39 @@ func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactio
 
 	return dbTx.Commit()
 }
+
+// SaveBlockHeader records the header of the block at height, which must have been saved with SaveBlock.
+// This method is idempotent and can be safely called multiple times with the same arguments.
+func (chain *Chain) SaveBlockHeader(ctx context.Context, height uint64, header BlockHeader) error {
+	dbTx, err := chain.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	var blockID int64
+	err = dbTx.QueryRowContext(ctx, `SELECT id FROM block WHERE height = ? AND fk_chain_id = ?`, height, chain.id).Scan(&blockID)
+	if err != nil {
+		return fmt.Errorf("find block at height %d: %w", height, err)
+	}
+
+	_, err = dbTx.ExecContext(ctx, `UPDATE block SET time = ?, proposer_address = ?, gas_used = ?, signature_count = ?, app_hash = ? WHERE id = ?`,
+		header.Time.UTC().Format(time.RFC3339Nano), header.ProposerAddress, header.GasUsed, header.SignatureCount(), header.AppHash, blockID,
+	)
+	if err != nil {
+		return fmt.Errorf("update block: %w", err)
+	}
+
+	_, err = dbTx.ExecContext(ctx, `DELETE FROM block_signature WHERE fk_block_id = ?`, blockID)
+	if err != nil {
+		return fmt.Errorf("delete from block_signature: %w", err)
+	}
+	for _, sig := range header.Signatures {
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO block_signature(validator_address, signed, fk_block_id) VALUES (?, ?, ?)`, sig.ValidatorAddress, sig.Signed, blockID)
+		if err != nil {
+			return fmt.Errorf("insert into block_signature: %w", err)
+		}
+	}
+
+	return dbTx.Commit()
+}
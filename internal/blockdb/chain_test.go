@@ -183,3 +183,57 @@ ORDER BY tendermint_event_attr.id`)
 		require.Zero(t, count)
 	})
 }
+
+func TestChain_SaveBlockHeader(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	header := BlockHeader{
+		Time:            time.Date(2022, 6, 1, 12, 0, 0, 500_000_000, time.UTC),
+		ProposerAddress: "AABB",
+		GasUsed:         12345,
+		AppHash:         "CCDD",
+		Signatures: []CommitSig{
+			{ValidatorAddress: "AABB", Signed: true},
+			{ValidatorAddress: "EEFF", Signed: false},
+		},
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		chain := validChain(t, db)
+
+		require.NoError(t, chain.SaveBlock(ctx, 5, nil))
+		require.NoError(t, chain.SaveBlockHeader(ctx, 5, header))
+		// Idempotent.
+		require.NoError(t, chain.SaveBlockHeader(ctx, 5, header))
+
+		var (
+			gotTime, gotProposer, gotAppHash string
+			gotGas, gotSigCount              int64
+		)
+		row := db.QueryRow(`SELECT time, proposer_address, gas_used, signature_count, app_hash FROM block WHERE height = 5`)
+		require.NoError(t, row.Scan(&gotTime, &gotProposer, &gotGas, &gotSigCount, &gotAppHash))
+		require.Equal(t, "2022-06-01T12:00:00.5Z", gotTime)
+		require.Equal(t, "AABB", gotProposer)
+		require.EqualValues(t, 12345, gotGas)
+		require.EqualValues(t, 1, gotSigCount)
+		require.Equal(t, "CCDD", gotAppHash)
+
+		var count int
+		row = db.QueryRow(`SELECT count(*) FROM block_signature`)
+		require.NoError(t, row.Scan(&count))
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		chain := validChain(t, db)
+
+		require.Error(t, chain.SaveBlockHeader(ctx, 5, header))
+	})
+}
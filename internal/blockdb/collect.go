@@ -34,17 +34,72 @@ type EventAttribute struct {
 	Key, Value string
 }
 
+// BlockHeader is the metadata of a block, independent of its transactions.
+type BlockHeader struct {
+	Time time.Time
+
+	// ProposerAddress is the hex-encoded address of the validator that proposed the block.
+	ProposerAddress string
+
+	// GasUsed is the total gas used by the transactions of the block.
+	GasUsed int64
+
+	AppHash string
+
+	// Signatures are the validator signatures in the last commit of the block,
+	// which attest to the block at the previous height.
+	// There is one entry per validator of the previous height, including those that did not sign.
+	Signatures []CommitSig
+}
+
+// SignatureCount returns the number of validators that signed the previous block.
+func (h BlockHeader) SignatureCount() int {
+	var n int
+	for _, s := range h.Signatures {
+		if s.Signed {
+			n++
+		}
+	}
+	return n
+}
+
+// CommitSig is the signature of a validator in a block commit.
+type CommitSig struct {
+	// ValidatorAddress is the hex-encoded validator address.
+	ValidatorAddress string
+
+	// Signed is false if the validator was absent or voted nil.
+	Signed bool
+}
+
 // TxFinder finds transactions given block at height.
 type TxFinder interface {
 	FindTxs(ctx context.Context, height uint64) ([]Tx, error)
 }
 
-// BlockSaver saves transactions for block at height.
+// MultiTxFinder is implemented by chains made of several chains producing their own blocks,
+// such as a polkadot relay chain and its parachains.
+// The blocks of each chain are saved separately, keyed by chain ID.
+type MultiTxFinder interface {
+	TxFinders() map[string]TxFinder
+}
+
+// BlockHeaderFinder finds the header of the block at height.
+// A TxFinder passed to NewCollector may optionally implement BlockHeaderFinder,
+// in which case the collector also saves block headers.
+type BlockHeaderFinder interface {
+	FindBlockHeader(ctx context.Context, height uint64) (BlockHeader, error)
+}
+
+// BlockSaver saves transactions and headers for block at height.
 type BlockSaver interface {
 	SaveBlock(ctx context.Context, height uint64, txs []Tx) error
+
+	// SaveBlockHeader saves the header of a block previously saved with SaveBlock.
+	SaveBlockHeader(ctx context.Context, height uint64, header BlockHeader) error
 }
 
-// Collector saves block transactions at regular intervals.
+// Collector saves block transactions, and headers if available, at regular intervals.
 type Collector struct {
 	finder TxFinder
 	log    *zap.Logger
@@ -107,9 +162,26 @@ func (p *Collector) saveTxsForHeight(ctx context.Context, height uint64) error {
 	if err != nil {
 		return fmt.Errorf("find txs: %w", err)
 	}
+
+	// Find the header before saving anything, so that a failure retries the whole height.
+	hf, hasHeader := p.finder.(BlockHeaderFinder)
+	var header BlockHeader
+	if hasHeader {
+		header, err = hf.FindBlockHeader(ctx, height)
+		if err != nil {
+			return fmt.Errorf("find block header: %w", err)
+		}
+	}
+
 	err = p.saver.SaveBlock(ctx, height, txs)
 	if err != nil {
 		return fmt.Errorf("save block: %w", err)
 	}
+
+	if hasHeader {
+		if err := p.saver.SaveBlockHeader(ctx, height, header); err != nil {
+			return fmt.Errorf("save block header: %w", err)
+		}
+	}
 	return nil
 }
@@ -27,6 +27,28 @@ func (f mockBlockSaver) SaveBlock(ctx context.Context, height uint64, txs []Tx)
 	return f(ctx, height, txs)
 }
 
+func (f mockBlockSaver) SaveBlockHeader(ctx context.Context, height uint64, header BlockHeader) error {
+	return nil
+}
+
+type mockHeaderFinder struct {
+	mockTxFinder
+	findHeader func(ctx context.Context, height uint64) (BlockHeader, error)
+}
+
+func (f mockHeaderFinder) FindBlockHeader(ctx context.Context, height uint64) (BlockHeader, error) {
+	return f.findHeader(ctx, height)
+}
+
+type mockHeaderSaver struct {
+	mockBlockSaver
+	saveHeader func(ctx context.Context, height uint64, header BlockHeader) error
+}
+
+func (s mockHeaderSaver) SaveBlockHeader(ctx context.Context, height uint64, header BlockHeader) error {
+	return s.saveHeader(ctx, height, header)
+}
+
 func TestCollector_Collect(t *testing.T) {
 	nopLog := zap.NewNop()
 
@@ -130,6 +152,90 @@ func TestCollector_Collect(t *testing.T) {
 	})
 }
 
+func TestCollector_CollectHeaders(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		finder := mockHeaderFinder{
+			mockTxFinder: func(ctx context.Context, height uint64) ([]Tx, error) { return nil, nil },
+			findHeader: func(ctx context.Context, height uint64) (BlockHeader, error) {
+				return BlockHeader{AppHash: strconv.FormatUint(height, 10)}, nil
+			},
+		}
+
+		var (
+			mu           sync.Mutex
+			savedBlocks  []uint64
+			savedHeaders []string
+		)
+		ch := make(chan uint64, 2)
+		saver := mockHeaderSaver{
+			mockBlockSaver: func(ctx context.Context, height uint64, txs []Tx) error {
+				mu.Lock()
+				defer mu.Unlock()
+				savedBlocks = append(savedBlocks, height)
+				return nil
+			},
+			saveHeader: func(ctx context.Context, height uint64, header BlockHeader) error {
+				if height > 2 {
+					return nil
+				}
+				mu.Lock()
+				savedHeaders = append(savedHeaders, header.AppHash)
+				mu.Unlock()
+				ch <- height
+				return nil
+			},
+		}
+
+		collector := NewCollector(zap.NewNop(), finder, saver, time.Nanosecond)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			collector.Collect(context.Background())
+		}()
+
+		require.Equal(t, uint64(1), <-ch)
+		require.Equal(t, uint64(2), <-ch)
+		collector.Stop()
+		<-done
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []uint64{1, 2}, savedBlocks[:2])
+		require.Equal(t, []string{"1", "2"}, savedHeaders)
+	})
+
+	t.Run("find header error", func(t *testing.T) {
+		ch := make(chan int)
+		finder := mockHeaderFinder{
+			mockTxFinder: func(ctx context.Context, height uint64) ([]Tx, error) { return nil, nil },
+			findHeader: func(ctx context.Context, height uint64) (BlockHeader, error) {
+				defer func() { ch <- int(height) }()
+				if height == 1 {
+					return BlockHeader{}, nil
+				}
+				return BlockHeader{}, errors.New("boom")
+			},
+		}
+
+		var saved int64
+		saver := mockBlockSaver(func(ctx context.Context, height uint64, txs []Tx) error {
+			atomic.StoreInt64(&saved, int64(height))
+			return nil
+		})
+
+		collector := NewCollector(zap.NewNop(), finder, saver, time.Nanosecond)
+		defer collector.Stop()
+		go collector.Collect(context.Background())
+
+		require.Equal(t, 1, <-ch)
+		require.Equal(t, 2, <-ch)
+		require.Equal(t, 2, <-ch) // assert height stops advancing
+
+		// The block is not saved without its header.
+		require.Equal(t, int64(1), atomic.LoadInt64(&saved))
+	})
+}
+
 func TestCollector_Stop(t *testing.T) {
 	// Synchronization control to allow test to progress without a data race.
 	// Begins locked, unlocks from the finder, and the test blocks trying to re-lock it.
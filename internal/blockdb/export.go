@@ -0,0 +1,446 @@
+package blockdb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	parquetwriter "github.com/xitongsys/parquet-go/writer"
+)
+
+// ExportFormat is the format query results are written in.
+type ExportFormat string
+
+const (
+	// ExportTable writes aligned columns for reading in a terminal.
+	ExportTable ExportFormat = "table"
+	// ExportCSV writes comma separated values with a header row.
+	ExportCSV ExportFormat = "csv"
+	// ExportJSONLines writes one JSON object per row.
+	ExportJSONLines ExportFormat = "jsonl"
+	// ExportParquet writes a parquet file with one optional column per result column.
+	ExportParquet ExportFormat = "parquet"
+)
+
+// ExportFormats are all supported export formats.
+var ExportFormats = []ExportFormat{ExportTable, ExportCSV, ExportJSONLines, ExportParquet}
+
+// ParseExportFormat returns the export format named s.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	for _, f := range ExportFormats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown export format %q", s)
+}
+
+// Dataset is a predefined query of the block database.
+type Dataset string
+
+const (
+	// DatasetTestCases lists test cases, with their number of chains and transactions.
+	DatasetTestCases Dataset = "test-cases"
+	// DatasetChains lists the chains of test cases, with their height and number of transactions.
+	DatasetChains Dataset = "chains"
+	// DatasetTxs holds the transactions of the chains of a test case.
+	DatasetTxs Dataset = "txs"
+	// DatasetMessages holds the cosmos messages of the transactions of the chains of a test case.
+	DatasetMessages Dataset = "messages"
+	// DatasetPackets holds the IBC packets sent by the chains of a test case.
+	DatasetPackets Dataset = "packets"
+)
+
+// Datasets are all predefined datasets.
+var Datasets = []Dataset{DatasetTestCases, DatasetChains, DatasetTxs, DatasetMessages, DatasetPackets}
+
+// ParseDataset returns the dataset named s.
+func ParseDataset(s string) (Dataset, error) {
+	for _, d := range Datasets {
+		if string(d) == s {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("unknown dataset %q", s)
+}
+
+// datasetQueries filter by test case with a single argument, which is 0 for all test cases.
+var datasetQueries = map[Dataset]string{
+	DatasetTestCases: `SELECT
+  test_case_id, test_case_name, test_case_git_sha, test_case_created_at
+  , COUNT(chain_kid) AS chain_total
+  , SUM(tx_total) AS tx_total
+FROM v_tx_agg
+WHERE ?1 = 0 OR test_case_id = ?1
+GROUP BY test_case_id
+ORDER BY test_case_id DESC`,
+
+	DatasetChains: `SELECT
+  test_case_id, test_case_name, chain_kid, chain_id, chain_type, chain_height, tx_total
+FROM v_tx_agg
+WHERE chain_kid IS NOT NULL AND (?1 = 0 OR test_case_id = ?1)
+ORDER BY test_case_id DESC, chain_id ASC`,
+
+	DatasetTxs: `SELECT
+  test_case_id, test_case_name, chain_kid, chain_id, chain_type, block_height, block_created_at, tx_id, tx
+FROM v_tx_flattened
+WHERE ?1 = 0 OR test_case_id = ?1
+ORDER BY test_case_id DESC, chain_id ASC, block_height ASC, tx_id ASC`,
+
+	DatasetMessages: `SELECT
+  test_case_id, test_case_name, chain_kid, chain_id, block_height, tx_id, msg_n, type
+  , client_chain_id, client_id, counterparty_client_id
+  , conn_id, counterparty_conn_id
+  , port_id, counterparty_port_id
+  , channel_id, counterparty_channel_id
+  , raw
+FROM v_cosmos_messages
+WHERE ?1 = 0 OR test_case_id = ?1
+ORDER BY test_case_id DESC, chain_id ASC, block_height ASC, tx_id ASC, msg_n ASC`,
+
+	DatasetPackets: `SELECT *
+FROM v_ibc_packets
+WHERE ?1 = 0 OR test_case_id = ?1
+ORDER BY test_case_id DESC, src_chain_id ASC, src_port ASC, src_channel ASC, sequence ASC`,
+}
+
+// ExportDataset writes the dataset of the test case with testCaseID to w in format,
+// or of all test cases if testCaseID is 0.
+// It returns the number of rows written.
+func (q *Query) ExportDataset(ctx context.Context, w io.Writer, format ExportFormat, dataset Dataset, testCaseID int64) (int, error) {
+	query, ok := datasetQueries[dataset]
+	if !ok {
+		return 0, fmt.Errorf("unknown dataset %q", dataset)
+	}
+	return q.Export(ctx, w, format, query, testCaseID)
+}
+
+// Export runs the SQL query with args and writes the result to w in format.
+// The query runs on a read-only connection, so it cannot modify the database.
+// Rows are written as they are read, except in the parquet format.
+// It returns the number of rows written.
+func (q *Query) Export(ctx context.Context, w io.Writer, format ExportFormat, query string, args ...any) (int, error) {
+	conn, err := q.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA query_only = ON`); err != nil {
+		return 0, fmt.Errorf("set query only: %w", err)
+	}
+	defer func() { _, _ = conn.ExecContext(context.Background(), `PRAGMA query_only = OFF`) }()
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	var rw rowWriter
+	switch format {
+	case ExportTable:
+		rw = newTableWriter(w, cols)
+	case ExportCSV:
+		rw, err = newCSVWriter(w, cols)
+	case ExportJSONLines:
+		rw = newJSONLinesWriter(w, cols)
+	case ExportParquet:
+		rw = &parquetWriter{w: w, cols: cols}
+	default:
+		err = fmt.Errorf("unknown export format %q", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for rows.Next() {
+		record := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range record {
+			ptrs[i] = &record[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return n, err
+		}
+		for i, v := range record {
+			record[i] = exportValue(v)
+		}
+		if err := rw.WriteRow(record); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	return n, rw.Close()
+}
+
+// rowWriter writes the rows of a query result in an export format.
+// Rows are written as they are read, except by formats that need all rows, such as parquet.
+type rowWriter interface {
+	WriteRow(record []any) error
+	// Close writes any buffered rows and the end of the output.
+	Close() error
+}
+
+// exportValue normalizes a scanned sqlite value to nil, int64, float64, or string.
+// Blobs that are not valid UTF-8 are base64 encoded.
+func exportValue(v any) any {
+	switch v := v.(type) {
+	case nil, int64, float64, string:
+		return v
+	case bool:
+		if v {
+			return int64(1)
+		}
+		return int64(0)
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return v.(string)
+}
+
+// tableBlockRows is the number of rows aligned together by tableWriter.
+const tableBlockRows = 1000
+
+// tableWriter writes aligned columns, flushing them every tableBlockRows rows,
+// so columns are aligned within each block of rows rather than across the whole result.
+type tableWriter struct {
+	tw       *tabwriter.Writer
+	buffered int
+}
+
+func newTableWriter(w io.Writer, cols []string) *tableWriter {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	return &tableWriter{tw: tw}
+}
+
+func (t *tableWriter) WriteRow(record []any) error {
+	fields := make([]string, len(record))
+	for i, v := range record {
+		// Keep each row on a single line.
+		fields[i] = strings.NewReplacer("\n", " ", "\t", " ").Replace(formatValue(v))
+	}
+	if _, err := fmt.Fprintln(t.tw, strings.Join(fields, "\t")); err != nil {
+		return err
+	}
+	t.buffered++
+	if t.buffered < tableBlockRows {
+		return nil
+	}
+	t.buffered = 0
+	return t.tw.Flush()
+}
+
+func (t *tableWriter) Close() error {
+	return t.tw.Flush()
+}
+
+type csvWriter struct {
+	cw     *csv.Writer
+	fields []string
+}
+
+func newCSVWriter(w io.Writer, cols []string) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(cols); err != nil {
+		return nil, err
+	}
+	return &csvWriter{cw: cw, fields: make([]string, len(cols))}, nil
+}
+
+func (c *csvWriter) WriteRow(record []any) error {
+	for i, v := range record {
+		c.fields[i] = formatValue(v)
+	}
+	return c.cw.Write(c.fields)
+}
+
+func (c *csvWriter) Close() error {
+	c.cw.Flush()
+	return c.cw.Error()
+}
+
+type jsonLinesWriter struct {
+	enc  *json.Encoder
+	cols []string
+}
+
+func newJSONLinesWriter(w io.Writer, cols []string) *jsonLinesWriter {
+	return &jsonLinesWriter{enc: json.NewEncoder(w), cols: cols}
+}
+
+func (j *jsonLinesWriter) WriteRow(record []any) error {
+	obj := make(jsonRow, len(j.cols))
+	for i, v := range record {
+		obj[i] = jsonField{Name: j.cols[i], Value: v}
+	}
+	return j.enc.Encode(obj)
+}
+
+func (j *jsonLinesWriter) Close() error {
+	return nil
+}
+
+type jsonField struct {
+	Name  string
+	Value any
+}
+
+// jsonRow is a JSON object that keeps its fields in column order.
+type jsonRow []jsonField
+
+func (r jsonRow) MarshalJSON() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		sb.Write(name)
+		sb.WriteByte(':')
+		sb.Write(value)
+	}
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+// parquetWriter buffers all rows, as the type of each column is inferred from all of its values,
+// and writes them on Close.
+type parquetWriter struct {
+	w       io.Writer
+	cols    []string
+	records [][]any
+}
+
+func (p *parquetWriter) WriteRow(record []any) error {
+	p.records = append(p.records, record)
+	return nil
+}
+
+func (p *parquetWriter) Close() error {
+	return writeParquet(p.w, p.cols, p.records)
+}
+
+// writeParquet writes records with a column type inferred from the values of each column:
+// INT64 if all values are integers, DOUBLE if all values are numbers, and UTF8 strings otherwise.
+func writeParquet(w io.Writer, cols []string, records [][]any) error {
+	names := uniqueNames(cols)
+	kinds := make([]string, len(cols))
+	schema := make([]string, len(cols))
+	for i, name := range names {
+		kinds[i] = columnKind(records, i)
+		typ := "type=BYTE_ARRAY, convertedtype=UTF8"
+		switch kinds[i] {
+		case "int":
+			typ = "type=INT64"
+		case "float":
+			typ = "type=DOUBLE"
+		}
+		schema[i] = fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", name, typ)
+	}
+
+	pw, err := parquetwriter.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return fmt.Errorf("create parquet writer: %w", err)
+	}
+	for _, record := range records {
+		// The writer buffers rows until it flushes a row group, so each row needs its own slice.
+		row := make([]any, len(cols))
+		for i, v := range record {
+			switch {
+			case v == nil:
+			case kinds[i] == "float":
+				f, ok := v.(float64)
+				if !ok {
+					f = float64(v.(int64))
+				}
+				row[i] = f
+			case kinds[i] == "int":
+				row[i] = v
+			default:
+				row[i] = formatValue(v)
+			}
+		}
+		if err := pw.Write(row); err != nil {
+			return fmt.Errorf("write parquet row: %w", err)
+		}
+	}
+	return pw.WriteStop()
+}
+
+// columnKind returns "int", "float", or "string" for the values of column i.
+func columnKind(records [][]any, i int) string {
+	kind := "int"
+	for _, record := range records {
+		switch record[i].(type) {
+		case nil, int64:
+		case float64:
+			kind = "float"
+		default:
+			return "string"
+		}
+	}
+	return kind
+}
+
+// uniqueNames replaces characters other than letters, digits and underscores in column names, e.g. of expressions,
+// and suffixes repeated names, e.g. of joined tables, with their occurrence.
+func uniqueNames(cols []string) []string {
+	names := make([]string, len(cols))
+	seen := make(map[string]int, len(cols))
+	for i, c := range cols {
+		name := nonIdentifierRe.ReplaceAllString(c, "_")
+		seen[name]++
+		names[i] = name
+		if n := seen[name]; n > 1 {
+			names[i] = name + "_" + strconv.Itoa(n)
+		}
+	}
+	return names
+}
+
+var nonIdentifierRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
@@ -0,0 +1,177 @@
+package blockdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range ExportFormats {
+		got, err := ParseExportFormat(string(f))
+		require.NoError(t, err)
+		require.Equal(t, f, got)
+	}
+
+	_, err := ParseExportFormat("xlsx")
+	require.EqualError(t, err, `unknown export format "xlsx"`)
+}
+
+func TestQuery_Export(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	c, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, c.SaveBlock(ctx, 10, []Tx{{Data: []byte(`{"a":"1,2"}`)}, {Data: []byte("tx2")}}))
+
+	other, err := CreateTestCase(ctx, db, "test2", "sha2")
+	require.NoError(t, err)
+	c, err = other.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, c.SaveBlock(ctx, 5, []Tx{{Data: []byte("tx3")}}))
+
+	q := NewQuery(db)
+	const query = `SELECT block_height AS height, tx, NULL AS missing, 1.5 AS ratio FROM v_tx_flattened WHERE test_case_id = ? ORDER BY tx_id`
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := q.Export(ctx, &buf, ExportCSV, query, tc.id)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"height", "tx", "missing", "ratio"},
+			{"10", `{"a":"1,2"}`, "", "1.5"},
+			{"10", "tx2", "", "1.5"},
+		}, records)
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := q.Export(ctx, &buf, ExportJSONLines, query, tc.id)
+		require.NoError(t, err)
+
+		const want = `{"height":10,"tx":"{\"a\":\"1,2\"}","missing":null,"ratio":1.5}
+{"height":10,"tx":"tx2","missing":null,"ratio":1.5}
+`
+		require.Equal(t, want, buf.String())
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := q.Export(ctx, &buf, ExportTable, query, tc.id)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		require.Equal(t, []string{"height", "tx", "missing", "ratio"}, strings.Fields(lines[0]))
+		require.Equal(t, []string{"10", "tx2", "1.5"}, strings.Fields(lines[2]))
+	})
+
+	t.Run("parquet", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := q.Export(ctx, &buf, ExportParquet, query, tc.id)
+		require.NoError(t, err)
+
+		type row struct {
+			Height  *int64   `parquet:"name=height, type=INT64, repetitiontype=OPTIONAL"`
+			Tx      *string  `parquet:"name=tx, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+			Missing *string  `parquet:"name=missing, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+			Ratio   *float64 `parquet:"name=ratio, type=DOUBLE, repetitiontype=OPTIONAL"`
+		}
+		pf, err := buffer.NewBufferFile(buf.Bytes())
+		require.NoError(t, err)
+		pr, err := reader.NewParquetReader(pf, new(row), 1)
+		require.NoError(t, err)
+		defer pr.ReadStop()
+		require.EqualValues(t, 2, pr.GetNumRows())
+
+		rows := make([]row, 2)
+		require.NoError(t, pr.Read(&rows))
+		require.Len(t, rows, 2)
+		require.EqualValues(t, 10, *rows[0].Height)
+		require.Equal(t, `{"a":"1,2"}`, *rows[0].Tx)
+		require.Nil(t, rows[0].Missing)
+		require.Equal(t, 1.5, *rows[1].Ratio)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		_, err := q.Export(ctx, new(bytes.Buffer), ExportCSV, `DELETE FROM tx`)
+		require.Error(t, err)
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM tx`).Scan(&count))
+		require.Equal(t, 3, count)
+
+		// The connection is writable again afterwards.
+		_, err = CreateTestCase(ctx, db, "test3", "sha3")
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := q.Export(ctx, &buf, ExportParquet, `SELECT 1 AS id, 2 AS id`)
+		require.NoError(t, err)
+	})
+}
+
+func TestQuery_ExportDataset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	c, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, c.SaveBlock(ctx, 10, []Tx{{Data: []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"},{"@type":"/ibc.applications.transfer.v1.MsgTransfer","source_port":"transfer"}]}}`)}}))
+	_, err = tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	_, err = CreateTestCase(ctx, db, "test2", "sha2")
+	require.NoError(t, err)
+
+	q := NewQuery(db)
+	for _, tt := range []struct {
+		Dataset    Dataset
+		TestCaseID int64
+		WantRows   int
+	}{
+		{DatasetTestCases, 0, 2},
+		{DatasetTestCases, tc.id, 1},
+		{DatasetChains, 0, 2},
+		{DatasetTxs, tc.id, 1},
+		{DatasetMessages, tc.id, 2},
+		{DatasetPackets, tc.id, 0},
+	} {
+		var buf bytes.Buffer
+		n, err := q.ExportDataset(ctx, &buf, ExportCSV, tt.Dataset, tt.TestCaseID)
+		require.NoError(t, err, tt.Dataset)
+		require.Equal(t, tt.WantRows, n, tt.Dataset)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err, tt.Dataset)
+		require.Len(t, records, tt.WantRows+1, tt.Dataset)
+		require.Equal(t, "test_case_id", records[0][0], tt.Dataset)
+	}
+
+	_, err = q.ExportDataset(ctx, new(bytes.Buffer), ExportCSV, "blocks", 0)
+	require.EqualError(t, err, `unknown dataset "blocks"`)
+}
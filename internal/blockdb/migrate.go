@@ -21,6 +21,8 @@ import (
 //	│                    │          │                    │         │                    │          │                    │
 //	└────────────────────┘          └────────────────────┘         └────────────────────┘          └────────────────────┘
 //
+// Blocks optionally record header metadata, and have many Block Signatures from the validators of the previous height.
+//
 // The gitSha ensures we can trace back to the version of the codebase that produced the schema.
 // Warning: Typical best practice wraps each migration step into its own transaction. For simplicity given
 // this is an embedded database, we omit transactions.
@@ -148,6 +150,31 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	for _, col := range []struct{ name, def string }{
+		{name: "time", def: "TEXT"},
+		{name: "proposer_address", def: "TEXT"},
+		{name: "gas_used", def: "INTEGER"},
+		{name: "signature_count", def: "INTEGER"},
+		{name: "app_hash", def: "TEXT"},
+	} {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE block ADD COLUMN %s %s`, col.name, col.def))
+		if errIgnoreDuplicateColumn(err, col.name) != nil {
+			return fmt.Errorf("alter table block add %s: %w", col.name, err)
+		}
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_signature (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    validator_address TEXT NOT NULL CHECK (length(validator_address) > 0),
+    signed INTEGER NOT NULL,
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE,
+    UNIQUE(validator_address,fk_block_id)
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_signature: %w", err)
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
@@ -269,6 +296,170 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_tx_agg view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_block_intervals`)
+	if err != nil {
+		return fmt.Errorf("drop old v_block_intervals view: %w", err)
+	}
+
+	// Only blocks with a saved header have a time.
+	// The interval of the first such block of a chain is NULL.
+	_, err = tx.Exec(`CREATE VIEW v_block_intervals AS
+SELECT
+  test_case.id AS test_case_id
+  , test_case.name AS test_case_name
+  , chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block.id AS block_id
+  , block.height AS block_height
+  , block.time AS block_time
+  , (julianday(block.time) - julianday(LAG(block.time) OVER (PARTITION BY block.fk_chain_id ORDER BY block.height))) * 86400000.0 AS interval_ms
+  , block.proposer_address AS proposer_address
+  , block.gas_used AS gas_used
+  , block.signature_count AS signature_count
+  , block.app_hash AS app_hash
+  , (SELECT COUNT(*) FROM tx WHERE tx.fk_block_id = block.id) AS tx_total
+FROM block
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+WHERE block.time IS NOT NULL
+`)
+	if err != nil {
+		return fmt.Errorf("create v_block_intervals view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_missed_signatures`)
+	if err != nil {
+		return fmt.Errorf("drop old v_missed_signatures view: %w", err)
+	}
+
+	// Signatures in the last commit of a block attest to the block at the previous height.
+	_, err = tx.Exec(`CREATE VIEW v_missed_signatures AS
+SELECT
+  test_case.id AS test_case_id
+  , test_case.name AS test_case_name
+  , chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block_signature.validator_address AS validator_address
+  , COUNT(*) AS signatures_expected
+  , SUM(CASE WHEN block_signature.signed THEN 0 ELSE 1 END) AS signatures_missed
+  , MAX(CASE WHEN block_signature.signed THEN NULL ELSE block.height - 1 END) AS last_missed_height
+FROM block_signature
+LEFT JOIN block ON block_signature.fk_block_id = block.id
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+GROUP BY chain.id, block_signature.validator_address
+`)
+	if err != nil {
+		return fmt.Errorf("create v_missed_signatures view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packet_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packet_events view: %w", err)
+	}
+
+	// One row per IBC packet lifecycle event, with the packet attributes pivoted into columns.
+	// The block time falls back to the time the block was saved, for blocks without a saved header.
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packet_events AS
+SELECT
+  test_case.id AS test_case_id
+  , test_case.name AS test_case_name
+  , chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block.id AS block_id
+  , block.height AS block_height
+  , COALESCE(block.time, block.created_at) AS block_time
+  , tx.id AS tx_id
+  , tendermint_event.id AS event_id
+  , tendermint_event.type AS type
+  , CAST(MAX(CASE WHEN attr.key = "packet_sequence" THEN attr.value END) AS INTEGER) AS sequence
+  , MAX(CASE WHEN attr.key = "packet_src_port" THEN attr.value END) AS src_port
+  , MAX(CASE WHEN attr.key = "packet_src_channel" THEN attr.value END) AS src_channel
+  , MAX(CASE WHEN attr.key = "packet_dst_port" THEN attr.value END) AS dst_port
+  , MAX(CASE WHEN attr.key = "packet_dst_channel" THEN attr.value END) AS dst_channel
+  , MAX(CASE WHEN attr.key = "packet_data" THEN attr.value END) AS data
+  , MAX(CASE WHEN attr.key = "packet_ack" THEN attr.value END) AS ack
+  , MAX(CASE WHEN attr.key = "packet_timeout_height" THEN attr.value END) AS timeout_height
+  , MAX(CASE WHEN attr.key = "packet_timeout_timestamp" THEN attr.value END) AS timeout_timestamp
+FROM tendermint_event
+INNER JOIN tendermint_event_attr attr ON attr.fk_event_id = tendermint_event.id
+LEFT JOIN tx ON tendermint_event.fk_tx_id = tx.id
+LEFT JOIN block ON tx.fk_block_id = block.id
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+WHERE tendermint_event.type IN ("send_packet", "recv_packet", "write_acknowledgement", "acknowledge_packet", "timeout_packet")
+GROUP BY tendermint_event.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packet_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packets`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packets view: %w", err)
+	}
+
+	// One row per sent packet, correlated by port, channel and sequence with the events of its lifecycle:
+	// receipt and acknowledgement write on the destination chain, which is another chain of the same test case,
+	// then acknowledgement or timeout on the source chain.
+	// Latencies are in milliseconds from the block time of the send_packet event.
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packets AS
+SELECT
+  send.test_case_id AS test_case_id
+  , send.test_case_name AS test_case_name
+  , send.sequence AS sequence
+  , send.chain_kid AS src_chain_kid
+  , send.chain_id AS src_chain_id
+  , send.src_port AS src_port
+  , send.src_channel AS src_channel
+  , MIN(recv.chain_kid) AS dst_chain_kid
+  , MIN(recv.chain_id) AS dst_chain_id
+  , send.dst_port AS dst_port
+  , send.dst_channel AS dst_channel
+  , send.data AS data
+  , send.block_height AS send_height
+  , send.block_time AS send_time
+  , MIN(recv.block_height) AS recv_height
+  , MIN(recv.block_time) AS recv_time
+  , MIN(write_ack.block_height) AS write_ack_height
+  , MIN(write_ack.ack) AS ack
+  , MIN(ack.block_height) AS ack_height
+  , MIN(ack.block_time) AS ack_time
+  , MIN(timeout.block_height) AS timeout_height
+  , MIN(timeout.block_time) AS timeout_time
+  , CASE
+      WHEN MIN(ack.event_id) IS NOT NULL THEN "acknowledged"
+      WHEN MIN(timeout.event_id) IS NOT NULL THEN "timed_out"
+      WHEN MIN(recv.event_id) IS NOT NULL THEN "received"
+      ELSE "sent"
+    END AS status
+  , (julianday(MIN(recv.block_time)) - julianday(send.block_time)) * 86400000.0 AS recv_latency_ms
+  , (julianday(COALESCE(MIN(ack.block_time), MIN(timeout.block_time))) - julianday(send.block_time)) * 86400000.0 AS complete_latency_ms
+FROM v_ibc_packet_events send
+LEFT JOIN v_ibc_packet_events recv ON recv.type = "recv_packet"
+  AND recv.test_case_id = send.test_case_id AND recv.chain_kid != send.chain_kid
+  AND recv.sequence = send.sequence
+  AND recv.src_port = send.src_port AND recv.src_channel = send.src_channel
+  AND recv.dst_port = send.dst_port AND recv.dst_channel = send.dst_channel
+LEFT JOIN v_ibc_packet_events write_ack ON write_ack.type = "write_acknowledgement"
+  AND write_ack.chain_kid = recv.chain_kid
+  AND write_ack.sequence = send.sequence
+  AND write_ack.dst_port = send.dst_port AND write_ack.dst_channel = send.dst_channel
+LEFT JOIN v_ibc_packet_events ack ON ack.type = "acknowledge_packet"
+  AND ack.chain_kid = send.chain_kid
+  AND ack.sequence = send.sequence
+  AND ack.src_port = send.src_port AND ack.src_channel = send.src_channel
+LEFT JOIN v_ibc_packet_events timeout ON timeout.type = "timeout_packet"
+  AND timeout.chain_kid = send.chain_kid
+  AND timeout.sequence = send.sequence
+  AND timeout.src_port = send.src_port AND timeout.src_channel = send.src_channel
+WHERE send.type = "send_packet"
+GROUP BY send.event_id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packets view: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,138 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy selects the test cases Prune deletes.
+// Zero fields are ignored, and a test case is deleted if any field selects it.
+type RetentionPolicy struct {
+	// MaxAge deletes test cases created more than MaxAge ago.
+	MaxAge time.Duration
+
+	// KeepPerTestName deletes all but the KeepPerTestName most recent test cases of each test name.
+	KeepPerTestName int
+
+	// KeepGitShas deletes test cases of all but the KeepGitShas git commits that most recently ran a test case.
+	KeepGitShas int
+
+	// GitShas deletes test cases of these git commits.
+	GitShas []string
+}
+
+// IsZero returns true if the policy selects no test cases.
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxAge <= 0 && p.KeepPerTestName <= 0 && p.KeepGitShas <= 0 && len(p.GitShas) == 0
+}
+
+// PruneResult summarizes the result of Prune.
+type PruneResult struct {
+	// TestCases is the number of deleted test cases.
+	TestCases int64
+	// SizeBefore and SizeAfter are the size of the database in bytes, before pruning and after vacuuming.
+	SizeBefore, SizeAfter int64
+}
+
+// Prune deletes the test cases selected by policy, along with their chains, blocks, transactions and events.
+// If any test case is deleted, the database is vacuumed afterwards, to return the freed pages to the file system.
+func Prune(ctx context.Context, db *sql.DB, policy RetentionPolicy) (PruneResult, error) {
+	var res PruneResult
+	if policy.IsZero() {
+		return res, nil
+	}
+
+	// Foreign keys, which cascade the deletion of test cases, are enabled per connection,
+	// and vacuum requires no other statements in progress on the connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`); err != nil {
+		return res, fmt.Errorf("pragma foreign_keys: %w", err)
+	}
+	if res.SizeBefore, err = databaseSize(ctx, conn); err != nil {
+		return res, err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	where, args := policy.where()
+	r, err := tx.ExecContext(ctx, `DELETE FROM test_case WHERE `+where, args...)
+	if err != nil {
+		return res, fmt.Errorf("delete test cases: %w", err)
+	}
+	if res.TestCases, err = r.RowsAffected(); err != nil {
+		return res, err
+	}
+	if err := tx.Commit(); err != nil {
+		return res, fmt.Errorf("commit: %w", err)
+	}
+
+	if res.TestCases > 0 {
+		if _, err := conn.ExecContext(ctx, `VACUUM`); err != nil {
+			return res, fmt.Errorf("vacuum: %w", err)
+		}
+		// Vacuuming goes through the write-ahead log, so truncate it too.
+		if _, err := conn.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+			return res, fmt.Errorf("checkpoint: %w", err)
+		}
+	}
+
+	res.SizeAfter, err = databaseSize(ctx, conn)
+	return res, err
+}
+
+// where returns the condition on test_case rows that selects the test cases to delete.
+func (p RetentionPolicy) where() (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	if p.MaxAge > 0 {
+		// created_at is formatted as RFC3339 in UTC, so it sorts chronologically.
+		conds = append(conds, `created_at < ?`)
+		args = append(args, time.Now().UTC().Add(-p.MaxAge).Format(time.RFC3339))
+	}
+	if p.KeepPerTestName > 0 {
+		conds = append(conds, `id IN (
+  SELECT id FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY name ORDER BY created_at DESC, id DESC) AS n FROM test_case
+  ) WHERE n > ?
+)`)
+		args = append(args, p.KeepPerTestName)
+	}
+	if p.KeepGitShas > 0 {
+		conds = append(conds, `git_sha NOT IN (
+  SELECT git_sha FROM test_case GROUP BY git_sha ORDER BY MAX(created_at) DESC, MAX(id) DESC LIMIT ?
+)`)
+		args = append(args, p.KeepGitShas)
+	}
+	if len(p.GitShas) > 0 {
+		conds = append(conds, `git_sha IN (?`+strings.Repeat(`, ?`, len(p.GitShas)-1)+`)`)
+		for _, sha := range p.GitShas {
+			args = append(args, sha)
+		}
+	}
+	return strings.Join(conds, " OR "), args
+}
+
+func databaseSize(ctx context.Context, conn *sql.Conn) (int64, error) {
+	var pages, pageSize int64
+	if err := conn.QueryRowContext(ctx, `PRAGMA page_count`).Scan(&pages); err != nil {
+		return 0, fmt.Errorf("page count: %w", err)
+	}
+	if err := conn.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("page size: %w", err)
+	}
+	return pages * pageSize, nil
+}
@@ -0,0 +1,101 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// setup creates test cases named test1 and test2, alternating, each created a day after the previous one,
+	// at git shas sha0, sha1, ... in order.
+	setup := func(t *testing.T, n int) *sql.DB {
+		db, err := ConnectDB(ctx, filepath.Join(t.TempDir(), "blocks.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		require.NoError(t, Migrate(db, "test"))
+
+		for i := 0; i < n; i++ {
+			tc, err := CreateTestCase(ctx, db, []string{"test1", "test2"}[i%2], "sha"+string(rune('0'+i)))
+			require.NoError(t, err)
+			c, err := tc.AddChain(ctx, "chain", "cosmos")
+			require.NoError(t, err)
+			require.NoError(t, c.SaveBlock(ctx, 1, []Tx{{Data: []byte(`{"tx":1}`), Events: []Event{{Type: "e", Attributes: []EventAttribute{{Key: "k", Value: "v"}}}}}}))
+
+			createdAt := now.Add(time.Duration(i-n) * 24 * time.Hour).Format(time.RFC3339)
+			_, err = db.Exec(`UPDATE test_case SET created_at = ? WHERE id = ?`, createdAt, tc.id)
+			require.NoError(t, err)
+		}
+		return db
+	}
+
+	remaining := func(t *testing.T, db *sql.DB) []string {
+		rows, err := db.Query(`SELECT git_sha FROM test_case ORDER BY id`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var shas []string
+		for rows.Next() {
+			var sha string
+			require.NoError(t, rows.Scan(&sha))
+			shas = append(shas, sha)
+		}
+		require.NoError(t, rows.Err())
+		return shas
+	}
+
+	for _, tt := range []struct {
+		Name   string
+		Policy RetentionPolicy
+		Want   []string
+	}{
+		{"zero", RetentionPolicy{}, []string{"sha0", "sha1", "sha2", "sha3", "sha4"}},
+		{"max age", RetentionPolicy{MaxAge: 36 * time.Hour}, []string{"sha4"}},
+		{"keep per test name", RetentionPolicy{KeepPerTestName: 1}, []string{"sha3", "sha4"}},
+		{"keep git shas", RetentionPolicy{KeepGitShas: 2}, []string{"sha3", "sha4"}},
+		{"git shas", RetentionPolicy{GitShas: []string{"sha1", "sha3"}}, []string{"sha0", "sha2", "sha4"}},
+		{"combined", RetentionPolicy{KeepPerTestName: 2, GitShas: []string{"sha4"}}, []string{"sha1", "sha2", "sha3"}},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			db := setup(t, 5)
+			res, err := Prune(ctx, db, tt.Policy)
+			require.NoError(t, err)
+			require.Equal(t, tt.Want, remaining(t, db))
+			require.EqualValues(t, 5-len(tt.Want), res.TestCases)
+
+			for _, table := range []string{"chain", "block", "tx", "tendermint_event", "tendermint_event_attr"} {
+				var count int
+				require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM `+table).Scan(&count))
+				require.Equal(t, len(tt.Want), count, table)
+			}
+		})
+	}
+
+	t.Run("vacuum", func(t *testing.T) {
+		t.Parallel()
+
+		db := setup(t, 5)
+		tc, err := CreateTestCase(ctx, db, "big", "sha-big")
+		require.NoError(t, err)
+		c, err := tc.AddChain(ctx, "chain", "cosmos")
+		require.NoError(t, err)
+		big := make([]byte, 1<<20)
+		require.NoError(t, c.SaveBlock(ctx, 1, []Tx{{Data: big}}))
+
+		res, err := Prune(ctx, db, RetentionPolicy{GitShas: []string{"sha-big"}})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, res.TestCases)
+		require.Greater(t, res.SizeBefore-res.SizeAfter, int64(1<<20)/2)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -124,7 +125,13 @@ type CosmosMessageResult struct {
 // CosmosMessages returns a summary of Cosmos messages for the chainID. In Cosmos, a transaction may have 1 or more
 // associated messages.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
-func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMessageResult, error) {
+// Only messages of transactions matching filter are returned, and filter.MessageType further narrows the messages.
+func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64, filter TxFilter) ([]CosmosMessageResult, error) {
+	where, args := filter.where("tx_id", "block_height")
+	if filter.MessageType != "" {
+		where += ` AND type LIKE ? ESCAPE '\'`
+		args = append(args, likePattern(filter.MessageType))
+	}
 	rows, err := q.db.QueryContext(ctx, `SELECT 
         block_height
         , msg_n -- message index or position within the tx
@@ -139,8 +146,8 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
         , channel_id
         , counterparty_channel_id
     FROM v_cosmos_messages
-    WHERE chain_kid = ?
-    ORDER BY block_height ASC , msg_n ASC`, chainPkey)
+    WHERE chain_kid = ?`+where+`
+    ORDER BY block_height ASC , msg_n ASC`, append([]any{chainPkey}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,14 +181,71 @@ type TxResult struct {
 	Tx     []byte
 }
 
-// Transactions returns TxResults only for blocks with transactions present.
+// TxFilter narrows the transactions returned by queries. The zero value matches all transactions.
+type TxFilter struct {
+	// Text matches transactions whose data, or whose event attribute keys or values, contain Text.
+	// Matching is case-insensitive for ASCII.
+	Text string
+
+	// MessageType matches transactions with a cosmos message whose type contains MessageType, e.g. "MsgTransfer".
+	MessageType string
+
+	// MinHeight and MaxHeight are the inclusive bounds of the block height. Zero means unbounded.
+	MinHeight, MaxHeight int64
+}
+
+// IsZero returns true if the filter matches all transactions.
+func (f TxFilter) IsZero() bool {
+	return f == TxFilter{}
+}
+
+// where returns conditions, each starting with AND, to filter rows by the given tx id and block height columns.
+func (f TxFilter) where(txIDCol, heightCol string) (string, []any) {
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	if f.MinHeight > 0 {
+		fmt.Fprintf(&sb, " AND %s >= ?", heightCol)
+		args = append(args, f.MinHeight)
+	}
+	if f.MaxHeight > 0 {
+		fmt.Fprintf(&sb, " AND %s <= ?", heightCol)
+		args = append(args, f.MaxHeight)
+	}
+	if f.Text != "" {
+		fmt.Fprintf(&sb, ` AND %[1]s IN (
+      SELECT id FROM tx WHERE data LIKE ? ESCAPE '\'
+      UNION
+      SELECT tendermint_event.fk_tx_id FROM tendermint_event
+      INNER JOIN tendermint_event_attr ON tendermint_event_attr.fk_event_id = tendermint_event.id
+      WHERE tendermint_event_attr.key LIKE ? ESCAPE '\' OR tendermint_event_attr.value LIKE ? ESCAPE '\'
+    )`, txIDCol)
+		pattern := likePattern(f.Text)
+		args = append(args, pattern, pattern, pattern)
+	}
+	if f.MessageType != "" {
+		fmt.Fprintf(&sb, ` AND %s IN (SELECT tx_id FROM v_cosmos_messages WHERE type LIKE ? ESCAPE '\')`, txIDCol)
+		args = append(args, likePattern(f.MessageType))
+	}
+	return sb.String(), args
+}
+
+// likePattern returns a LIKE pattern, escaped with backslash, that matches strings containing s.
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
+
+// Transactions returns TxResults only for blocks with transactions present, and only the transactions matching filter.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
-func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult, error) {
+func (q *Query) Transactions(ctx context.Context, chainPkey int64, filter TxFilter) ([]TxResult, error) {
+	where, args := filter.where("tx.id", "block.height")
 	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data FROM tx 
     INNER JOIN block on tx.fk_block_id = block.id
     INNER JOIN chain on block.fk_chain_id = chain.id
-    WHERE chain.id = ?
-    ORDER BY block.height ASC, tx.id ASC`, chainPkey)
+    WHERE chain.id = ?`+where+`
+    ORDER BY block.height ASC, tx.id ASC`, append([]any{chainPkey}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -198,3 +262,113 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 
 	return results, nil
 }
+
+// IBCPacketResult is an IBC packet sent by a chain of a test case, with the state of its lifecycle.
+type IBCPacketResult struct {
+	Sequence int64
+
+	SrcChainPKey int64
+	SrcChainID   string
+	SrcPort      string
+	SrcChannel   string
+
+	// The destination chain is only known once the packet is received.
+	DstChainPKey sql.NullInt64
+	DstChainID   sql.NullString
+	DstPort      string
+	DstChannel   string
+
+	Data sql.NullString
+	Ack  sql.NullString
+
+	SendHeight     int64
+	RecvHeight     sql.NullInt64
+	WriteAckHeight sql.NullInt64
+	AckHeight      sql.NullInt64
+	TimeoutHeight  sql.NullInt64
+
+	// Status is one of "sent", "received", "acknowledged" or "timed_out".
+	Status string
+
+	// RecvLatency is the time from the send block to the receive block.
+	RecvLatency sql.NullFloat64 // milliseconds
+	// CompleteLatency is the time from the send block to the acknowledgement or timeout block.
+	CompleteLatency sql.NullFloat64 // milliseconds
+}
+
+// IBCPackets returns the IBC packets sent by the chains of the test case,
+// correlated with their receipt, acknowledgement and timeout across chains.
+func (q *Query) IBCPackets(ctx context.Context, testCaseID int64) ([]IBCPacketResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        sequence
+        , src_chain_kid, src_chain_id, src_port, src_channel
+        , dst_chain_kid, dst_chain_id, dst_port, dst_channel
+        , data, ack
+        , send_height, recv_height, write_ack_height, ack_height, timeout_height
+        , status, recv_latency_ms, complete_latency_ms
+    FROM v_ibc_packets
+    WHERE test_case_id = ?
+    ORDER BY send_height ASC, src_chain_id ASC, sequence ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []IBCPacketResult
+	for rows.Next() {
+		var res IBCPacketResult
+		if err := rows.Scan(
+			&res.Sequence,
+			&res.SrcChainPKey, &res.SrcChainID, &res.SrcPort, &res.SrcChannel,
+			&res.DstChainPKey, &res.DstChainID, &res.DstPort, &res.DstChannel,
+			&res.Data, &res.Ack,
+			&res.SendHeight, &res.RecvHeight, &res.WriteAckHeight, &res.AckHeight, &res.TimeoutHeight,
+			&res.Status, &res.RecvLatency, &res.CompleteLatency,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// IBCPacketEventResult is a single event in the lifecycle of an IBC packet.
+type IBCPacketEventResult struct {
+	ChainPKey int64
+	ChainID   string
+	Height    int64
+	Type      string // One of send_packet, recv_packet, write_acknowledgement, acknowledge_packet or timeout_packet.
+
+	Sequence   int64
+	SrcPort    string
+	SrcChannel string
+	DstPort    string
+	DstChannel string
+}
+
+// IBCPacketEvents returns the lifecycle events of the IBC packets of the test case, across all its chains.
+func (q *Query) IBCPacketEvents(ctx context.Context, testCaseID int64) ([]IBCPacketEventResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        chain_kid, chain_id, block_height, type
+        , sequence, src_port, src_channel, dst_port, dst_channel
+    FROM v_ibc_packet_events
+    WHERE test_case_id = ?
+    ORDER BY block_time ASC, chain_id ASC, event_id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []IBCPacketEventResult
+	for rows.Next() {
+		var res IBCPacketEventResult
+		if err := rows.Scan(
+			&res.ChainPKey, &res.ChainID, &res.Height, &res.Type,
+			&res.Sequence, &res.SrcPort, &res.SrcChannel, &res.DstPort, &res.DstChannel,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
@@ -137,7 +137,7 @@ func TestQuery_CosmosMessages(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	results, err := NewQuery(db).CosmosMessages(ctx, chain.id)
+	results, err := NewQuery(db).CosmosMessages(ctx, chain.id, TxFilter{})
 	require.NoError(t, err)
 	require.NotEmpty(t, results)
 
@@ -167,6 +167,11 @@ func TestQuery_CosmosMessages(t *testing.T) {
 			res.ChannelID.Valid || res.CounterpartyChannelID.Valid
 		require.Truef(t, atLeastOnePresent, "IBC messages must contain valid IBC info for %+v", res)
 	}
+
+	filtered, err := NewQuery(db).CosmosMessages(ctx, chain.id, TxFilter{MessageType: "connectionopeninit", MaxHeight: 2})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, third, filtered[0])
 }
 
 func TestQuery_Transactions(t *testing.T) {
@@ -186,7 +191,7 @@ func TestQuery_Transactions(t *testing.T) {
 		require.NoError(t, chain.SaveBlock(ctx, 12, []Tx{{Data: []byte(`1`)}}))
 		require.NoError(t, chain.SaveBlock(ctx, 14, []Tx{{Data: []byte(`2`)}, {Data: []byte(`3`)}}))
 
-		results, err := NewQuery(db).Transactions(ctx, chain.id)
+		results, err := NewQuery(db).Transactions(ctx, chain.id, TxFilter{})
 		require.NoError(t, err)
 
 		require.Len(t, results, 3)
@@ -201,6 +206,50 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Equal(t, "3", string(results[2].Tx))
 	})
 
+	t.Run("filter", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		tc, err := CreateTestCase(ctx, db, "test", "abc123")
+		require.NoError(t, err)
+		chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+		require.NoError(t, err)
+
+		transfer := `{"body":{"messages":[{"@type":"/ibc.applications.transfer.v1.MsgTransfer"}]}}`
+		send := `{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","amount":"100_%"}]}}`
+		require.NoError(t, chain.SaveBlock(ctx, 10, []Tx{{Data: []byte(transfer)}}))
+		require.NoError(t, chain.SaveBlock(ctx, 11, []Tx{
+			{Data: []byte(send), Events: []Event{{Type: "transfer", Attributes: []EventAttribute{{Key: "recipient", Value: "Cosmos1Recipient"}}}}},
+		}))
+		require.NoError(t, chain.SaveBlock(ctx, 12, []Tx{{Data: []byte(transfer)}}))
+
+		for _, tt := range []struct {
+			Filter      TxFilter
+			WantHeights []int64
+		}{
+			{TxFilter{}, []int64{10, 11, 12}},
+			{TxFilter{MinHeight: 11}, []int64{11, 12}},
+			{TxFilter{MaxHeight: 11}, []int64{10, 11}},
+			{TxFilter{MinHeight: 11, MaxHeight: 11}, []int64{11}},
+			{TxFilter{MessageType: "MsgTransfer"}, []int64{10, 12}},
+			{TxFilter{MessageType: "MsgTransfer", MinHeight: 11}, []int64{12}},
+			{TxFilter{Text: "cosmos1recipient"}, []int64{11}},
+			{TxFilter{Text: "msgsend"}, []int64{11}},
+			{TxFilter{Text: "100_%"}, []int64{11}},
+			{TxFilter{Text: "1000"}, nil},
+			{TxFilter{Text: "1%0"}, nil},
+		} {
+			results, err := NewQuery(db).Transactions(ctx, chain.id, tt.Filter)
+			require.NoError(t, err, tt.Filter)
+
+			var heights []int64
+			for _, res := range results {
+				heights = append(heights, res.Height)
+			}
+			require.Equal(t, tt.WantHeights, heights, tt.Filter)
+		}
+	})
+
 	t.Run("no txs", func(t *testing.T) {
 		db := migratedDB()
 		defer db.Close()
@@ -210,9 +259,113 @@ func TestQuery_Transactions(t *testing.T) {
 		chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
 		require.NoError(t, err)
 
-		results, err := NewQuery(db).Transactions(ctx, chain.id)
+		results, err := NewQuery(db).Transactions(ctx, chain.id, TxFilter{})
 		require.NoError(t, err)
 
 		require.Len(t, results, 0)
 	})
 }
+
+func TestQuery_IBCPackets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	packetEvent := func(typ string, seq, srcChannel, dstChannel string, extra ...EventAttribute) Event {
+		return Event{
+			Type: typ,
+			Attributes: append([]EventAttribute{
+				{Key: "packet_sequence", Value: seq},
+				{Key: "packet_src_port", Value: "transfer"},
+				{Key: "packet_src_channel", Value: srcChannel},
+				{Key: "packet_dst_port", Value: "transfer"},
+				{Key: "packet_dst_channel", Value: dstChannel},
+			}, extra...),
+		}
+	}
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	gaia, err := tc.AddChain(ctx, "gaia-1", "cosmos")
+	require.NoError(t, err)
+	osmo, err := tc.AddChain(ctx, "osmosis-1", "cosmos")
+	require.NoError(t, err)
+
+	// A packet with the same channels and sequence in another test case must not be correlated.
+	otherTC, err := CreateTestCase(ctx, db, "test2", "sha1")
+	require.NoError(t, err)
+	otherChain, err := otherTC.AddChain(ctx, "osmosis-1", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetEvent("recv_packet", "2", "channel-0", "channel-1"),
+	}}}))
+
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	saveBlock := func(c *Chain, height uint64, at time.Duration, events ...Event) {
+		require.NoError(t, c.SaveBlock(ctx, height, []Tx{{Data: []byte(`{}`), Events: events}}))
+		require.NoError(t, c.SaveBlockHeader(ctx, height, BlockHeader{Time: start.Add(at)}))
+	}
+
+	// Sequence 1 completes; sequence 2 is received but not yet acknowledged; sequence 3 times out.
+	saveBlock(gaia, 10, 0,
+		packetEvent("send_packet", "1", "channel-0", "channel-1", EventAttribute{Key: "packet_data", Value: `{"amount":"1"}`}),
+		packetEvent("send_packet", "2", "channel-0", "channel-1"),
+		packetEvent("send_packet", "3", "channel-0", "channel-1"),
+	)
+	saveBlock(osmo, 20, 2*time.Second,
+		packetEvent("recv_packet", "1", "channel-0", "channel-1"),
+		packetEvent("write_acknowledgement", "1", "channel-0", "channel-1", EventAttribute{Key: "packet_ack", Value: `{"result":"AQ=="}`}),
+		packetEvent("recv_packet", "2", "channel-0", "channel-1"),
+	)
+	saveBlock(gaia, 11, 5*time.Second,
+		packetEvent("acknowledge_packet", "1", "channel-0", "channel-1"),
+		packetEvent("timeout_packet", "3", "channel-0", "channel-1"),
+	)
+
+	q := NewQuery(db)
+	packets, err := q.IBCPackets(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, packets, 3)
+
+	p := packets[0]
+	require.EqualValues(t, 1, p.Sequence)
+	require.Equal(t, "acknowledged", p.Status)
+	require.Equal(t, "gaia-1", p.SrcChainID)
+	require.Equal(t, "osmosis-1", p.DstChainID.String)
+	require.Equal(t, osmo.id, p.DstChainPKey.Int64)
+	require.Equal(t, "channel-0", p.SrcChannel)
+	require.Equal(t, "channel-1", p.DstChannel)
+	require.Equal(t, `{"amount":"1"}`, p.Data.String)
+	require.Equal(t, `{"result":"AQ=="}`, p.Ack.String)
+	require.EqualValues(t, 10, p.SendHeight)
+	require.EqualValues(t, 20, p.RecvHeight.Int64)
+	require.EqualValues(t, 20, p.WriteAckHeight.Int64)
+	require.EqualValues(t, 11, p.AckHeight.Int64)
+	require.InDelta(t, 2000, p.RecvLatency.Float64, 1)
+	require.InDelta(t, 5000, p.CompleteLatency.Float64, 1)
+
+	p = packets[1]
+	require.EqualValues(t, 2, p.Sequence)
+	require.Equal(t, "received", p.Status)
+	require.EqualValues(t, 20, p.RecvHeight.Int64)
+	require.False(t, p.AckHeight.Valid)
+	require.False(t, p.CompleteLatency.Valid)
+
+	p = packets[2]
+	require.EqualValues(t, 3, p.Sequence)
+	require.Equal(t, "timed_out", p.Status)
+	require.False(t, p.DstChainID.Valid)
+	require.False(t, p.RecvLatency.Valid)
+	require.EqualValues(t, 11, p.TimeoutHeight.Int64)
+	require.InDelta(t, 5000, p.CompleteLatency.Float64, 1)
+
+	events, err := q.IBCPacketEvents(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, events, 8)
+	require.Equal(t, "send_packet", events[0].Type)
+	require.Equal(t, "gaia-1", events[0].ChainID)
+	require.Equal(t, "timeout_packet", events[7].Type)
+}
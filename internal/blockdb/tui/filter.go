@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb/tui/presenter"
+)
+
+// Labels of the filter form fields.
+const (
+	filterTextLabel        = "Search"
+	filterMessageTypeLabel = "Message Type"
+	filterMinHeightLabel   = "Min Height"
+	filterMaxHeightLabel   = "Max Height"
+)
+
+// filterFormView edits filter. Apply is called with the edited filter, or with a zero filter to clear it.
+// Fail is called if the form is invalid.
+func filterFormView(filter blockdb.TxFilter, apply func(blockdb.TxFilter), fail func(error)) *tview.Form {
+	pres := presenter.TxFilter{Filter: filter}
+	form := tview.NewForm().
+		AddInputField(filterTextLabel, pres.Text(), 40, nil, nil).
+		AddInputField(filterMessageTypeLabel, pres.MessageType(), 40, nil, nil).
+		AddInputField(filterMinHeightLabel, pres.MinHeight(), 12, tview.InputFieldInteger, nil).
+		AddInputField(filterMaxHeightLabel, pres.MaxHeight(), 12, tview.InputFieldInteger, nil)
+
+	text := func(label string) string {
+		return form.GetFormItemByLabel(label).(*tview.InputField).GetText()
+	}
+	form.AddButton("Apply", func() {
+		filter, err := parseTxFilter(text(filterTextLabel), text(filterMessageTypeLabel), text(filterMinHeightLabel), text(filterMaxHeightLabel))
+		if err != nil {
+			fail(err)
+			return
+		}
+		apply(filter)
+	})
+	form.AddButton("Clear", func() {
+		apply(blockdb.TxFilter{})
+	})
+
+	form.SetFieldBackgroundColor(backgroundColor).
+		SetFieldTextColor(searchActiveColor).
+		SetButtonBackgroundColor(searchInactiveColor).
+		SetLabelColor(textColor).
+		SetBackgroundColor(backgroundColor)
+	form.SetTitle("Filter Transactions and Messages").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderPadding(1, 1, 1, 1).
+		SetBorderAttributes(tcell.AttrDim)
+	return form
+}
+
+func parseTxFilter(text, msgType, minHeight, maxHeight string) (blockdb.TxFilter, error) {
+	filter := blockdb.TxFilter{
+		Text:        strings.TrimSpace(text),
+		MessageType: strings.TrimSpace(msgType),
+	}
+	var err error
+	if filter.MinHeight, err = parseHeight(minHeight); err != nil {
+		return filter, fmt.Errorf("min height: %w", err)
+	}
+	if filter.MaxHeight, err = parseHeight(maxHeight); err != nil {
+		return filter, fmt.Errorf("max height: %w", err)
+	}
+	if filter.MinHeight > 0 && filter.MaxHeight > 0 && filter.MinHeight > filter.MaxHeight {
+		return filter, fmt.Errorf("min height %d is greater than max height %d", filter.MinHeight, filter.MaxHeight)
+	}
+	return filter, nil
+}
+
+func parseHeight(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	h, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 {
+		return 0, fmt.Errorf("%d must not be negative", h)
+	}
+	return h, nil
+}
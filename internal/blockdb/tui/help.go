@@ -40,15 +40,25 @@ var (
 	}
 
 	keyMap = map[mainContent][]keyBinding{
-		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"enter", "view txs"}}, tableNavKeys),
-		cosmosMessagesMain: bindingsWithBase(tableNavKeys),
+		testCasesMain: bindingsWithBase([]keyBinding{
+			{"m", "cosmos messages"},
+			{"p", "ibc packets"},
+			{"enter", "view txs"},
+			{"f", "filter"},
+			{"l", "toggle live"},
+		}, tableNavKeys),
+		cosmosMessagesMain: bindingsWithBase([]keyBinding{{"f", "filter"}, {"l", "toggle live"}}, tableNavKeys),
+		ibcPacketsMain:     bindingsWithBase([]keyBinding{{"l", "toggle live"}}, tableNavKeys),
 		txDetailMain: bindingsWithBase([]keyBinding{
 			{"[", "previous tx"},
 			{"]", "next tx"},
 			{"/", "toggle search"},
 			{"c", "copy all txs"},
+			{"f", "filter"},
+			{"l", "toggle live"},
 		}, textNavKeys),
 		errorModalMain: bindingsWithBase(nil),
+		filterFormMain: bindingsWithBase([]keyBinding{{"tab", "next field"}, {"enter", "apply"}}),
 	}
 )
 
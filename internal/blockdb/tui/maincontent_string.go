@@ -11,12 +11,14 @@ func _() {
 	_ = x[testCasesMain-0]
 	_ = x[cosmosMessagesMain-1]
 	_ = x[txDetailMain-2]
-	_ = x[errorModalMain-3]
+	_ = x[ibcPacketsMain-3]
+	_ = x[errorModalMain-4]
+	_ = x[filterFormMain-5]
 }
 
-const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMain"
+const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainibcPacketsMainerrorModalMainfilterFormMain"
 
-var _mainContent_index = [...]uint8{0, 13, 31, 43, 57}
+var _mainContent_index = [...]uint8{0, 13, 31, 43, 57, 71, 85}
 
 func (i mainContent) String() string {
 	if i < 0 || i >= mainContent(len(_mainContent_index)-1) {
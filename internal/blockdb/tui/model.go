@@ -18,9 +18,14 @@ const (
 	testCasesMain mainContent = iota
 	cosmosMessagesMain
 	txDetailMain
+	ibcPacketsMain
 	errorModalMain
+	filterFormMain
 )
 
+// recentTestCasesLimit is the number of test cases re-queried when refreshing the test cases view.
+const recentTestCasesLimit = 100
+
 type mainStack []mainContent
 
 func (stack mainStack) Push(s mainContent) []mainContent { return append(stack, s) }
@@ -29,8 +34,10 @@ func (stack mainStack) Pop() []mainContent               { return stack[:len(sta
 
 // QueryService fetches data from a database.
 type QueryService interface {
-	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
-	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	RecentTestCases(ctx context.Context, limit int) ([]blockdb.TestCaseResult, error)
+	CosmosMessages(ctx context.Context, chainPkey int64, filter blockdb.TxFilter) ([]blockdb.CosmosMessageResult, error)
+	Transactions(ctx context.Context, chainPkey int64, filter blockdb.TxFilter) ([]blockdb.TxResult, error)
+	IBCPackets(ctx context.Context, testCaseID int64) ([]blockdb.IBCPacketResult, error)
 }
 
 // Model encapsulates state that updates a view.
@@ -46,6 +53,15 @@ type Model struct {
 	// stack keeps tracks of primary content pushed and popped
 	stack mainStack
 
+	// refreshers re-query the database and re-render the main content in the stack.
+	refreshers map[mainContent]func(ctx context.Context) error
+
+	// filter narrows the transactions and cosmos messages of a chain.
+	filter blockdb.TxFilter
+
+	// live refreshes the current main content on every tick of Tail.
+	live bool
+
 	// write to the system clipboard
 	clipboard func(text string) error
 }
@@ -67,6 +83,9 @@ func NewModel(
 		stack:         mainStack{testCasesMain},
 		clipboard:     clipboard.WriteAll,
 	}
+	m.refreshers = map[mainContent]func(ctx context.Context) error{
+		testCasesMain: m.refreshTestCases,
+	}
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.SetBackgroundColor(backgroundColor).SetBorder(false)
@@ -88,3 +107,46 @@ func NewModel(
 func (m *Model) RootView() *tview.Flex {
 	return m.layout
 }
+
+// SetLive turns live mode on or off. In live mode, the current main content is refreshed on every tick of Tail,
+// so new blocks and transactions appear while a test is writing to the database.
+// SetLive must be called from the main goroutine.
+func (m *Model) SetLive(live bool) {
+	m.live = live
+	m.updateStatus()
+}
+
+// Tail calls Refresh every interval while live mode is on, until ctx is done.
+// Because views must be updated from the main goroutine, each refresh is run by queueUpdate,
+// which should be *(tview.Application).QueueUpdateDraw.
+func (m *Model) Tail(ctx context.Context, interval time.Duration, queueUpdate func(f func())) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			queueUpdate(func() {
+				if m.live {
+					m.Refresh(ctx)
+				}
+			})
+		}
+	}
+}
+
+// Refresh re-queries the database and re-renders the current main content, keeping the selected row or tx.
+// If the last row or tx is selected, the selection follows newly added rows or txs.
+// Refresh must be called from the main goroutine.
+func (m *Model) Refresh(ctx context.Context) {
+	refresh := m.refreshers[m.stack.Current()]
+	if refresh == nil {
+		return
+	}
+	if err := refresh(ctx); err != nil {
+		// Stop live mode, otherwise every tick would push another error.
+		m.SetLive(false)
+		m.pushErrorModal(err)
+	}
+}
@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// IBCPacket presents a blockdb.IBCPacketResult.
+type IBCPacket struct {
+	Result blockdb.IBCPacketResult
+}
+
+func (p IBCPacket) Sequence() string { return strconv.FormatInt(p.Result.Sequence, 10) }
+
+// Source is the sending chain, port and channel, e.g. gaia-1 transfer/channel-0.
+func (p IBCPacket) Source() string {
+	return p.Result.SrcChainID + " " + p.Result.SrcPort + "/" + p.Result.SrcChannel
+}
+
+// Destination is the receiving chain, port and channel.
+// The chain is omitted until the packet is received.
+func (p IBCPacket) Destination() string {
+	return strings.TrimSpace(p.Result.DstChainID.String + " " + p.Result.DstPort + "/" + p.Result.DstChannel)
+}
+
+// Status is a human-readable packet status, e.g. "timed out".
+func (p IBCPacket) Status() string { return strings.ReplaceAll(p.Result.Status, "_", " ") }
+
+// Heights are the heights of the packet lifecycle events, e.g. 10 → 20 → 11.
+func (p IBCPacket) Heights() string {
+	heights := []string{strconv.FormatInt(p.Result.SendHeight, 10)}
+	for _, h := range []sql.NullInt64{p.Result.RecvHeight, p.Result.AckHeight, p.Result.TimeoutHeight} {
+		if h.Valid {
+			heights = append(heights, strconv.FormatInt(h.Int64, 10))
+		}
+	}
+	return strings.Join(heights, " → ")
+}
+
+func (p IBCPacket) RecvLatency() string     { return formatLatency(p.Result.RecvLatency) }
+func (p IBCPacket) CompleteLatency() string { return formatLatency(p.Result.CompleteLatency) }
+
+func formatLatency(ms sql.NullFloat64) string {
+	if !ms.Valid {
+		return ""
+	}
+	d := time.Duration(ms.Float64 * float64(time.Millisecond))
+	return fmt.Sprint(d.Round(time.Millisecond))
+}
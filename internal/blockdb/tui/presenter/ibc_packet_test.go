@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBCPacket(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		result := blockdb.IBCPacketResult{
+			Sequence:        7,
+			SrcChainID:      "gaia-1",
+			SrcPort:         "transfer",
+			SrcChannel:      "channel-0",
+			DstChainID:      sql.NullString{String: "osmosis-1", Valid: true},
+			DstPort:         "transfer",
+			DstChannel:      "channel-1",
+			SendHeight:      10,
+			RecvHeight:      sql.NullInt64{Int64: 20, Valid: true},
+			AckHeight:       sql.NullInt64{Int64: 11, Valid: true},
+			Status:          "acknowledged",
+			RecvLatency:     sql.NullFloat64{Float64: 2000.4, Valid: true},
+			CompleteLatency: sql.NullFloat64{Float64: 5500, Valid: true},
+		}
+
+		pres := IBCPacket{result}
+		require.Equal(t, "7", pres.Sequence())
+		require.Equal(t, "gaia-1 transfer/channel-0", pres.Source())
+		require.Equal(t, "osmosis-1 transfer/channel-1", pres.Destination())
+		require.Equal(t, "acknowledged", pres.Status())
+		require.Equal(t, "10 → 20 → 11", pres.Heights())
+		require.Equal(t, "2s", pres.RecvLatency())
+		require.Equal(t, "5.5s", pres.CompleteLatency())
+	})
+
+	t.Run("zero state", func(t *testing.T) {
+		pres := IBCPacket{blockdb.IBCPacketResult{
+			DstPort:    "transfer",
+			DstChannel: "channel-1",
+			Status:     "timed_out",
+		}}
+
+		require.Equal(t, "transfer/channel-1", pres.Destination())
+		require.Equal(t, "timed out", pres.Status())
+		require.Equal(t, "0", pres.Heights())
+		require.Empty(t, pres.RecvLatency())
+		require.Empty(t, pres.CompleteLatency())
+	})
+}
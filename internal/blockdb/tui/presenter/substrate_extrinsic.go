@@ -0,0 +1,63 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// SubstrateExtrinsic presents a blockdb.TxResult collected from a substrate based chain,
+// such as a polkadot relay chain or parachain.
+type SubstrateExtrinsic struct {
+	Result blockdb.TxResult
+}
+
+type substrateExtrinsicData struct {
+	Pallet string `json:"pallet"`
+	Call   string `json:"call"`
+	Signer any    `json:"signer"`
+}
+
+func (xt SubstrateExtrinsic) data() (substrateExtrinsicData, bool) {
+	var data substrateExtrinsicData
+	if err := json.Unmarshal(xt.Result.Tx, &data); err != nil {
+		return data, false
+	}
+	return data, data.Pallet != "" && data.Call != ""
+}
+
+// IsExtrinsic returns whether the tx is a decoded extrinsic, as opposed to a tx of another chain type,
+// an extrinsic that could not be decoded, or an artificial tx holding block initialization or finalization events.
+func (xt SubstrateExtrinsic) IsExtrinsic() bool {
+	_, ok := xt.data()
+	return ok
+}
+
+// Call is the decoded pallet and method name, e.g. Balances.transfer.
+func (xt SubstrateExtrinsic) Call() string {
+	data, ok := xt.data()
+	if !ok {
+		return ""
+	}
+	return data.Pallet + "." + data.Call
+}
+
+// Signer is the address of the signer, or "unsigned" for inherents and unsigned extrinsics.
+func (xt SubstrateExtrinsic) Signer() string {
+	data, ok := xt.data()
+	switch {
+	case !ok:
+		return ""
+	case data.Signer == nil:
+		return "unsigned"
+	}
+	if s, ok := data.Signer.(string); ok {
+		return s
+	}
+	bz, err := json.Marshal(data.Signer)
+	if err != nil {
+		return fmt.Sprint(data.Signer)
+	}
+	return string(bz)
+}
@@ -0,0 +1,51 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstrateExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signed", func(t *testing.T) {
+		pres := SubstrateExtrinsic{blockdb.TxResult{
+			Tx: []byte(`{"index":1,"pallet":"Balances","call":"transfer","signer":"5yNZjX24n2eg7W6EVamaTXNQbWCwchhThEaSWB7V3GRjtHeL","args":{"value":1000}}`),
+		}}
+		require.True(t, pres.IsExtrinsic())
+		require.Equal(t, "Balances.transfer", pres.Call())
+		require.Equal(t, "5yNZjX24n2eg7W6EVamaTXNQbWCwchhThEaSWB7V3GRjtHeL", pres.Signer())
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		pres := SubstrateExtrinsic{blockdb.TxResult{
+			Tx: []byte(`{"index":0,"pallet":"Timestamp","call":"set","args":{"now":1680000000000}}`),
+		}}
+		require.Equal(t, "Timestamp.set", pres.Call())
+		require.Equal(t, "unsigned", pres.Signer())
+	})
+
+	t.Run("non-address signer", func(t *testing.T) {
+		pres := SubstrateExtrinsic{blockdb.TxResult{
+			Tx: []byte(`{"index":0,"pallet":"Balances","call":"transfer","signer":{"Index":3}}`),
+		}}
+		require.Equal(t, `{"Index":3}`, pres.Signer())
+	})
+
+	for _, tt := range []struct {
+		Name string
+		Tx   string
+	}{
+		{"artificial", `{"data":"initialization","note":"this is a transaction artificially created for debugging purposes"}`},
+		{"undecodable", `{"index":2,"error":"unsupported extrinsic version 5","raw":"0x040500"}`},
+		{"cosmos", `{"body":{"messages":[]}}`},
+		{"non-json", `some data`},
+	} {
+		pres := SubstrateExtrinsic{blockdb.TxResult{Tx: []byte(tt.Tx)}}
+		require.False(t, pres.IsExtrinsic(), tt.Name)
+		require.Empty(t, pres.Call(), tt.Name)
+		require.Empty(t, pres.Signer(), tt.Name)
+	}
+}
@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// TxFilter presents a blockdb.TxFilter.
+type TxFilter struct {
+	Filter blockdb.TxFilter
+}
+
+func (p TxFilter) Text() string        { return p.Filter.Text }
+func (p TxFilter) MessageType() string { return p.Filter.MessageType }
+func (p TxFilter) MinHeight() string   { return formatHeight(p.Filter.MinHeight) }
+func (p TxFilter) MaxHeight() string   { return formatHeight(p.Filter.MaxHeight) }
+
+// String summarizes the filter on a single line, e.g. `text:"uatom" type:MsgTransfer height:10..20`.
+func (p TxFilter) String() string {
+	if p.Filter.IsZero() {
+		return "none"
+	}
+	var parts []string
+	if p.Filter.Text != "" {
+		parts = append(parts, fmt.Sprintf("text:%q", p.Filter.Text))
+	}
+	if p.Filter.MessageType != "" {
+		parts = append(parts, "type:"+p.Filter.MessageType)
+	}
+	if p.Filter.MinHeight > 0 || p.Filter.MaxHeight > 0 {
+		parts = append(parts, "height:"+p.MinHeight()+".."+p.MaxHeight())
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatHeight formats an optional height, where zero is unset.
+func formatHeight(h int64) string {
+	if h <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(h, 10)
+}
@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		pres := TxFilter{blockdb.TxFilter{Text: "uatom", MessageType: "MsgTransfer", MinHeight: 10, MaxHeight: 20}}
+
+		require.Equal(t, "uatom", pres.Text())
+		require.Equal(t, "MsgTransfer", pres.MessageType())
+		require.Equal(t, "10", pres.MinHeight())
+		require.Equal(t, "20", pres.MaxHeight())
+		require.Equal(t, `text:"uatom" type:MsgTransfer height:10..20`, pres.String())
+
+		pres = TxFilter{blockdb.TxFilter{MinHeight: 10}}
+		require.Equal(t, "height:10..", pres.String())
+	})
+
+	t.Run("zero state", func(t *testing.T) {
+		var pres TxFilter
+
+		require.Empty(t, pres.MinHeight())
+		require.Empty(t, pres.MaxHeight())
+		require.Equal(t, "none", pres.String())
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb/tui/presenter"
 )
 
@@ -22,31 +23,82 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 		switch {
 		case event.Key() == tcell.KeyESC:
 			if len(m.stack) > 1 { // Stack must be at least 1, so we don't remove all main content views.
-				m.mainContentView().RemovePage(m.stack.Current().String())
-				m.stack = m.stack.Pop()
+				m.popMainView()
 				return nil
 			}
 
 		case event.Key() == tcell.KeyEnter && m.stack.Current() == testCasesMain:
 			// Show tx detail.
 			tc := m.testCases[m.selectedRow()]
-			results, err := m.querySvc.Transactions(ctx, tc.ChainPKey)
+			results, err := m.querySvc.Transactions(ctx, tc.ChainPKey, m.filter)
 			if err != nil {
 				m.pushErrorModal(fmt.Errorf("query transactions: %w", err))
 				return nil
 			}
-			m.pushMainView(txDetailMain, newTxDetailView(tc.ChainID, results))
+			m.pushMainView(txDetailMain, newTxDetailView(tc.ChainID, results, m.filter.Text))
+			m.refreshers[txDetailMain] = func(ctx context.Context) error {
+				results, err := m.querySvc.Transactions(ctx, tc.ChainPKey, m.filter)
+				if err != nil {
+					return fmt.Errorf("query transactions: %w", err)
+				}
+				m.txDetailView().ReplaceTxs(results)
+				return nil
+			}
 			return nil
 
 		case event.Rune() == 'm' && m.stack.Current() == testCasesMain:
 			// Show cosmos messages.
 			tc := m.testCases[m.selectedRow()]
-			results, err := m.querySvc.CosmosMessages(ctx, tc.ChainPKey)
+			results, err := m.querySvc.CosmosMessages(ctx, tc.ChainPKey, m.filter)
 			if err != nil {
 				m.pushErrorModal(fmt.Errorf("query cosmos messages: %w", err))
 				return nil
 			}
 			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results))
+			m.refreshers[cosmosMessagesMain] = func(ctx context.Context) error {
+				results, err := m.querySvc.CosmosMessages(ctx, tc.ChainPKey, m.filter)
+				if err != nil {
+					return fmt.Errorf("query cosmos messages: %w", err)
+				}
+				refreshTableRows(m.frontTable(), cosmosMessageHeaders, cosmosMessageRows(results))
+				return nil
+			}
+			return nil
+
+		case event.Rune() == 'p' && m.stack.Current() == testCasesMain:
+			// Show IBC packets across all chains of the test case.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.IBCPackets(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query ibc packets: %w", err))
+				return nil
+			}
+			m.pushMainView(ibcPacketsMain, ibcPacketsView(tc, results))
+			m.refreshers[ibcPacketsMain] = func(ctx context.Context) error {
+				results, err := m.querySvc.IBCPackets(ctx, tc.ID)
+				if err != nil {
+					return fmt.Errorf("query ibc packets: %w", err)
+				}
+				refreshTableRows(m.frontTable(), ibcPacketHeaders, ibcPacketRows(results))
+				return nil
+			}
+			return nil
+
+		case event.Rune() == 'l' && m.stack.Current() != errorModalMain && m.stack.Current() != filterFormMain && !m.searching():
+			// Toggle live mode, refreshing immediately when turned on.
+			m.SetLive(!m.live)
+			if m.live {
+				m.Refresh(ctx)
+			}
+			return nil
+
+		case event.Rune() == 'f' && m.filterable() && !m.searching():
+			m.pushMainView(filterFormMain, filterFormView(m.filter, func(filter blockdb.TxFilter) {
+				m.filter = filter
+				m.updateStatus()
+				m.popMainView()
+				m.Refresh(ctx)
+			}, m.pushErrorModal))
 			return nil
 
 		case event.Rune() == '[' && m.stack.Current() == txDetailMain:
@@ -86,6 +138,26 @@ func (m *Model) updateHelp(oldMainContent mainContent) {
 	help.Replace(keyMap[m.stack.Current()])
 }
 
+// updateStatus updates the header with the live mode and filter.
+func (m *Model) updateStatus() {
+	status := m.layout.GetItem(0).(*tview.Flex).GetItem(1).(*tview.Table)
+	setStatusCells(status, m.live, m.filter)
+}
+
+// filterable returns true if the filter applies to the current main content.
+func (m *Model) filterable() bool {
+	switch m.stack.Current() {
+	case testCasesMain, cosmosMessagesMain, txDetailMain:
+		return true
+	}
+	return false
+}
+
+// searching returns true while the user types a search term in the tx detail view.
+func (m *Model) searching() bool {
+	return m.stack.Current() == txDetailMain && m.txDetailView().Search.HasFocus()
+}
+
 func (m *Model) mainContentView() *tview.Pages {
 	return m.layout.GetItem(1).(*tview.Pages)
 }
@@ -95,17 +167,53 @@ func (m *Model) pushMainView(main mainContent, view tview.Primitive) {
 	m.mainContentView().AddAndSwitchToPage(main.String(), view, true)
 }
 
+func (m *Model) popMainView() {
+	m.mainContentView().RemovePage(m.stack.Current().String())
+	delete(m.refreshers, m.stack.Current())
+	m.stack = m.stack.Pop()
+}
+
 func (m *Model) pushErrorModal(err error) {
 	m.pushMainView(errorModalMain, errorModalView(err))
 }
 
 func (m *Model) selectedRow() int {
-	_, view := m.mainContentView().GetFrontPage()
-	row, _ := view.(*tview.Table).GetSelection()
+	row, _ := m.frontTable().GetSelection()
 	// Offset by 1 to account for header row.
 	return row - 1
 }
 
+func (m *Model) frontTable() *tview.Table {
+	_, view := m.mainContentView().GetFrontPage()
+	return view.(*tview.Table)
+}
+
+// refreshTestCases re-queries the recent test cases, keeping the selected chain of a test case.
+func (m *Model) refreshTestCases(ctx context.Context) error {
+	testCases, err := m.querySvc.RecentTestCases(ctx, recentTestCasesLimit)
+	if err != nil {
+		return fmt.Errorf("query recent test cases: %w", err)
+	}
+
+	tbl := m.frontTable()
+	selected := -1
+	if i := m.selectedRow(); i >= 0 && i < len(m.testCases) {
+		selected = i
+	}
+	row := 0
+	for i, tc := range testCases {
+		if selected >= 0 && tc.ChainPKey == m.testCases[selected].ChainPKey {
+			row = i
+			break
+		}
+	}
+
+	m.testCases = testCases
+	setTableRows(tbl, testCaseHeaders, testCaseRows(testCases))
+	tbl.Select(row+1, 0) // Offset by 1 to account for header row.
+	return nil
+}
+
 func (m *Model) txDetailView() *txDetailView {
 	_, primitive := m.mainContentView().GetFrontPage()
 	return primitive.(*txDetailView)
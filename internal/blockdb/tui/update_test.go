@@ -29,28 +29,49 @@ func draw(view tview.Primitive) {
 }
 
 type mockQueryService struct {
-	GotChainPkey int64
-	Messages     []blockdb.CosmosMessageResult
-	Txs          []blockdb.TxResult
-	Err          error
+	GotChainPkey  int64
+	GotTestCaseID int64
+	GotFilter     blockdb.TxFilter
+	TestCases     []blockdb.TestCaseResult
+	Messages      []blockdb.CosmosMessageResult
+	Txs           []blockdb.TxResult
+	Packets       []blockdb.IBCPacketResult
+	Err           error
 }
 
-func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error) {
+func (m *mockQueryService) RecentTestCases(ctx context.Context, limit int) ([]blockdb.TestCaseResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return m.TestCases, m.Err
+}
+
+func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64, filter blockdb.TxFilter) ([]blockdb.TxResult, error) {
 	if ctx == nil {
 		panic("nil context")
 	}
 	m.GotChainPkey = chainPkey
+	m.GotFilter = filter
 	return m.Txs, m.Err
 }
 
-func (m *mockQueryService) CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error) {
+func (m *mockQueryService) CosmosMessages(ctx context.Context, chainPkey int64, filter blockdb.TxFilter) ([]blockdb.CosmosMessageResult, error) {
 	if ctx == nil {
 		panic("nil context")
 	}
 	m.GotChainPkey = chainPkey
+	m.GotFilter = filter
 	return m.Messages, m.Err
 }
 
+func (m *mockQueryService) IBCPackets(ctx context.Context, testCaseID int64) ([]blockdb.IBCPacketResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	return m.Packets, m.Err
+}
+
 func TestModel_Update(t *testing.T) {
 	ctx := context.Background()
 
@@ -95,6 +116,34 @@ func TestModel_Update(t *testing.T) {
 		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1")
 	})
 
+	t.Run("ibc packets view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Packets: []blockdb.IBCPacketResult{
+				{Sequence: 1, Status: "acknowledged"},
+				{Sequence: 2, Status: "sent"},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "TestRelayer", ChainPKey: 5},
+			{ID: 4, ChainPKey: 6},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+
+		// By default, first row is selected in a rendered table.
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		_, table := model.mainContentView().GetFrontPage()
+
+		// 3 rows: 1 header + 2 blockdb.IBCPacketResult
+		require.Equal(t, 3, table.(*tview.Table).GetRowCount())
+		require.Contains(t, table.(*tview.Table).GetTitle(), "TestRelayer")
+	})
+
 	t.Run("tx detail", func(t *testing.T) {
 		querySvc := &mockQueryService{
 			Txs: []blockdb.TxResult{
@@ -215,3 +264,172 @@ func TestModel_Update(t *testing.T) {
 		require.IsType(t, &tview.Modal{}, primative.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
 	})
 }
+
+func TestModel_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("test cases", func(t *testing.T) {
+		querySvc := &mockQueryService{}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 1, ChainPKey: 5},
+			{ID: 1, ChainPKey: 6},
+		})
+		draw(model.RootView())
+
+		table := model.frontTable()
+		table.Select(2, 0)
+
+		// A new test case is listed first, and the selected chain stays selected.
+		querySvc.TestCases = []blockdb.TestCaseResult{
+			{ID: 2, ChainPKey: 7},
+			{ID: 1, ChainPKey: 5},
+			{ID: 1, ChainPKey: 6},
+		}
+		model.Refresh(ctx)
+
+		require.Equal(t, 4, table.GetRowCount())
+		require.Equal(t, 2, model.selectedRow())
+		require.EqualValues(t, 6, model.testCases[model.selectedRow()].ChainPKey)
+	})
+
+	t.Run("tx detail follows tail", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{Height: 12, Tx: []byte(`{"tx":1}`)},
+				{Height: 13, Tx: []byte(`{"tx":2}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ChainPKey: 5, ChainID: "my-chain1"}})
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+		update(runeKey(']'))
+
+		querySvc.Txs = append(querySvc.Txs, blockdb.TxResult{Height: 14, Tx: []byte(`{"tx":3}`)})
+		model.Refresh(ctx)
+
+		txDetail := model.txDetailView()
+		require.Equal(t, 3, txDetail.Pages.GetPageCount())
+		_, primitive := txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "Tx 3 of 3")
+
+		// Does not follow once moved away from the last tx.
+		update(runeKey('['))
+		querySvc.Txs = append(querySvc.Txs, blockdb.TxResult{Height: 15, Tx: []byte(`{"tx":4}`)})
+		model.Refresh(ctx)
+
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "Tx 2 of 4")
+	})
+
+	t.Run("messages follow tail", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Messages: []blockdb.CosmosMessageResult{{Height: 10}, {Height: 11}},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ChainPKey: 5}})
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('m'))
+		table := model.frontTable()
+		table.Select(2, 0)
+
+		querySvc.Messages = append(querySvc.Messages, blockdb.CosmosMessageResult{Height: 12})
+		model.Refresh(ctx)
+
+		require.Equal(t, 4, table.GetRowCount())
+		row, _ := table.GetSelection()
+		require.Equal(t, 3, row)
+	})
+
+	t.Run("error stops live mode", func(t *testing.T) {
+		querySvc := &mockQueryService{}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ChainPKey: 5}})
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('l'))
+		require.True(t, model.live)
+
+		querySvc.Err = errors.New("boom")
+		model.Refresh(ctx)
+
+		require.False(t, model.live)
+		require.Equal(t, errorModalMain, model.stack.Current())
+	})
+}
+
+func TestModel_Tail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	querySvc := &mockQueryService{TestCases: []blockdb.TestCaseResult{{ChainPKey: 5}, {ChainPKey: 6}}}
+	model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ChainPKey: 5}})
+	draw(model.RootView())
+	model.SetLive(true)
+
+	updates := make(chan func())
+	go model.Tail(ctx, time.Millisecond, func(f func()) { updates <- f })
+
+	// Run the queued update on this goroutine, as the tview application would on the main goroutine.
+	(<-updates)()
+	require.Len(t, model.testCases, 2)
+}
+
+func TestModel_Filter(t *testing.T) {
+	ctx := context.Background()
+
+	querySvc := &mockQueryService{
+		Txs: []blockdb.TxResult{{Height: 12, Tx: []byte(`{"amount":"uatom"}`)}},
+	}
+	model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ChainPKey: 5, ChainID: "my-chain1"}})
+	draw(model.RootView())
+
+	update := model.Update(ctx)
+	update(enterKey)
+	update(runeKey('f'))
+	require.Equal(t, filterFormMain, model.stack.Current())
+
+	_, primitive := model.mainContentView().GetFrontPage()
+	form := primitive.(*tview.Form)
+	setField := func(label, text string) {
+		form.GetFormItemByLabel(label).(*tview.InputField).SetText(text)
+	}
+	pressButton := func(label string) {
+		form.GetButton(form.GetButtonIndex(label)).InputHandler()(enterKey, func(tview.Primitive) {})
+	}
+
+	setField(filterTextLabel, "uatom")
+	setField(filterMessageTypeLabel, "MsgTransfer")
+	setField(filterMinHeightLabel, "20")
+	setField(filterMaxHeightLabel, "10")
+	pressButton("Apply")
+
+	// Invalid height range.
+	require.Equal(t, errorModalMain, model.stack.Current())
+	update(escKey)
+	require.Equal(t, filterFormMain, model.stack.Current())
+
+	setField(filterMaxHeightLabel, "")
+	pressButton("Apply")
+
+	// The tx detail view is refreshed with the filter.
+	require.Equal(t, txDetailMain, model.stack.Current())
+	want := blockdb.TxFilter{Text: "uatom", MessageType: "MsgTransfer", MinHeight: 20}
+	require.Equal(t, want, model.filter)
+	require.Equal(t, want, querySvc.GotFilter)
+
+	// New views are queried with the filter, with the search text highlighted.
+	update(escKey)
+	update(enterKey)
+	require.Equal(t, want, querySvc.GotFilter)
+	require.Equal(t, "uatom", model.txDetailView().Search.GetText())
+
+	update(runeKey('f'))
+	_, primitive = model.mainContentView().GetFrontPage()
+	form = primitive.(*tview.Form)
+	pressButton("Clear")
+	require.Zero(t, model.filter)
+	require.Zero(t, querySvc.GotFilter)
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
@@ -35,6 +36,8 @@ func schemaVersionView(m *Model) *tview.Table {
 	tbl.SetCell(0, 0, titleCell("Database:"))
 	tbl.SetCell(1, 0, titleCell("Schema Version:"))
 	tbl.SetCell(2, 0, titleCell("Schema Date:"))
+	tbl.SetCell(statusLiveRow, 0, titleCell("Live:"))
+	tbl.SetCell(statusFilterRow, 0, titleCell("Filter:"))
 
 	valCell := func(s string) *tview.TableCell {
 		return tview.NewTableCell(s).SetStyle(textStyle)
@@ -42,10 +45,26 @@ func schemaVersionView(m *Model) *tview.Table {
 	tbl.SetCell(0, 1, valCell(m.databasePath))
 	tbl.SetCell(1, 1, valCell(m.schemaVersion))
 	tbl.SetCell(2, 1, valCell(presenter.FormatTime(m.schemaDate)))
+	setStatusCells(tbl, m.live, m.filter)
 
 	return tbl
 }
 
+// Rows of the schema version table that show the state of the model.
+const (
+	statusLiveRow   = 3
+	statusFilterRow = 4
+)
+
+func setStatusCells(tbl *tview.Table, live bool, filter blockdb.TxFilter) {
+	liveText := "off"
+	if live {
+		liveText = "on"
+	}
+	tbl.SetCell(statusLiveRow, 1, tview.NewTableCell(liveText).SetStyle(textStyle))
+	tbl.SetCell(statusFilterRow, 1, tview.NewTableCell(presenter.TxFilter{Filter: filter}.String()).SetStyle(textStyle))
+}
+
 func detailTableView(title string, headers []string, rows [][]string) *tview.Table {
 	if len(headers) == 0 {
 		panic(errors.New("detailTableView headers are required"))
@@ -61,6 +80,13 @@ func detailTableView(title string, headers []string, rows [][]string) *tview.Tab
 		SetBorderAttributes(tcell.AttrDim)
 
 	tbl.SetTitle(title)
+	setTableRows(tbl, headers, rows)
+	return tbl
+}
+
+// setTableRows replaces the content of tbl with the headers and rows.
+func setTableRows(tbl *tview.Table, headers []string, rows [][]string) {
+	tbl.Clear()
 
 	headerCell := func(s string) *tview.TableCell {
 		s = strings.ToUpper(s)
@@ -89,23 +115,38 @@ func detailTableView(title string, headers []string, rows [][]string) *tview.Tab
 			tbl.SetCell(rowPos, col, contentCell(content))
 		}
 	}
-	return tbl
+}
+
+// refreshTableRows replaces the content of tbl with the headers and rows, keeping the selected row.
+// If the last row was selected, the new last row is selected instead.
+func refreshTableRows(tbl *tview.Table, headers []string, rows [][]string) {
+	row, _ := tbl.GetSelection()
+	followTail := row >= tbl.GetRowCount()-1
+	setTableRows(tbl, headers, rows)
+	if followTail || row > len(rows) {
+		row = len(rows)
+	}
+	tbl.Select(row, 0)
+}
+
+var testCaseHeaders = []string{
+	"ID",
+	"Date",
+	"Name",
+	"Git Sha",
+	"Chain",
+	"Height",
+	"Tx Total",
 }
 
 // testCasesView is the initial main content.
 func testCasesView(m *Model) *tview.Table {
-	headers := []string{
-		"ID",
-		"Date",
-		"Name",
-		"Git Sha",
-		"Chain",
-		"Height",
-		"Tx Total",
-	}
+	return detailTableView("Test Cases", testCaseHeaders, testCaseRows(m.testCases))
+}
 
-	rows := make([][]string, len(m.testCases))
-	for i, tc := range m.testCases {
+func testCaseRows(testCases []blockdb.TestCaseResult) [][]string {
+	rows := make([][]string, len(testCases))
+	for i, tc := range testCases {
 		pres := presenter.TestCase{Result: tc}
 		rows[i] = []string{
 			pres.ID(),
@@ -117,21 +158,25 @@ func testCasesView(m *Model) *tview.Table {
 			pres.TxTotal(),
 		}
 	}
+	return rows
+}
 
-	return detailTableView("Test Cases", headers, rows)
+var cosmosMessageHeaders = []string{
+	"Height",
+	"Index",
+	"Type",
+	"Client Chain",
+	"Client",
+	"Connection",
+	"Channel:Port",
 }
 
 func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageResult) *tview.Table {
-	headers := []string{
-		"Height",
-		"Index",
-		"Type",
-		"Client Chain",
-		"Client",
-		"Connection",
-		"Channel:Port",
-	}
+	title := fmt.Sprintf("%s [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
+	return detailTableView(title, cosmosMessageHeaders, cosmosMessageRows(msgs))
+}
 
+func cosmosMessageRows(msgs []blockdb.CosmosMessageResult) [][]string {
 	rows := make([][]string, len(msgs))
 	for i, msg := range msgs {
 		pres := presenter.CosmosMessage{Result: msg}
@@ -145,9 +190,39 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 			pres.Channels(),
 		}
 	}
+	return rows
+}
 
-	title := fmt.Sprintf("%s [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
-	return detailTableView(title, headers, rows)
+var ibcPacketHeaders = []string{
+	"Sequence",
+	"Source",
+	"Destination",
+	"Status",
+	"Heights",
+	"Recv Latency",
+	"Complete Latency",
+}
+
+func ibcPacketsView(tc blockdb.TestCaseResult, packets []blockdb.IBCPacketResult) *tview.Table {
+	title := fmt.Sprintf("IBC Packets: %s [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	return detailTableView(title, ibcPacketHeaders, ibcPacketRows(packets))
+}
+
+func ibcPacketRows(packets []blockdb.IBCPacketResult) [][]string {
+	rows := make([][]string, len(packets))
+	for i, packet := range packets {
+		pres := presenter.IBCPacket{Result: packet}
+		rows[i] = []string{
+			pres.Sequence(),
+			pres.Source(),
+			pres.Destination(),
+			pres.Status(),
+			pres.Heights(),
+			pres.RecvLatency(),
+			pres.CompleteLatency(),
+		}
+	}
+	return rows
 }
 
 func errorModalView(err error) *tview.Flex {
@@ -176,22 +251,26 @@ const (
 type txDetailView struct {
 	*tview.Flex
 
-	chainID string
+	chainID    string
+	searchTerm string
 
 	Txs    []blockdb.TxResult
 	Pages  *tview.Pages
 	Search *tview.InputField
 }
 
-func newTxDetailView(chainID string, txs []blockdb.TxResult) *txDetailView {
+// newTxDetailView returns a view of txs, with searchTerm initially highlighted.
+func newTxDetailView(chainID string, txs []blockdb.TxResult, searchTerm string) *txDetailView {
 	detail := &txDetailView{
-		chainID: chainID,
-		Txs:     txs,
+		chainID:    chainID,
+		searchTerm: searchTerm,
+		Txs:        txs,
 	}
 
 	detail.Pages = tview.NewPages()
-	detail.replacePages("", "0")
+	detail.replacePages(searchTerm, "0")
 	detail.Search = detail.buildSearchInput()
+	detail.Search.SetText(searchTerm)
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.SetBorder(false)
@@ -229,9 +308,40 @@ func (detail *txDetailView) activateSearch() {
 // DoSearch re-renders the text views with highlighted text.
 func (detail *txDetailView) DoSearch() {
 	detail.deactivateSearch()
-	term := detail.Search.GetText()
+	detail.searchTerm = detail.Search.GetText()
 	idx, _ := detail.Pages.GetFrontPage()
-	detail.replacePages(term, idx)
+	detail.replacePages(detail.searchTerm, idx)
+}
+
+// ReplaceTxs re-renders the text views if txs differ from the current ones, keeping the current tx.
+// If the last tx was shown, the new last tx is shown instead.
+func (detail *txDetailView) ReplaceTxs(txs []blockdb.TxResult) {
+	if sameTxs(detail.Txs, txs) {
+		// Re-rendering would reset the scroll position.
+		return
+	}
+	idxStr, _ := detail.Pages.GetFrontPage()
+	idx, _ := strconv.Atoi(idxStr)
+	if idx >= len(detail.Txs)-1 || idx >= len(txs) {
+		idx = len(txs) - 1
+	}
+	for i := len(txs); i < len(detail.Txs); i++ {
+		detail.Pages.RemovePage(strconv.Itoa(i))
+	}
+	detail.Txs = txs
+	detail.replacePages(detail.searchTerm, strconv.Itoa(idx))
+}
+
+func sameTxs(a, b []blockdb.TxResult) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Height != b[i].Height || !bytes.Equal(a[i].Tx, b[i].Tx) {
+			return false
+		}
+	}
+	return true
 }
 
 // "pageIdx" is an integer string, e.g. "0", "1".
@@ -259,7 +369,11 @@ func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
 			SetBorderPadding(0, 0, 1, 1).
 			SetBorderAttributes(tcell.AttrDim)
 
-		textView.SetTitle(fmt.Sprintf("%s @ Height %d [Tx %d of %d]", detail.chainID, tx.Height, i+1, len(detail.Txs)))
+		title := fmt.Sprintf("%s @ Height %d [Tx %d of %d]", detail.chainID, tx.Height, i+1, len(detail.Txs))
+		if xt := (presenter.SubstrateExtrinsic{Result: tx}); xt.IsExtrinsic() {
+			title += fmt.Sprintf(" %s by %s", xt.Call(), xt.Signer())
+		}
+		textView.SetTitle(title)
 
 		detail.Pages.AddPage(idx, textView, true, false)
 	}
@@ -2,6 +2,7 @@ package blockdb
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -139,3 +140,91 @@ ORDER BY test_case_id, chain_kid, block_id, tx_id
 func TestTxAggView(t *testing.T) {
 	// Nop. Tested as part of QueryService.
 }
+
+func TestBlockHeaderViews(t *testing.T) {
+	t.Parallel()
+
+	db := migratedDB()
+	defer db.Close()
+
+	ctx := context.Background()
+
+	tc, err := CreateTestCase(ctx, db, "mytest", "abc123")
+	require.NoError(t, err)
+
+	chain, err := tc.AddChain(ctx, "chain1", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, signed := range [][2]bool{{true, true}, {true, false}, {false, false}} {
+		height := uint64(i + 1)
+		require.NoError(t, chain.SaveBlock(ctx, height, []Tx{{Data: []byte("tx")}}))
+		require.NoError(t, chain.SaveBlockHeader(ctx, height, BlockHeader{
+			Time:            start.Add(time.Duration(i*i) * time.Second),
+			ProposerAddress: "val0",
+			GasUsed:         int64(100 * height),
+			Signatures: []CommitSig{
+				{ValidatorAddress: "val0", Signed: signed[0]},
+				{ValidatorAddress: "val1", Signed: signed[1]},
+			},
+		}))
+	}
+	// A block without a header is excluded from the intervals.
+	require.NoError(t, chain.SaveBlock(ctx, 4, nil))
+
+	t.Run("block intervals", func(t *testing.T) {
+		rows, err := db.Query(`SELECT block_height, interval_ms, gas_used, signature_count, tx_total
+FROM v_block_intervals ORDER BY block_height`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		type row struct {
+			height, gas, sigs, txs int64
+			interval               sql.NullFloat64
+		}
+		var got []row
+		for rows.Next() {
+			var r row
+			require.NoError(t, rows.Scan(&r.height, &r.interval, &r.gas, &r.sigs, &r.txs))
+			got = append(got, r)
+		}
+		require.NoError(t, rows.Err())
+
+		require.Len(t, got, 3)
+		require.False(t, got[0].interval.Valid)
+		require.InDelta(t, 1000, got[1].interval.Float64, 1)
+		require.InDelta(t, 3000, got[2].interval.Float64, 1)
+		require.EqualValues(t, 200, got[1].gas)
+		require.EqualValues(t, 1, got[1].sigs)
+		require.EqualValues(t, 1, got[1].txs)
+	})
+
+	t.Run("missed signatures", func(t *testing.T) {
+		rows, err := db.Query(`SELECT validator_address, signatures_expected, signatures_missed, last_missed_height
+FROM v_missed_signatures ORDER BY validator_address`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var (
+			addr             string
+			expected, missed int
+			lastMissed       sql.NullInt64
+		)
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&addr, &expected, &missed, &lastMissed))
+		require.Equal(t, "val0", addr)
+		require.Equal(t, 3, expected)
+		require.Equal(t, 1, missed)
+		require.EqualValues(t, 2, lastMissed.Int64)
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&addr, &expected, &missed, &lastMissed))
+		require.Equal(t, "val1", addr)
+		require.Equal(t, 3, expected)
+		require.Equal(t, 2, missed)
+		require.EqualValues(t, 2, lastMissed.Int64)
+
+		require.False(t, rows.Next())
+	})
+}
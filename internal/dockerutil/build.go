@@ -0,0 +1,336 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// defaultBuildRepository is the repository of built images that do not set one.
+const defaultBuildRepository = "interchaintest-local"
+
+// Builds are serialized, so that chains built from the same source at the same time
+// build the image once, and find it in the cache thereafter.
+var buildImageMu sync.Mutex
+
+// BuildImage builds image from its local source, if image.Build is set,
+// and sets image.Version to the tag of the built image.
+// The tag is derived from the content of the build context, the Dockerfile, the build args and the target,
+// so an image is only rebuilt when any of them change.
+// If image.Build is nil, BuildImage does nothing.
+func BuildImage(ctx context.Context, log *zap.Logger, cli *client.Client, image *ibc.DockerImage) error {
+	b := image.Build
+	if b == nil {
+		return nil
+	}
+	if image.Repository == "" {
+		image.Repository = defaultBuildRepository
+	}
+
+	buildImageMu.Lock()
+	defer buildImageMu.Unlock()
+
+	bc, err := newBuildContext(*b)
+	if err != nil {
+		return err
+	}
+	image.Version = "local-" + bc.hash()[:16]
+
+	ref := image.Ref()
+	if _, _, err := cli.ImageInspectWithRaw(ctx, ref); err == nil {
+		log.Info("Using cached image", zap.String("image", ref), zap.String("context", b.Context))
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return fmt.Errorf("inspecting image %s: %w", ref, err)
+	}
+
+	log.Info("Building image", zap.String("image", ref), zap.String("context", b.Context))
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(bc.writeTar(pw))
+	}()
+	defer pr.Close()
+
+	buildArgs := make(map[string]*string, len(b.Args))
+	for k, v := range b.Args {
+		v := v
+		buildArgs[k] = &v
+	}
+
+	res, err := cli.ImageBuild(ctx, pr, types.ImageBuildOptions{
+		Tags:        []string{ref},
+		Dockerfile:  bc.dockerfile,
+		BuildArgs:   buildArgs,
+		Target:      b.Target,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return fmt.Errorf("building image %s: %w", ref, err)
+	}
+	defer res.Body.Close()
+
+	// The build output is a stream of JSON messages, one of which holds the error if the build fails.
+	dec := json.NewDecoder(res.Body)
+	for {
+		var msg struct {
+			Stream string `json:"stream"`
+			Error  string `json:"error"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("reading build output of image %s: %w", ref, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("building image %s: %s", ref, msg.Error)
+		}
+		if s := strings.TrimSpace(msg.Stream); s != "" {
+			log.Debug(s, zap.String("image", ref))
+		}
+	}
+}
+
+// buildContext is the set of files sent to docker to build an image.
+type buildContext struct {
+	build      ibc.DockerImageBuild
+	dir        string
+	dockerfile string
+
+	// files are the paths of the included files and directories, relative to dir, in walk order.
+	files []string
+}
+
+func newBuildContext(b ibc.DockerImageBuild) (*buildContext, error) {
+	if b.Context == "" {
+		return nil, errors.New("build context must be set")
+	}
+	dir, err := filepath.Abs(b.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerfile := b.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	dockerfile = filepath.ToSlash(filepath.Clean(dockerfile))
+	if _, err := os.Stat(filepath.Join(dir, dockerfile)); err != nil {
+		return nil, fmt.Errorf("dockerfile must be within the build context: %w", err)
+	}
+
+	ignore, err := readDockerignore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	bc := &buildContext{build: b, dir: dir, dockerfile: dockerfile}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		// Docker always needs the Dockerfile, even if it is ignored.
+		if rel != dockerfile && ignore.matches(rel) {
+			if d.IsDir() && !ignore.hasExceptions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		bc.files = append(bc.files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking build context %s: %w", dir, err)
+	}
+
+	return bc, nil
+}
+
+// hash returns the hex encoded hash of everything that determines the built image.
+func (bc *buildContext) hash() string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "dockerfile %q\ntarget %q\n", bc.dockerfile, bc.build.Target)
+
+	args := make([]string, 0, len(bc.build.Args))
+	for k := range bc.build.Args {
+		args = append(args, k)
+	}
+	sort.Strings(args)
+	for _, k := range args {
+		fmt.Fprintf(h, "arg %q=%q\n", k, bc.build.Args[k])
+	}
+
+	for _, rel := range bc.files {
+		p := filepath.Join(bc.dir, filepath.FromSlash(rel))
+		fi, err := os.Lstat(p)
+		if err != nil {
+			// The file disappeared while hashing; the hash cannot match a previous build.
+			fmt.Fprintf(h, "missing %q\n", rel)
+			continue
+		}
+		fmt.Fprintf(h, "file %q %s\n", rel, fi.Mode())
+
+		switch {
+		case fi.Mode()&fs.ModeSymlink != 0:
+			target, _ := os.Readlink(p)
+			fmt.Fprintf(h, "link %q\n", target)
+		case fi.Mode().IsRegular():
+			f, err := os.Open(p)
+			if err != nil {
+				fmt.Fprintf(h, "unreadable %q\n", rel)
+				continue
+			}
+			_, _ = io.Copy(h, f)
+			_ = f.Close()
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeTar writes the build context to w as a tar stream, as expected by the docker build API.
+func (bc *buildContext) writeTar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+
+	for _, rel := range bc.files {
+		p := filepath.Join(bc.dir, filepath.FromSlash(rel))
+		fi, err := os.Lstat(p)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return fmt.Errorf("creating tar header for %s: %w", rel, err)
+		}
+		hdr.Name = rel
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if fi.Mode().IsRegular() {
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(tw, f)
+			_ = f.Close()
+			if err != nil {
+				return fmt.Errorf("writing %s to tar: %w", rel, err)
+			}
+		}
+	}
+
+	return tw.Close()
+}
+
+// dockerignore holds the patterns of a .dockerignore file.
+// Patterns are matched with path.Match against a path and each of its parent directories;
+// patterns starting with "!" re-include paths excluded by an earlier pattern.
+// The "**" wildcard of docker is not supported.
+type dockerignore []ignorePattern
+
+type ignorePattern struct {
+	pattern   string
+	exception bool
+}
+
+func readDockerignore(dir string) (dockerignore, error) {
+	f, err := os.Open(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns dockerignore
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p ignorePattern
+		if strings.HasPrefix(line, "!") {
+			p.exception = true
+			line = strings.TrimSpace(line[1:])
+		}
+		p.pattern = path.Clean(strings.TrimPrefix(filepath.ToSlash(line), "/"))
+		patterns = append(patterns, p)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("reading .dockerignore: %w", err)
+	}
+	return patterns, nil
+}
+
+// matches reports whether rel, a slash separated path relative to the build context, is excluded.
+// The last matching pattern wins.
+func (d dockerignore) matches(rel string) bool {
+	var excluded bool
+	for _, p := range d {
+		for dir := rel; dir != "."; dir = path.Dir(dir) {
+			if ok, _ := path.Match(p.pattern, dir); ok {
+				excluded = !p.exception
+				break
+			}
+		}
+	}
+	return excluded
+}
+
+func (d dockerignore) hasExceptions() bool {
+	for _, p := range d {
+		if p.exception {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package dockerutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+func writeBuildContext(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestBuildContext(t *testing.T) {
+	dir := writeBuildContext(t, map[string]string{
+		"Dockerfile":        "FROM busybox\n",
+		".dockerignore":     "# Comment\n.git\nbuild/*\n!build/keep\n*.log\n",
+		"main.go":           "package main\n",
+		"app.log":           "ignored",
+		".git/HEAD":         "ignored",
+		"build/out":         "ignored",
+		"build/keep":        "kept",
+		"docs/readme.md":    "kept",
+		"docs/nested.log":   "kept, as patterns match from the root of the context",
+		"docker/Dockerfile": "FROM busybox\n",
+	})
+
+	bc, err := newBuildContext(ibc.DockerImageBuild{Context: dir})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		".dockerignore",
+		"Dockerfile",
+		"build",
+		"build/keep",
+		"docker",
+		"docker/Dockerfile",
+		"docs",
+		"docs/nested.log",
+		"docs/readme.md",
+		"main.go",
+	}, bc.files)
+
+	h := bc.hash()
+	require.Len(t, h, 64)
+
+	t.Run("hash is stable", func(t *testing.T) {
+		bc, err := newBuildContext(ibc.DockerImageBuild{Context: dir})
+		require.NoError(t, err)
+		require.Equal(t, h, bc.hash())
+	})
+
+	t.Run("ignored files do not change the hash", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other.log"), []byte("x"), 0o644))
+
+		bc, err := newBuildContext(ibc.DockerImageBuild{Context: dir})
+		require.NoError(t, err)
+		require.Equal(t, h, bc.hash())
+	})
+
+	t.Run("build options change the hash", func(t *testing.T) {
+		for _, b := range []ibc.DockerImageBuild{
+			{Context: dir, Args: map[string]string{"VERSION": "1"}},
+			{Context: dir, Target: "runner"},
+			{Context: dir, Dockerfile: "docker/Dockerfile"},
+		} {
+			bc, err := newBuildContext(b)
+			require.NoError(t, err)
+			require.NotEqual(t, h, bc.hash(), b)
+		}
+	})
+
+	t.Run("source changes the hash", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+
+		bc, err := newBuildContext(ibc.DockerImageBuild{Context: dir})
+		require.NoError(t, err)
+		require.NotEqual(t, h, bc.hash())
+	})
+
+	t.Run("missing dockerfile", func(t *testing.T) {
+		_, err := newBuildContext(ibc.DockerImageBuild{Context: dir, Dockerfile: "nope"})
+		require.ErrorContains(t, err, "dockerfile must be within the build context")
+	})
+}
+
+func TestBuildImage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	cli, networkID := DockerSetup(t)
+
+	dir := writeBuildContext(t, map[string]string{
+		"Dockerfile": "FROM busybox:stable\nARG GREETING\nCOPY hello.txt /hello.txt\nRUN echo \"$GREETING\" >> /hello.txt\n",
+		"hello.txt":  "hello\n",
+	})
+
+	image := ibc.DockerImage{
+		Repository: "interchaintest-buildimage-test",
+		Build:      &ibc.DockerImageBuild{Context: dir, Args: map[string]string{"GREETING": "world"}},
+	}
+	require.NoError(t, BuildImage(ctx, zaptest.NewLogger(t), cli, &image))
+	require.True(t, strings.HasPrefix(image.Version, "local-"), image.Version)
+	t.Cleanup(func() {
+		_, _ = cli.ImageRemove(context.Background(), image.Ref(), types.ImageRemoveOptions{Force: true})
+	})
+
+	res := NewImage(zaptest.NewLogger(t), cli, networkID, t.Name(), image.Repository, image.Version).
+		Run(ctx, []string{"cat", "/hello.txt"}, ContainerOptions{})
+	require.NoError(t, res.Err)
+	require.Equal(t, "hello\nworld\n", string(res.Stdout))
+
+	// Building the same source again uses the cached image.
+	again := ibc.DockerImage{Repository: image.Repository, Build: image.Build}
+	require.NoError(t, BuildImage(ctx, zaptest.NewLogger(t), cli, &again))
+	require.Equal(t, image.Version, again.Version)
+}
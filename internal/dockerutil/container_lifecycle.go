@@ -42,6 +42,7 @@ func (c *ContainerLifecycle) CreateContainer(
 	volumeBinds []string,
 	hostName string,
 	cmd []string,
+	resources ibc.Resources,
 ) error {
 	imageRef := image.Ref()
 	c.log.Info(
@@ -51,6 +52,11 @@ func (c *ContainerLifecycle) CreateContainer(
 		zap.String("command", strings.Join(cmd, " ")),
 	)
 
+	res, err := containerResources(resources)
+	if err != nil {
+		return err
+	}
+
 	pb, listeners, err := GeneratePortBindings(ports)
 	if err != nil {
 		return fmt.Errorf("failed to generate port bindings: %w", err)
@@ -78,6 +84,7 @@ func (c *ContainerLifecycle) CreateContainer(
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       res,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -158,7 +165,8 @@ func (c *ContainerLifecycle) GetHostPorts(ctx context.Context, portIDs ...string
 }
 
 // Running will inspect the container and check its state to determine if it is currently running.
-// If the container is running nil will be returned, otherwise an error is returned.
+// If the container is running nil will be returned, otherwise an error is returned,
+// which reports whether the container was killed for running out of memory.
 func (c *ContainerLifecycle) Running(ctx context.Context) error {
 	cjson, err := c.client.ContainerInspect(ctx, c.id)
 	if err != nil {
@@ -167,5 +175,8 @@ func (c *ContainerLifecycle) Running(ctx context.Context) error {
 	if cjson.State.Running {
 		return nil
 	}
+	if err := OOMKilledError(cjson); err != nil {
+		return err
+	}
 	return fmt.Errorf("container with name %s and id %s is not running", c.containerName, c.id)
 }
@@ -0,0 +1,117 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// CaptureContainerLogsAlways determines whether the full logs of every container associated with a test
+// using DockerSetup are written to the test's artifact directory when the test passes.
+// Container logs are always captured when the test fails, unless ArtifactsDir is empty.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_CAPTURE_CONTAINER_LOGS to a non-empty value.
+// Because dockerutil is an internal package, the public API for setting this value
+// is interchaintest.CaptureContainerLogsAlways(bool).
+var CaptureContainerLogsAlways = os.Getenv("IBCTEST_CAPTURE_CONTAINER_LOGS") != ""
+
+// ArtifactsDir is the root directory of the per-test artifact directories returned by TestArtifactsDir.
+//
+// It defaults to the value of the environment variable IBCTEST_ARTIFACTS_DIR,
+// or $HOME/.interchaintest/artifacts if that is not set.
+// If empty, no artifacts are written.
+// Because dockerutil is an internal package, the public API for setting this value
+// is interchaintest.SetArtifactsDir(string).
+var ArtifactsDir = defaultArtifactsDir()
+
+func defaultArtifactsDir() string {
+	if dir := os.Getenv("IBCTEST_ARTIFACTS_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".interchaintest", "artifacts")
+}
+
+// TestArtifactsDir returns the artifact directory for the test with the given name,
+// nesting subtests in subdirectories,
+// or an empty string if ArtifactsDir is empty.
+func TestArtifactsDir(testName string) string {
+	if ArtifactsDir == "" {
+		return ""
+	}
+	parts := strings.Split(testName, "/")
+	for i, p := range parts {
+		parts[i] = SanitizeContainerName(p)
+	}
+	return filepath.Join(append([]string{ArtifactsDir}, parts...)...)
+}
+
+var (
+	containerLogsHooksMu sync.Mutex
+	containerLogsHooks   = make(map[string][]func(containerName, path string))
+)
+
+// OnContainerLogsCaptured registers fn to be called with the path of every container log file
+// captured during the docker cleanup of the test that created the docker network with the given ID through DockerSetup.
+// The hooks of a network are discarded after its docker cleanup.
+func OnContainerLogsCaptured(networkID string, fn func(containerName, path string)) {
+	containerLogsHooksMu.Lock()
+	defer containerLogsHooksMu.Unlock()
+	containerLogsHooks[networkID] = append(containerLogsHooks[networkID], fn)
+}
+
+func takeContainerLogsHooks(networkID string) []func(containerName, path string) {
+	containerLogsHooksMu.Lock()
+	defer containerLogsHooksMu.Unlock()
+	hooks := containerLogsHooks[networkID]
+	delete(containerLogsHooks, networkID)
+	return hooks
+}
+
+// writeContainerLogs writes the complete stdout and stderr of the container to
+// a file named after the container in dir, returning the path of the file.
+func writeContainerLogs(ctx context.Context, cli *client.Client, dir string, c types.Container) (string, error) {
+	name := c.ID
+	if len(c.Names) > 0 {
+		name = strings.TrimPrefix(c.Names[0], "/")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("mkdirall: %w", err)
+	}
+
+	rc, err := cli.ContainerLogs(ctx, c.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("container logs: %w", err)
+	}
+	defer rc.Close()
+
+	path := filepath.Join(dir, SanitizeContainerName(name)+".log")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	// Both streams are written to the same file, so their lines stay in order.
+	if _, err := stdcopy.StdCopy(f, f, rc); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("copy logs: %w", err)
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,102 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"go.uber.org/zap"
+)
+
+// idleCmd keeps a container running without doing anything, until it is stopped.
+var idleCmd = []string{"sh", "-c", `trap 'exit 0' TERM INT; while :; do sleep 3600 & wait $!; done`}
+
+// StartIdle starts a container that idles until stopped, for running commands in it with Exec.
+// The image must provide sh and sleep.
+func (image *Image) StartIdle(ctx context.Context, opts ContainerOptions) (*Container, error) {
+	c, err := image.Start(ctx, idleCmd, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Running(ctx); err != nil {
+		if stopErr := c.Stop(10 * time.Second); stopErr != nil {
+			c.log.Info("Failed to remove idle container", zap.Error(stopErr))
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Running returns nil if the container is running and not paused, otherwise an error describing why it is not.
+func (c *Container) Running(ctx context.Context) error {
+	cjson, err := c.image.client.ContainerInspect(ctx, c.containerID)
+	if err != nil {
+		return err
+	}
+	if !cjson.State.Running || cjson.State.Paused {
+		return fmt.Errorf("container %s is %s", c.Name, cjson.State.Status)
+	}
+	return nil
+}
+
+// Exec runs cmd to completion in the running container with docker exec, adding env to its environment.
+// The result reports the outcome of cmd, as with Wait, so a non-zero exit code sets its Err along with its output.
+// The returned error is only set if cmd could not be run at all, e.g. because the container stopped.
+func (c *Container) Exec(ctx context.Context, cmd []string, env []string) (ContainerExecResult, error) {
+	cli := c.image.client
+
+	exec, err := cli.ContainerExecCreate(ctx, c.containerID, types.ExecConfig{
+		Cmd:          cmd,
+		Env:          env,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return ContainerExecResult{}, fmt.Errorf("creating exec in container %s: %w", c.Name, err)
+	}
+
+	resp, err := cli.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return ContainerExecResult{}, fmt.Errorf("attaching to exec in container %s: %w", c.Name, err)
+	}
+	defer resp.Close()
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	copyErr := make(chan error, 1)
+	go func() {
+		// Output is multiplexed into one stream, as with container logs.
+		_, err := stdcopy.StdCopy(&stdoutBuf, &stderrBuf, resp.Reader)
+		copyErr <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ContainerExecResult{Err: ctx.Err(), ExitCode: 1}, nil
+	case err := <-copyErr:
+		if err != nil {
+			return ContainerExecResult{Err: err, ExitCode: 1}, nil
+		}
+	}
+
+	inspect, err := cli.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return ContainerExecResult{Err: err, ExitCode: 1}, nil
+	}
+
+	res := ContainerExecResult{
+		ExitCode: inspect.ExitCode,
+		Stdout:   stdoutBuf.Bytes(),
+		Stderr:   stderrBuf.Bytes(),
+	}
+	if inspect.ExitCode != 0 {
+		out := strings.Join([]string{stdoutBuf.String(), stderrBuf.String()}, " ")
+		res.Err = fmt.Errorf("exit code %d: %s", inspect.ExitCode, out)
+	}
+	return res, nil
+}
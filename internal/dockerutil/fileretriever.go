@@ -14,7 +14,7 @@ import (
 	"go.uber.org/zap"
 )
 
-// FileRetriever allows retrieving a single file from a Docker volume.
+// FileRetriever allows retrieving files from Docker volumes or containers.
 // In the future it may allow retrieving an entire directory.
 type FileRetriever struct {
 	log *zap.Logger
@@ -32,7 +32,21 @@ func NewFileRetriever(log *zap.Logger, cli *client.Client, testName string) *Fil
 // SingleFileContent returns the content of the file named at relPath,
 // inside the volume specified by volumeName.
 func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPath string) ([]byte, error) {
-	const mountPath = "/mnt/dockervolume"
+	contents, err := r.FilesContent(ctx, VolumeFile{Volume: volumeName, Path: relPath})
+	if err != nil {
+		return nil, err
+	}
+	return contents[0], nil
+}
+
+// FilesContent returns the content of each of files, in order, ignoring their Content fields.
+// All volumes are mounted in a single one-off container, which is never started.
+func (r *FileRetriever) FilesContent(ctx context.Context, files ...VolumeFile) ([][]byte, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	mounts := newVolumeMounts(files)
 
 	if err := ensureBusybox(ctx, r.cli); err != nil {
 		return nil, err
@@ -51,7 +65,7 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 			Labels: map[string]string{CleanupLabel: r.testName},
 		},
 		&container.HostConfig{
-			Binds:      []string{volumeName + ":" + mountPath},
+			Binds:      mounts.binds,
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
@@ -70,7 +84,32 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 		}
 	}()
 
-	rc, _, err := r.cli.CopyFromContainer(ctx, cc.ID, path.Join(mountPath, relPath))
+	contents := make([][]byte, len(files))
+	for i, f := range files {
+		mountPath := mounts.paths[mounts.byVolume[f.Volume]]
+		if contents[i], err = r.copyFile(ctx, cc.ID, path.Join(mountPath, f.Path)); err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// ContainerFilesContent returns the content of each of relPaths, relative to dir,
+// read from an existing container without starting a one-off container.
+func (r *FileRetriever) ContainerFilesContent(ctx context.Context, containerID, dir string, relPaths ...string) ([][]byte, error) {
+	contents := make([][]byte, len(relPaths))
+	for i, relPath := range relPaths {
+		var err error
+		if contents[i], err = r.copyFile(ctx, containerID, path.Join(dir, relPath)); err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// copyFile returns the content of the file at the absolute path p in the container.
+func (r *FileRetriever) copyFile(ctx context.Context, containerID, p string) ([]byte, error) {
+	rc, _, err := r.cli.CopyFromContainer(ctx, containerID, p)
 	if err != nil {
 		return nil, fmt.Errorf("copying from container: %w", err)
 	}
@@ -78,7 +117,7 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 		_ = rc.Close()
 	}()
 
-	wantPath := path.Base(relPath)
+	wantPath := path.Base(p)
 	tr := tar.NewReader(rc)
 	for {
 		hdr, err := tr.Next()
@@ -89,12 +128,12 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 			return nil, fmt.Errorf("reading tar from container: %w", err)
 		}
 		if hdr.Name != wantPath {
-			r.log.Debug("Unexpected path", zap.String("want", relPath), zap.String("got", hdr.Name))
+			r.log.Debug("Unexpected path", zap.String("want", p), zap.String("got", hdr.Name))
 			continue
 		}
 
 		return io.ReadAll(tr)
 	}
 
-	return nil, fmt.Errorf("path %q not found in tar from container", relPath)
+	return nil, fmt.Errorf("path %q not found in tar from container", p)
 }
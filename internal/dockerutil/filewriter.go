@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -13,8 +14,7 @@ import (
 	"go.uber.org/zap"
 )
 
-// FileWriter allows retrieving a single file from a Docker volume.
-// In the future it may allow retrieving an entire directory.
+// FileWriter allows writing files into Docker volumes or containers.
 type FileWriter struct {
 	log *zap.Logger
 
@@ -28,9 +28,27 @@ func NewFileWriter(log *zap.Logger, cli *client.Client, testName string) *FileWr
 	return &FileWriter{log: log, cli: cli, testName: testName}
 }
 
+// VolumeFile is a file at Path, relative to the root of the Docker volume named Volume.
+type VolumeFile struct {
+	Volume  string
+	Path    string
+	Content []byte
+}
+
 // WriteFile writes the single file containing content, at relPath within the given volume.
 func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string, content []byte) error {
-	const mountPath = "/mnt/dockervolume"
+	return w.WriteFiles(ctx, VolumeFile{Volume: volumeName, Path: relPath, Content: content})
+}
+
+// WriteFiles writes files into their volumes with a single one-off container,
+// copying the files of each volume in one tar stream.
+// Every volume written to is chowned to the owner of its root directory, as WriteFile does.
+func (w *FileWriter) WriteFiles(ctx context.Context, files ...VolumeFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	mounts := newVolumeMounts(files)
 
 	if err := ensureBusybox(ctx, w.cli); err != nil {
 		return err
@@ -44,14 +62,12 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			Image: busyboxRef,
 
 			Entrypoint: []string{"sh", "-c"},
-			Cmd: []string{
-				// Take the uid and gid of the mount path,
-				// and set that as the owner of the new relative path.
-				`chown -R "$(stat -c '%u:%g' "$1")" "$2"`,
+			Cmd: append([]string{
+				// Take the uid and gid of each mount path,
+				// and set that as the owner of the new relative paths.
+				`for d in "$@"; do chown -R "$(stat -c '%u:%g' "$d")" "$d" || exit 1; done`,
 				"_", // Meaningless arg0 for sh -c with positional args.
-				mountPath,
-				mountPath,
-			},
+			}, mounts.paths...),
 
 			// Use root user to avoid permission issues when reading files from the volume.
 			User: GetRootUserString(),
@@ -59,7 +75,7 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			Labels: map[string]string{CleanupLabel: w.testName},
 		},
 		&container.HostConfig{
-			Binds:      []string{volumeName + ":" + mountPath},
+			Binds:      mounts.binds,
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
@@ -84,36 +100,28 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 	}()
 
-	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
-	if err := tw.WriteHeader(&tar.Header{
-		Name: relPath,
-
-		Size: int64(len(content)),
-		Mode: 0600,
-		// Not setting uname because the container will chown it anyway.
-
-		ModTime: time.Now(),
+	for i, mountPath := range mounts.paths {
+		contents := make(map[string][]byte)
+		for _, f := range files {
+			if mounts.byVolume[f.Volume] == i {
+				contents[f.Path] = f.Content
+			}
+		}
 
-		Format: tar.FormatPAX,
-	}); err != nil {
-		return fmt.Errorf("writing tar header: %w", err)
-	}
-	if _, err := tw.Write(content); err != nil {
-		return fmt.Errorf("writing content to tar: %w", err)
-	}
-	if err := tw.Close(); err != nil {
-		return fmt.Errorf("closing tar writer: %w", err)
-	}
+		buf, err := tarFiles(contents)
+		if err != nil {
+			return err
+		}
 
-	if err := w.cli.CopyToContainer(
-		ctx,
-		cc.ID,
-		mountPath,
-		&buf,
-		types.CopyToContainerOptions{},
-	); err != nil {
-		return fmt.Errorf("copying tar to container: %w", err)
+		if err := w.cli.CopyToContainer(
+			ctx,
+			cc.ID,
+			mountPath,
+			buf,
+			types.CopyToContainerOptions{},
+		); err != nil {
+			return fmt.Errorf("copying tar to container: %w", err)
+		}
 	}
 
 	if err := w.cli.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
@@ -140,3 +148,88 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 
 	return nil
 }
+
+// WriteContainerFiles writes files, keyed by their path relative to dir, into an existing container
+// in one tar stream, without starting a one-off container.
+// The files are owned by the user the container runs as.
+func (w *FileWriter) WriteContainerFiles(ctx context.Context, containerID, dir string, files map[string][]byte) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	buf, err := tarFiles(files)
+	if err != nil {
+		return err
+	}
+
+	if err := w.cli.CopyToContainer(
+		ctx,
+		containerID,
+		dir,
+		buf,
+		types.CopyToContainerOptions{CopyUIDGID: true},
+	); err != nil {
+		return fmt.Errorf("copying tar to container: %w", err)
+	}
+
+	return nil
+}
+
+// volumeMounts are the bind mounts of the one-off container accessing the volumes of a set of VolumeFiles.
+type volumeMounts struct {
+	binds []string
+	paths []string
+
+	// byVolume is the index in paths of each volume.
+	byVolume map[string]int
+}
+
+func newVolumeMounts(files []VolumeFile) volumeMounts {
+	m := volumeMounts{byVolume: make(map[string]int)}
+	for _, f := range files {
+		if _, ok := m.byVolume[f.Volume]; ok {
+			continue
+		}
+
+		mountPath := fmt.Sprintf("/mnt/dockervolume%d", len(m.paths))
+		m.byVolume[f.Volume] = len(m.paths)
+		m.paths = append(m.paths, mountPath)
+		m.binds = append(m.binds, f.Volume+":"+mountPath)
+	}
+	return m
+}
+
+// tarFiles returns a tar archive of files, keyed by their relative path.
+func tarFiles(files map[string][]byte) (*bytes.Buffer, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		content := files[name]
+		if err := tw.WriteHeader(&tar.Header{
+			Name: name,
+
+			Size: int64(len(content)),
+			Mode: 0600,
+			// Not setting uname because the files are chowned anyway.
+
+			ModTime: time.Now(),
+
+			Format: tar.FormatPAX,
+		}); err != nil {
+			return nil, fmt.Errorf("writing tar header: %w", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, fmt.Errorf("writing content to tar: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("closing tar writer: %w", err)
+	}
+	return &buf, nil
+}
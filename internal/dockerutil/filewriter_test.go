@@ -65,4 +65,46 @@ func TestFileWriter(t *testing.T) {
 
 		require.Equal(t, string(res.Stdout), ":D")
 	})
+
+	t.Run("many files across volumes", func(t *testing.T) {
+		v2, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+			Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+		})
+		require.NoError(t, err)
+
+		files := []dockerutil.VolumeFile{
+			{Volume: v.Name, Path: "config/genesis.json", Content: []byte("one")},
+			{Volume: v2.Name, Path: "config/genesis.json", Content: []byte("two")},
+			{Volume: v2.Name, Path: "config/gentx/gentx.json", Content: []byte("three")},
+		}
+		require.NoError(t, fw.WriteFiles(ctx, files...))
+
+		fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), cli, t.Name())
+		contents, err := fr.FilesContent(ctx, files...)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("one"), []byte("two"), []byte("three")}, contents)
+	})
+
+	t.Run("running container", func(t *testing.T) {
+		c, err := img.Start(ctx, []string{"sleep", "60"}, dockerutil.ContainerOptions{
+			Binds: []string{v.Name + ":/mnt/test"},
+			User:  dockerutil.GetRootUserString(),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, fw.WriteContainerFiles(ctx, c.Name, "/mnt/test", map[string][]byte{
+			"live.txt":        []byte("live"),
+			"nested/live.txt": []byte("nested"),
+		}))
+
+		fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), cli, t.Name())
+		contents, err := fr.ContainerFilesContent(ctx, c.Name, "/mnt/test", "live.txt", "nested/live.txt")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("live"), []byte("nested")}, contents)
+
+		// The files landed in the volume.
+		b, err := fr.SingleFileContent(ctx, v.Name, "nested/live.txt")
+		require.NoError(t, err)
+		require.Equal(t, "nested", string(b))
+	})
 }
@@ -18,6 +18,8 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/stdcopy"
 	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
 // Image is a docker image.
@@ -89,6 +91,9 @@ type ContainerOptions struct {
 
 	// If non-zero, will limit the amount of log lines returned.
 	LogTail uint64
+
+	// Resource limits of the container.
+	Resources ibc.Resources
 }
 
 // ContainerExecResult is a wrapper type that wraps an exit code and associated output from stderr & stdout, along with
@@ -116,6 +121,50 @@ func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions
 	return c.Wait(ctx, opts.LogTail)
 }
 
+// ExecReporter has the same method as ibc.ChainExecReporter.
+// It is redeclared here so that internal/dockerutil does not depend on ibc.
+type ExecReporter interface {
+	TrackChainExec(
+		containerName string,
+		command, env []string,
+		stdout, stderr string,
+		exitCode int,
+		startedAt, finishedAt time.Time,
+		err error,
+	)
+}
+
+// RunReported is like Run, but also reports the container, command, environment, output and timing to rep.
+func (image *Image) RunReported(ctx context.Context, rep ExecReporter, cmd []string, opts ContainerOptions) ContainerExecResult {
+	startedAt := time.Now()
+
+	var (
+		containerName string
+		res           ContainerExecResult
+	)
+	c, err := image.Start(ctx, cmd, opts)
+	if err != nil {
+		res = ContainerExecResult{
+			Err:      err,
+			ExitCode: -1,
+		}
+	} else {
+		containerName = c.Name
+		res = c.Wait(ctx, opts.LogTail)
+	}
+
+	rep.TrackChainExec(
+		containerName,
+		cmd, opts.Env,
+		string(res.Stdout), string(res.Stderr),
+		res.ExitCode,
+		startedAt, time.Now(),
+		res.Err,
+	)
+
+	return res
+}
+
 func (image *Image) imageRef() string {
 	return image.repository + ":" + image.tag
 }
@@ -156,6 +205,11 @@ func (image *Image) createContainer(ctx context.Context, containerName, hostName
 		}
 	}
 
+	res, err := containerResources(opts.Resources)
+	if err != nil {
+		return "", err
+	}
+
 	cc, err := image.client.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -175,6 +229,7 @@ func (image *Image) createContainer(ctx context.Context, containerName, hostName
 			Binds:           opts.Binds,
 			PublishAllPorts: true, // Because we publish all ports, no need to expose specific ports.
 			AutoRemove:      false,
+			Resources:       res,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -246,7 +301,7 @@ type Container struct {
 }
 
 // Wait blocks until the container exits. Calling wait is not suitable for daemons and servers.
-// A non-zero status code returns an error.
+// A non-zero status code returns an error, along with the output of the container.
 //
 // Wait implicitly calls Stop.
 // If logTail is non-zero, the stdout and stderr logs will be truncated at the end to that number of lines.
@@ -293,6 +348,14 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 		logOpts.Tail = strconv.FormatUint(logTail, 10)
 	}
 
+	// The container is removed by Stop, so check whether it ran out of memory first.
+	var oomErr error
+	if exitCode != 0 {
+		if cjson, err := c.image.client.ContainerInspect(ctx, c.containerID); err == nil {
+			oomErr = OOMKilledError(cjson)
+		}
+	}
+
 	rc, err := c.image.client.ContainerLogs(ctx, c.containerID, logOpts)
 	if err != nil {
 		return ContainerExecResult{
@@ -323,11 +386,15 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 
 	if exitCode != 0 {
 		out := strings.Join([]string{stdoutBuf.String(), stderrBuf.String()}, " ")
+		err := fmt.Errorf("exit code %d: %s", exitCode, out)
+		if oomErr != nil {
+			err = fmt.Errorf("%w; exit code %d: %s", oomErr, exitCode, out)
+		}
 		return ContainerExecResult{
-			Err:      fmt.Errorf("exit code %d: %s", exitCode, out),
+			Err:      err,
 			ExitCode: exitCode,
-			Stdout:   nil,
-			Stderr:   nil,
+			Stdout:   stdoutBuf.Bytes(),
+			Stderr:   stderrBuf.Bytes(),
 		}
 	}
 
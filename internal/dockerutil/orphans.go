@@ -0,0 +1,142 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"go.uber.org/multierr"
+)
+
+// Kinds of LabeledResource.
+const (
+	ContainerResource = "container"
+	VolumeResource    = "volume"
+	NetworkResource   = "network"
+)
+
+// LabeledResource is a docker container, volume or network labeled with CleanupLabel.
+type LabeledResource struct {
+	// Kind is one of ContainerResource, VolumeResource or NetworkResource.
+	Kind string
+	ID   string
+	Name string
+
+	// TestName is the name of the test that created the resource, i.e. the value of its CleanupLabel.
+	TestName string
+}
+
+func (r LabeledResource) String() string {
+	return fmt.Sprintf("%s %s (test %s)", r.Kind, r.Name, r.TestName)
+}
+
+// FindLabeled returns the containers, volumes and networks labeled with CleanupLabel,
+// in that order.
+// If testNames is not empty, only the resources of those tests and their subtests are returned.
+func FindLabeled(ctx context.Context, cli *client.Client, testNames []string) ([]LabeledResource, error) {
+	args := filters.NewArgs(filters.Arg("label", CleanupLabel))
+
+	var found []LabeledResource
+
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("listing containers: %w", err)
+	}
+	for _, c := range cs {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		found = appendMatching(found, testNames, LabeledResource{
+			Kind: ContainerResource, ID: c.ID, Name: name, TestName: c.Labels[CleanupLabel],
+		})
+	}
+
+	vs, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("listing volumes: %w", err)
+	}
+	for _, v := range vs.Volumes {
+		found = appendMatching(found, testNames, LabeledResource{
+			Kind: VolumeResource, ID: v.Name, Name: v.Name, TestName: v.Labels[CleanupLabel],
+		})
+	}
+
+	ns, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("listing networks: %w", err)
+	}
+	for _, n := range ns {
+		found = appendMatching(found, testNames, LabeledResource{
+			Kind: NetworkResource, ID: n.ID, Name: n.Name, TestName: n.Labels[CleanupLabel],
+		})
+	}
+
+	return found, nil
+}
+
+// appendMatching appends r to rs if r belongs to one of testNames or their subtests,
+// or if testNames is empty.
+func appendMatching(rs []LabeledResource, testNames []string, r LabeledResource) []LabeledResource {
+	if len(testNames) == 0 {
+		return append(rs, r)
+	}
+	for _, name := range testNames {
+		if r.TestName == name || strings.HasPrefix(r.TestName, name+"/") {
+			return append(rs, r)
+		}
+	}
+	return rs
+}
+
+// RemoveLabeled stops and removes the resources returned by FindLabeled.
+// Containers are removed first, so that their volumes and networks are no longer in use.
+// RemoveLabeled continues past failures, and returns the resources it removed alongside the combined errors.
+func RemoveLabeled(ctx context.Context, cli *client.Client, rs []LabeledResource) ([]LabeledResource, error) {
+	var (
+		removed []LabeledResource
+		errs    error
+	)
+	for _, kind := range []string{ContainerResource, VolumeResource, NetworkResource} {
+		for _, r := range rs {
+			if r.Kind != kind {
+				continue
+			}
+			if err := removeLabeled(ctx, cli, r); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("removing %s: %w", r, err))
+				continue
+			}
+			removed = append(removed, r)
+		}
+	}
+	return removed, errs
+}
+
+func removeLabeled(ctx context.Context, cli *client.Client, r LabeledResource) error {
+	var err error
+	switch r.Kind {
+	case ContainerResource:
+		timeout := 10
+		if err := cli.ContainerStop(ctx, r.ID, container.StopOptions{Timeout: &timeout}); isLoggableStopError(err) {
+			return err
+		}
+		err = cli.ContainerRemove(ctx, r.ID, types.ContainerRemoveOptions{Force: true})
+	case VolumeResource:
+		err = cli.VolumeRemove(ctx, r.ID, false)
+	case NetworkResource:
+		err = cli.NetworkRemove(ctx, r.ID)
+	default:
+		return fmt.Errorf("unknown resource kind %q", r.Kind)
+	}
+	if errdefs.IsNotFound(err) {
+		// Already gone, e.g. removed along with its test by a concurrent docker cleanup.
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package dockerutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendMatching(t *testing.T) {
+	r := func(testName string) LabeledResource {
+		return LabeledResource{Kind: VolumeResource, Name: "v", TestName: testName}
+	}
+
+	var rs []LabeledResource
+	for _, name := range []string{"TestA", "TestA/sub", "TestAB", "TestB"} {
+		rs = appendMatching(rs, []string{"TestA", "TestC"}, r(name))
+	}
+	require.Equal(t, []LabeledResource{r("TestA"), r("TestA/sub")}, rs)
+
+	require.Len(t, appendMatching(nil, nil, r("TestB")), 1)
+
+	require.Equal(t, "volume v (test TestA)", r("TestA").String())
+}
+
+func TestFindAndRemoveLabeled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	cli, _ := DockerSetup(t)
+
+	// Resources of a killed test, which the docker cleanup of this test does not remove.
+	orphanTest := t.Name() + "-orphan-" + RandLowerCaseLetterString(5)
+	labels := map[string]string{CleanupLabel: orphanTest}
+
+	v, err := cli.VolumeCreate(ctx, volume.CreateOptions{Labels: labels})
+	require.NoError(t, err)
+	n, err := cli.NetworkCreate(ctx, "interchaintest-orphan-"+RandLowerCaseLetterString(8), types.NetworkCreate{Labels: labels})
+	require.NoError(t, err)
+
+	rs, err := FindLabeled(ctx, cli, []string{orphanTest})
+	require.NoError(t, err)
+	require.Len(t, rs, 2)
+	require.Equal(t, LabeledResource{Kind: VolumeResource, ID: v.Name, Name: v.Name, TestName: orphanTest}, rs[0])
+	require.Equal(t, NetworkResource, rs[1].Kind)
+	require.Equal(t, n.ID, rs[1].ID)
+
+	removed, err := RemoveLabeled(ctx, cli, rs)
+	require.NoError(t, err)
+	require.Equal(t, rs, removed)
+
+	rs, err = FindLabeled(ctx, cli, []string{orphanTest})
+	require.NoError(t, err)
+	require.Empty(t, rs)
+}
@@ -0,0 +1,53 @@
+package dockerutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// containerResources converts r to the resources of a docker container.
+func containerResources(r ibc.Resources) (container.Resources, error) {
+	res := container.Resources{
+		NanoCPUs:    int64(r.CPUs * 1e9),
+		BlkioWeight: r.BlkioWeight,
+	}
+
+	if r.Memory != "" {
+		mem, err := units.RAMInBytes(r.Memory)
+		if err != nil {
+			return res, fmt.Errorf("invalid memory limit %q: %w", r.Memory, err)
+		}
+		res.Memory = mem
+	}
+
+	if r.PidsLimit > 0 {
+		limit := r.PidsLimit
+		res.PidsLimit = &limit
+	}
+
+	if r.BlkioWeight != 0 && (r.BlkioWeight < 10 || r.BlkioWeight > 1000) {
+		return res, fmt.Errorf("invalid blkio weight %d: must be between 10 and 1000", r.BlkioWeight)
+	}
+
+	return res, nil
+}
+
+// OOMKilledError returns an error reporting that the inspected container was killed for running out of memory,
+// or nil if it was not.
+func OOMKilledError(c types.ContainerJSON) error {
+	if c.ContainerJSONBase == nil || c.State == nil || !c.State.OOMKilled {
+		return nil
+	}
+
+	limit := "no memory limit"
+	if c.HostConfig != nil && c.HostConfig.Memory > 0 {
+		limit = "memory limit " + units.BytesSize(float64(c.HostConfig.Memory))
+	}
+	return fmt.Errorf("container %s was killed because it ran out of memory (%s)", strings.TrimPrefix(c.Name, "/"), limit)
+}
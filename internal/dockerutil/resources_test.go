@@ -0,0 +1,48 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+func TestContainerResources(t *testing.T) {
+	res, err := containerResources(ibc.Resources{})
+	require.NoError(t, err)
+	require.Equal(t, container.Resources{}, res)
+
+	res, err = containerResources(ibc.Resources{CPUs: 1.5, Memory: "512m", PidsLimit: 256, BlkioWeight: 500})
+	require.NoError(t, err)
+	require.Equal(t, int64(1_500_000_000), res.NanoCPUs)
+	require.Equal(t, int64(512*1024*1024), res.Memory)
+	require.Equal(t, int64(256), *res.PidsLimit)
+	require.Equal(t, uint16(500), res.BlkioWeight)
+
+	_, err = containerResources(ibc.Resources{Memory: "lots"})
+	require.ErrorContains(t, err, `invalid memory limit "lots"`)
+
+	_, err = containerResources(ibc.Resources{BlkioWeight: 5})
+	require.EqualError(t, err, "invalid blkio weight 5: must be between 10 and 1000")
+}
+
+func TestOOMKilledError(t *testing.T) {
+	inspect := func(oomKilled bool, memory int64) types.ContainerJSON {
+		return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+			Name:       "/gaia-1-val-0",
+			State:      &types.ContainerState{OOMKilled: oomKilled},
+			HostConfig: &container.HostConfig{Resources: container.Resources{Memory: memory}},
+		}}
+	}
+
+	require.NoError(t, OOMKilledError(inspect(false, 0)))
+	require.NoError(t, OOMKilledError(types.ContainerJSON{}))
+
+	require.EqualError(t, OOMKilledError(inspect(true, 512*1024*1024)),
+		"container gaia-1-val-0 was killed because it ran out of memory (memory limit 512MiB)")
+	require.EqualError(t, OOMKilledError(inspect(true, 0)),
+		"container gaia-1-val-0 was killed because it ran out of memory (no memory limit)")
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -68,12 +70,9 @@ func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 		panic(fmt.Errorf("failed to create docker client: %v", err))
 	}
 
-	// Clean up docker resources at end of test.
-	t.Cleanup(dockerCleanup(t, cli))
-
-	// Also eagerly clean up any leftover resources from a previous test run,
+	// Eagerly clean up any leftover resources from a previous test run,
 	// e.g. if the test was interrupted.
-	dockerCleanup(t, cli)()
+	dockerCleanup(t, cli, "")()
 
 	name := fmt.Sprintf("interchaintest-%s", RandLowerCaseLetterString(8))
 	network, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
@@ -85,16 +84,56 @@ func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 		panic(fmt.Errorf("failed to create docker network: %v", err))
 	}
 
+	// Clean up docker resources at end of test.
+	t.Cleanup(dockerCleanup(t, cli, network.ID))
+
 	return cli, network.ID
 }
 
-// dockerCleanup will clean up Docker containers, networks, and the other various config files generated in testing
-func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
+var (
+	cleanupHooksMu sync.Mutex
+	cleanupHooks   = make(map[string][]func(failed bool))
+)
+
+// BeforeDockerCleanup registers fn to be called at the start of the docker cleanup of the test
+// that created the docker network with the given ID through DockerSetup,
+// while the containers and volumes of the test still exist.
+// The argument to fn reports whether the test failed.
+// The hooks of a network are discarded after its docker cleanup.
+func BeforeDockerCleanup(networkID string, fn func(failed bool)) {
+	cleanupHooksMu.Lock()
+	defer cleanupHooksMu.Unlock()
+	cleanupHooks[networkID] = append(cleanupHooks[networkID], fn)
+}
+
+func takeCleanupHooks(networkID string) []func(failed bool) {
+	cleanupHooksMu.Lock()
+	defer cleanupHooksMu.Unlock()
+	hooks := cleanupHooks[networkID]
+	delete(cleanupHooks, networkID)
+	return hooks
+}
+
+// dockerCleanup will clean up Docker containers, networks, and the other various config files generated in testing.
+// networkID is the network created by DockerSetup for the final cleanup at the end of the test,
+// and empty for the eager cleanup of resources left over from a previous run of the test.
+// The final cleanup runs the hooks registered for networkID through BeforeDockerCleanup,
+// and writes container logs to the test's artifact directory
+// when the test failed or CaptureContainerLogsAlways is set.
+func dockerCleanup(t DockerSetupTestingT, cli *client.Client, networkID string) func() {
+	final := networkID != ""
 	return func() {
 		showContainerLogs := os.Getenv("SHOW_CONTAINER_LOGS") != ""
 		containerLogTail := os.Getenv("CONTAINER_LOG_TAIL")
 		ctx := context.TODO()
 		cli.NegotiateAPIVersion(ctx)
+
+		if final {
+			for _, h := range takeCleanupHooks(networkID) {
+				h(t.Failed())
+			}
+		}
+
 		cs, err := cli.ContainerList(ctx, types.ContainerListOptions{
 			All: true,
 			Filters: filters.NewArgs(
@@ -106,7 +145,27 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 			return
 		}
 
+		var (
+			logsDir   string
+			logsHooks []func(containerName, path string)
+		)
+		if final {
+			if dir := TestArtifactsDir(t.Name()); dir != "" && (t.Failed() || CaptureContainerLogsAlways) {
+				logsDir = filepath.Join(dir, "container-logs")
+			}
+			logsHooks = takeContainerLogsHooks(networkID)
+		}
+
 		for _, c := range cs {
+			if final {
+				// A node that died of running out of memory otherwise only shows up as a stalled chain.
+				if cjson, err := cli.ContainerInspect(ctx, c.ID); err == nil {
+					if err := OOMKilledError(cjson); err != nil {
+						t.Logf("%v", err)
+					}
+				}
+			}
+
 			var stopTimeout container.StopOptions
 			timeout := 10
 			timeoutDur := time.Duration(timeout * int(time.Second))
@@ -150,6 +209,16 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 				}
 			}
 
+			if logsDir != "" {
+				if path, err := writeContainerLogs(ctx, cli, logsDir, c); err != nil {
+					t.Logf("Failed to capture logs of container %s during docker cleanup: %v", c.ID, err)
+				} else {
+					for _, h := range logsHooks {
+						h(strings.Join(c.Names, " "), path)
+					}
+				}
+			}
+
 			if err := cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{
 				// Not removing volumes with the container, because we separately handle them conditionally.
 				Force: true,
@@ -158,6 +227,10 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 			}
 		}
 
+		if logsDir != "" && len(cs) > 0 {
+			t.Logf("Container logs written to %s", logsDir)
+		}
+
 		pruneVolumesWithRetry(ctx, t, cli)
 		pruneNetworksWithRetry(ctx, t, cli)
 	}
@@ -3,6 +3,8 @@ package dockerutil_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -10,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestDockerSetup_KeepVolumes(t *testing.T) {
@@ -78,3 +81,82 @@ func TestDockerSetup_KeepVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerSetup_CaptureContainerLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	origDir, origAlways := dockerutil.ArtifactsDir, dockerutil.CaptureContainerLogsAlways
+	defer func() {
+		dockerutil.ArtifactsDir, dockerutil.CaptureContainerLogsAlways = origDir, origAlways
+	}()
+
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		always   bool
+		passed   bool
+		captured bool
+	}{
+		{always: false, passed: false, captured: true},
+		{always: true, passed: false, captured: true},
+		{always: false, passed: true, captured: false},
+		{always: true, passed: true, captured: true},
+	} {
+		tc := tc
+		state := "failed"
+		if tc.passed {
+			state = "passed"
+		}
+
+		testName := fmt.Sprintf("always=%t, test %s", tc.always, state)
+		t.Run(testName, func(t *testing.T) {
+			dockerutil.ArtifactsDir = t.TempDir()
+			dockerutil.CaptureContainerLogsAlways = tc.always
+			mt := mocktesting.NewT(t.Name())
+
+			var captured []string
+			mt.Simulate(func() {
+				cli, networkID := dockerutil.DockerSetup(mt)
+				dockerutil.OnContainerLogsCaptured(networkID, func(_, path string) {
+					captured = append(captured, path)
+				})
+
+				// Start, unlike Run, leaves the container in place for docker cleanup to capture its logs.
+				img := dockerutil.NewImage(zap.NewNop(), cli, networkID, mt.Name(), "busybox", "stable")
+				_, err := img.Start(ctx, []string{"echo", "hello from the container"}, dockerutil.ContainerOptions{})
+				require.NoError(t, err)
+
+				if !tc.passed {
+					mt.Fail()
+				}
+			})
+
+			if !tc.captured {
+				require.Empty(t, captured)
+				return
+			}
+
+			require.Len(t, captured, 1)
+			require.True(t, strings.HasPrefix(captured[0], dockerutil.TestArtifactsDir(mt.Name())))
+
+			logs, err := os.ReadFile(captured[0])
+			require.NoError(t, err)
+			require.Contains(t, string(logs), "hello from the container")
+		})
+	}
+}
+
+func TestTestArtifactsDir(t *testing.T) {
+	origDir := dockerutil.ArtifactsDir
+	defer func() {
+		dockerutil.ArtifactsDir = origDir
+	}()
+
+	dockerutil.ArtifactsDir = "/artifacts"
+	require.Equal(t, "/artifacts/TestFoo/gaia_v7.0.1_osmosis/rly", dockerutil.TestArtifactsDir("TestFoo/gaia:v7.0.1+osmosis/rly"))
+
+	dockerutil.ArtifactsDir = ""
+	require.Empty(t, dockerutil.TestArtifactsDir("TestFoo"))
+}
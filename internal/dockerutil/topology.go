@@ -0,0 +1,410 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// Topology describes the docker containers and volumes of a test.
+type Topology struct {
+	Containers []TopologyContainer `json:"containers"`
+
+	// Volumes are all volumes of the test, including those not mounted by any container,
+	// such as the home directory of a relayer that was not running.
+	Volumes []TopologyVolume `json:"volumes"`
+}
+
+// TopologyContainer describes a container of a test, with enough detail to recreate it.
+type TopologyContainer struct {
+	Name       string   `json:"name"`
+	Hostname   string   `json:"hostname"`
+	Image      string   `json:"image"`
+	Entrypoint []string `json:"entrypoint,omitempty"`
+	Cmd        []string `json:"cmd,omitempty"`
+	Env        []string `json:"env,omitempty"`
+	User       string   `json:"user,omitempty"`
+
+	// ExposedPorts are the container ports, such as "26657/tcp".
+	ExposedPorts []string `json:"exposed_ports,omitempty"`
+
+	Mounts []TopologyMount `json:"mounts,omitempty"`
+}
+
+// TopologyMount describes a volume mounted in a TopologyContainer.
+type TopologyMount struct {
+	Volume      string `json:"volume"`
+	Destination string `json:"destination"`
+}
+
+// TopologyVolume describes a volume of a Topology.
+type TopologyVolume struct {
+	Name string `json:"name"`
+
+	// Owner is the value of the NodeOwnerLabel of the volume, if any.
+	Owner string `json:"owner,omitempty"`
+
+	// Archive is the path of the volume archive, relative to the directory passed to CaptureTopology.
+	Archive string `json:"archive,omitempty"`
+}
+
+// CaptureTopology inspects the containers of the test with the given name,
+// and archives the test's volumes to dir/volumes.
+// Files that are useful to read without restoring a volume, such as genesis files and configuration,
+// are also extracted to dir/files/<volume owner>.
+//
+// CaptureTopology must be called before the test's docker cleanup removes the containers and volumes.
+func CaptureTopology(ctx context.Context, log *zap.Logger, cli *client.Client, testName, dir string) (Topology, error) {
+	var top Topology
+	f := filters.NewArgs(filters.Arg("label", CleanupLabel+"="+testName))
+
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: f})
+	if err != nil {
+		return top, fmt.Errorf("listing containers: %w", err)
+	}
+	for _, c := range cs {
+		info, err := cli.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return top, fmt.Errorf("inspecting container %s: %w", c.ID, err)
+		}
+		if strings.HasPrefix(strings.TrimPrefix(info.Name, "/"), "interchaintest-volume") {
+			// Skip short-lived helper containers such as those of SetVolumeOwner and ArchiveVolume.
+			continue
+		}
+
+		tc := TopologyContainer{
+			Name:       strings.TrimPrefix(info.Name, "/"),
+			Hostname:   info.Config.Hostname,
+			Image:      info.Config.Image,
+			Entrypoint: info.Config.Entrypoint,
+			Cmd:        info.Config.Cmd,
+			Env:        info.Config.Env,
+			User:       info.Config.User,
+		}
+		for p := range info.Config.ExposedPorts {
+			tc.ExposedPorts = append(tc.ExposedPorts, string(p))
+		}
+		sort.Strings(tc.ExposedPorts)
+
+		for _, m := range info.Mounts {
+			if m.Type != mount.TypeVolume {
+				continue
+			}
+			tc.Mounts = append(tc.Mounts, TopologyMount{Volume: m.Name, Destination: m.Destination})
+		}
+
+		top.Containers = append(top.Containers, tc)
+	}
+	sort.Slice(top.Containers, func(i, j int) bool { return top.Containers[i].Name < top.Containers[j].Name })
+
+	vs, err := cli.VolumeList(ctx, volume.ListOptions{Filters: f})
+	if err != nil {
+		return top, fmt.Errorf("listing volumes: %w", err)
+	}
+	for _, v := range vs.Volumes {
+		tv := TopologyVolume{Name: v.Name, Owner: v.Labels[NodeOwnerLabel]}
+		a, err := captureVolume(ctx, log, cli, testName, dir, tv)
+		if err != nil {
+			return top, fmt.Errorf("capturing volume %s: %w", v.Name, err)
+		}
+		tv.Archive = a
+		top.Volumes = append(top.Volumes, tv)
+	}
+	sort.Slice(top.Volumes, func(i, j int) bool { return top.Volumes[i].Name < top.Volumes[j].Name })
+
+	return top, nil
+}
+
+// captureVolume archives the volume to dir/volumes/<volume>.tar,
+// extracts its readable files, and returns the path of the archive relative to dir.
+func captureVolume(ctx context.Context, log *zap.Logger, cli *client.Client, testName, dir string, v TopologyVolume) (string, error) {
+	rel := filepath.Join("volumes", v.Name+".tar")
+	p := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", fmt.Errorf("mkdirall: %w", err)
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return "", err
+	}
+	if err := ArchiveVolume(ctx, log, cli, testName, v.Name, f); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	owner := v.Owner
+	if owner == "" {
+		owner = v.Name
+	}
+	if err := extractReadableFiles(p, filepath.Join(dir, "files", SanitizeContainerName(owner))); err != nil {
+		return "", fmt.Errorf("extracting files: %w", err)
+	}
+	return rel, nil
+}
+
+// IsTopologyFile reports whether the file at the given path inside a volume
+// is worth extracting from the volume archive: genesis files and configuration.
+func IsTopologyFile(name string) bool {
+	base := path.Base(name)
+	if strings.Contains(base, "genesis") && strings.HasSuffix(base, ".json") {
+		return true
+	}
+	switch path.Ext(base) {
+	case ".toml", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// extractReadableFiles writes the regular files of the volume archive at archivePath
+// for which IsTopologyFile returns true to destDir, preserving their path inside the volume.
+func extractReadableFiles(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Entries are rooted at the volume archive directory; see ArchiveVolume.
+		name := strings.TrimPrefix(path.Clean(hdr.Name), volumeArchiveRoot+"/")
+		if strings.HasPrefix(name, "../") || !IsTopologyFile(name) {
+			continue
+		}
+
+		dst := filepath.Join(destDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		out, err := os.Create(dst)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			_ = out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+	}
+}
+
+// RelaunchedTopology is a set of containers recreated by RelaunchTopology.
+type RelaunchedTopology struct {
+	log       *zap.Logger
+	cli       *client.Client
+	networkID string
+	label     string
+
+	// HostPorts maps container names to their exposed container ports to the host address they are published on.
+	HostPorts map[string]map[string]string
+}
+
+// RelaunchTopology recreates the volumes of top, then recreates and starts its containers on a new network,
+// under the same names, so that they can still reach each other by hostname.
+//
+// Volumes that still exist, for example because the test ran with KeepVolumesOnFailure,
+// are used as they are; missing volumes are restored from their archive in dir.
+// All docker resources created are labelled with label, and are removed by Stop.
+func RelaunchTopology(ctx context.Context, log *zap.Logger, cli *client.Client, label, dir string, top Topology) (*RelaunchedTopology, error) {
+	name := fmt.Sprintf("interchaintest-relaunch-%s", RandLowerCaseLetterString(8))
+	n, err := cli.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+
+		Labels: map[string]string{CleanupLabel: label},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating network: %w", err)
+	}
+
+	rt := &RelaunchedTopology{
+		log:       log,
+		cli:       cli,
+		networkID: n.ID,
+		label:     label,
+		HostPorts: make(map[string]map[string]string),
+	}
+
+	for _, v := range top.Volumes {
+		if err := rt.ensureVolume(ctx, dir, v); err != nil {
+			return rt, fmt.Errorf("volume %s: %w", v.Name, err)
+		}
+	}
+
+	for _, tc := range top.Containers {
+		binds := make([]string, len(tc.Mounts))
+		for i, m := range tc.Mounts {
+			binds[i] = m.Volume + ":" + m.Destination
+		}
+
+		if err := rt.start(ctx, tc, binds); err != nil {
+			return rt, fmt.Errorf("container %s: %w", tc.Name, err)
+		}
+	}
+
+	return rt, nil
+}
+
+// ensureVolume creates the volume from its archive unless it still exists.
+func (rt *RelaunchedTopology) ensureVolume(ctx context.Context, dir string, v TopologyVolume) error {
+	_, err := rt.cli.VolumeInspect(ctx, v.Name)
+	if err == nil {
+		rt.log.Info("Reusing preserved volume", zap.String("volume", v.Name))
+		return nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return err
+	}
+	if v.Archive == "" {
+		return fmt.Errorf("volume no longer exists and has no archive")
+	}
+
+	labels := map[string]string{CleanupLabel: rt.label}
+	if v.Owner != "" {
+		labels[NodeOwnerLabel] = v.Owner
+	}
+	if _, err := rt.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   v.Name,
+		Labels: labels,
+	}); err != nil {
+		return fmt.Errorf("creating volume: %w", err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, v.Archive))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rt.log.Info("Restoring volume from archive", zap.String("volume", v.Name), zap.String("archive", v.Archive))
+	return RestoreVolume(ctx, rt.log, rt.cli, rt.label, v.Name, f)
+}
+
+func (rt *RelaunchedTopology) start(ctx context.Context, tc TopologyContainer, binds []string) error {
+	exposed := make(nat.PortSet, len(tc.ExposedPorts))
+	for _, p := range tc.ExposedPorts {
+		exposed[nat.Port(p)] = struct{}{}
+	}
+
+	entrypoint := tc.Entrypoint
+	if entrypoint == nil {
+		// Match the containers created by ContainerLifecycle and Image,
+		// which override the image entrypoint.
+		entrypoint = []string{}
+	}
+
+	cc, err := rt.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: tc.Image,
+
+			Entrypoint: entrypoint,
+			Cmd:        tc.Cmd,
+			Env:        tc.Env,
+			User:       tc.User,
+
+			Hostname: tc.Hostname,
+
+			Labels: map[string]string{CleanupLabel: rt.label},
+
+			ExposedPorts: exposed,
+		},
+		&container.HostConfig{
+			Binds:           binds,
+			PublishAllPorts: true,
+			DNS:             []string{},
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				rt.networkID: {Aliases: []string{tc.Hostname}},
+			},
+		},
+		nil,
+		tc.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	if err := StartContainer(ctx, rt.cli, cc.ID); err != nil {
+		return fmt.Errorf("starting container: %w", err)
+	}
+
+	info, err := rt.cli.ContainerInspect(ctx, cc.ID)
+	if err != nil {
+		return fmt.Errorf("inspecting container: %w", err)
+	}
+	hostPorts := make(map[string]string, len(tc.ExposedPorts))
+	for _, p := range tc.ExposedPorts {
+		if hp := GetHostPort(info, p); hp != "" {
+			hostPorts[p] = hp
+		}
+	}
+	rt.HostPorts[tc.Name] = hostPorts
+	return nil
+}
+
+// Stop removes the containers and network created by RelaunchTopology.
+// Volumes are removed only if removeVolumes is true.
+func (rt *RelaunchedTopology) Stop(ctx context.Context, removeVolumes bool) error {
+	f := filters.NewArgs(filters.Arg("label", CleanupLabel+"="+rt.label))
+
+	cs, err := rt.cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: f})
+	if err != nil {
+		return fmt.Errorf("listing containers: %w", err)
+	}
+	var errs error
+	for _, c := range cs {
+		if err := rt.cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("removing container %s: %w", c.ID, err))
+		}
+	}
+
+	if removeVolumes {
+		if _, err := rt.cli.VolumesPrune(ctx, f); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("pruning volumes: %w", err))
+		}
+	}
+
+	if err := rt.cli.NetworkRemove(ctx, rt.networkID); err != nil && !errdefs.IsNotFound(err) {
+		errs = multierr.Append(errs, fmt.Errorf("removing network: %w", err))
+	}
+
+	return errs
+}
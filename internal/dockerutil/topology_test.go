@@ -0,0 +1,95 @@
+package dockerutil_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestIsTopologyFile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"config/genesis.json":           true,
+		"config/config.toml":            true,
+		"config/app.toml":               true,
+		".relayer/config/config.yaml":   true,
+		"chain-spec-raw-genesis.json":   true,
+		"config/node_key.json":          false,
+		"data/blockstore.db/000001.log": false,
+		"keyring-test/relayer.info":     false,
+	} {
+		require.Equal(t, want, dockerutil.IsTopologyFile(name), name)
+	}
+}
+
+func TestCaptureTopology(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := interchaintest.DockerSetup(t)
+
+	ctx := context.Background()
+	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{
+			dockerutil.CleanupLabel:   t.Name(),
+			dockerutil.NodeOwnerLabel: "node-0",
+		},
+	})
+	require.NoError(t, err)
+
+	fw := dockerutil.NewFileWriter(zaptest.NewLogger(t), cli, t.Name())
+	require.NoError(t, fw.WriteFile(ctx, v.Name, "config/genesis.json", []byte(`{"chain_id":"test-1"}`)))
+	require.NoError(t, fw.WriteFile(ctx, v.Name, "data/state.db", []byte("state")))
+
+	img := dockerutil.NewImage(zaptest.NewLogger(t), cli, network, t.Name(), "busybox", "stable")
+	_, err = img.Start(ctx, []string{"sleep", "60"}, dockerutil.ContainerOptions{
+		Binds: []string{v.Name + ":/home"},
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	top, err := dockerutil.CaptureTopology(ctx, zaptest.NewLogger(t), cli, t.Name(), dir)
+	require.NoError(t, err)
+
+	require.Len(t, top.Containers, 1)
+	require.Equal(t, []string{"sleep", "60"}, top.Containers[0].Cmd)
+	require.Equal(t, []dockerutil.TopologyMount{{Volume: v.Name, Destination: "/home"}}, top.Containers[0].Mounts)
+
+	require.Len(t, top.Volumes, 1)
+	require.Equal(t, "node-0", top.Volumes[0].Owner)
+	require.FileExists(t, filepath.Join(dir, top.Volumes[0].Archive))
+
+	genesis, err := os.ReadFile(filepath.Join(dir, "files", "node-0", "config", "genesis.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"chain_id":"test-1"}`, string(genesis))
+	require.NoFileExists(t, filepath.Join(dir, "files", "node-0", "data", "state.db"))
+
+	// Restore the archive into a fresh volume.
+	restored := v.Name + "-restored"
+	_, err = cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Name:   restored,
+		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+	})
+	require.NoError(t, err)
+
+	f, err := os.Open(filepath.Join(dir, top.Volumes[0].Archive))
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, dockerutil.RestoreVolume(ctx, zaptest.NewLogger(t), cli, t.Name(), restored, f))
+
+	res := img.Run(ctx, []string{"cat", "/mnt/test/data/state.db"}, dockerutil.ContainerOptions{
+		Binds: []string{restored + ":/mnt/test"},
+		User:  dockerutil.GetRootUserString(),
+	})
+	require.NoError(t, res.Err)
+	require.Equal(t, "state", string(res.Stdout))
+}
@@ -0,0 +1,100 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// volumeArchiveMountDir is where ArchiveVolume and RestoreVolume mount the volume.
+// Archives written by ArchiveVolume contain the volume contents under volumeArchiveRoot.
+const (
+	volumeArchiveMountDir = "/mnt"
+	volumeArchiveRoot     = "dockervolume"
+)
+
+// ArchiveVolume writes the entire contents of the named volume to w, as a tar stream,
+// preserving file ownership and modes.
+// The stream can be restored into another volume with RestoreVolume.
+func ArchiveVolume(ctx context.Context, log *zap.Logger, cli *client.Client, testName, volumeName string, w io.Writer) error {
+	id, cleanup, err := volumeArchiveContainer(ctx, log, cli, testName, volumeName)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	rc, _, err := cli.CopyFromContainer(ctx, id, volumeArchiveMountDir+"/"+volumeArchiveRoot)
+	if err != nil {
+		return fmt.Errorf("copying from container: %w", err)
+	}
+	defer rc.Close()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("reading tar from container: %w", err)
+	}
+	return nil
+}
+
+// RestoreVolume extracts a tar stream written by ArchiveVolume into the named volume,
+// which is created by docker if it does not exist.
+func RestoreVolume(ctx context.Context, log *zap.Logger, cli *client.Client, testName, volumeName string, r io.Reader) error {
+	id, cleanup, err := volumeArchiveContainer(ctx, log, cli, testName, volumeName)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := cli.CopyToContainer(ctx, id, volumeArchiveMountDir, r, types.CopyToContainerOptions{
+		// Keep the ownership recorded in the archive,
+		// so that non-root node processes can still write to their home directories.
+		CopyUIDGID: true,
+	}); err != nil {
+		return fmt.Errorf("copying to container: %w", err)
+	}
+	return nil
+}
+
+// volumeArchiveContainer creates, but does not start, a busybox container mounting the named volume.
+// Docker can copy files from and to a container that is not running.
+func volumeArchiveContainer(ctx context.Context, log *zap.Logger, cli *client.Client, testName, volumeName string) (string, func(), error) {
+	if err := ensureBusybox(ctx, cli); err != nil {
+		return "", nil, err
+	}
+
+	containerName := fmt.Sprintf("interchaintest-volumearchive-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+	cc, err := cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			// Use root user to avoid permission issues when reading or writing files in the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: testName},
+		},
+		&container.HostConfig{
+			Binds: []string{volumeName + ":" + volumeArchiveMountDir + "/" + volumeArchiveRoot},
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating container: %w", err)
+	}
+
+	cleanup := func() {
+		if err := cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			log.Warn("Failed to remove volume archive container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}
+	return cc.ID, cleanup, nil
+}
@@ -0,0 +1,235 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// Docker runs the process in a container, and one-off commands in short-lived containers,
+// all mounting VolumeName at Home.
+//
+// Docker holds no state of its own beside Lifecycle,
+// so owners whose image or volume change over time may construct a new Docker for each call.
+type Docker struct {
+	Log       *zap.Logger
+	Client    *client.Client
+	NetworkID string
+	TestName  string
+
+	Image      ibc.DockerImage
+	VolumeName string
+	Home       string
+
+	// HostName is the host name of the container on the docker network.
+	HostName string
+	// Ports are published on random host ports.
+	Ports nat.PortSet
+
+	// Resources limits the process and the one-off commands.
+	Resources ibc.Resources
+
+	Lifecycle *dockerutil.ContainerLifecycle
+
+	// ExecContainer, if set, runs the one-off commands of Exec with docker exec instead of in new containers.
+	// Exec falls back to new containers if ExecContainer cannot run commands, e.g. because it stopped.
+	ExecContainer *dockerutil.Container
+}
+
+var _ Runner = (*Docker)(nil)
+
+func (d *Docker) HomeDir() string {
+	return d.Home
+}
+
+func (d *Docker) ListenAddress(port string) string {
+	return "0.0.0.0:" + nat.Port(port).Port()
+}
+
+func (d *Docker) Address(port string) string {
+	return d.HostName + ":" + nat.Port(port).Port()
+}
+
+func (d *Docker) binds() []string {
+	return []string{d.VolumeName + ":" + d.Home}
+}
+
+func (d *Docker) Create(ctx context.Context, cmd []string) error {
+	return d.Lifecycle.CreateContainer(ctx, d.TestName, d.NetworkID, d.Image, d.Ports, d.binds(), d.HostName, cmd, d.Resources)
+}
+
+func (d *Docker) Start(ctx context.Context) error {
+	return d.Lifecycle.StartContainer(ctx)
+}
+
+func (d *Docker) Pause(ctx context.Context) error {
+	return d.Lifecycle.PauseContainer(ctx)
+}
+
+func (d *Docker) Unpause(ctx context.Context) error {
+	return d.Lifecycle.UnpauseContainer(ctx)
+}
+
+func (d *Docker) Stop(ctx context.Context) error {
+	return d.Lifecycle.StopContainer(ctx)
+}
+
+func (d *Docker) Remove(ctx context.Context) error {
+	return d.Lifecycle.RemoveContainer(ctx)
+}
+
+func (d *Docker) Running(ctx context.Context) error {
+	return d.Lifecycle.Running(ctx)
+}
+
+func (d *Docker) ID() string {
+	return d.Lifecycle.ContainerID()
+}
+
+func (d *Docker) HostPorts(ctx context.Context, ports ...string) ([]string, error) {
+	return d.Lifecycle.GetHostPorts(ctx, ports...)
+}
+
+func (d *Docker) Exec(ctx context.Context, cmd []string, env []string) ExecResult {
+	res := ExecResult{Cmd: cmd, StartedAt: time.Now()}
+
+	if d.ExecContainer != nil {
+		r, err := d.ExecContainer.Exec(ctx, cmd, env)
+		if err == nil {
+			res.Name = d.ExecContainer.Name
+			res.Err, res.ExitCode, res.Stdout, res.Stderr = r.Err, r.ExitCode, r.Stdout, r.Stderr
+			res.FinishedAt = time.Now()
+			return res
+		}
+		d.Log.Info("Falling back to a one-off container", zap.String("exec_container", d.ExecContainer.Name), zap.Error(err))
+	}
+
+	job := dockerutil.NewImage(d.Log, d.Client, d.NetworkID, d.TestName, d.Image.Repository, d.Image.Version)
+	c, err := job.Start(ctx, cmd, dockerutil.ContainerOptions{
+		Env:       env,
+		Binds:     d.binds(),
+		Resources: d.Resources,
+	})
+	if err != nil {
+		res.Err, res.ExitCode = err, -1
+	} else {
+		res.Name = c.Name
+		r := c.Wait(ctx, 0)
+		res.Err, res.ExitCode, res.Stdout, res.Stderr = r.Err, r.ExitCode, r.Stdout, r.Stderr
+	}
+
+	res.FinishedAt = time.Now()
+	return res
+}
+
+func (d *Docker) Report(ctx context.Context) (ExecResult, error) {
+	var res ExecResult
+
+	stdoutBuf := new(bytes.Buffer)
+	stderrBuf := new(bytes.Buffer)
+	containerID := d.Lifecycle.ContainerID()
+	rc, err := d.Client.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(reportTailLines),
+	})
+	if err != nil {
+		return res, fmt.Errorf("retrieving ContainerLogs: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	if _, err := stdcopy.StdCopy(stdoutBuf, stderrBuf, rc); err != nil {
+		return res, fmt.Errorf("demuxing logs: %w", err)
+	}
+	res.Stdout, res.Stderr = stdoutBuf.Bytes(), stderrBuf.Bytes()
+
+	c, err := d.Client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return res, fmt.Errorf("inspecting container: %w", err)
+	}
+	res.Name, res.Cmd, res.ExitCode = c.Name, c.Args, c.State.ExitCode
+	res.Err = dockerutil.OOMKilledError(c)
+
+	res.StartedAt, err = time.Parse(time.RFC3339Nano, c.State.StartedAt)
+	if err != nil {
+		d.Log.Info("Failed to parse container StartedAt", zap.Error(err))
+		res.StartedAt = time.Unix(0, 0)
+	}
+
+	res.FinishedAt, err = time.Parse(time.RFC3339Nano, c.State.FinishedAt)
+	if err != nil {
+		d.Log.Info("Failed to parse container FinishedAt", zap.Error(err))
+		res.FinishedAt = time.Now().UTC()
+	}
+
+	return res, nil
+}
+
+func (d *Docker) WriteFile(ctx context.Context, relPath string, content []byte) error {
+	return d.WriteFiles(ctx, map[string][]byte{relPath: content})
+}
+
+func (d *Docker) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
+	contents, err := d.ReadFiles(ctx, relPath)
+	if err != nil {
+		return nil, err
+	}
+	return contents[0], nil
+}
+
+// WriteFiles copies files straight into the container of the process while it is running,
+// and otherwise into the volume through a one-off container.
+func (d *Docker) WriteFiles(ctx context.Context, files map[string][]byte) error {
+	fw := dockerutil.NewFileWriter(d.Log, d.Client, d.TestName)
+	if id := d.runningContainerID(ctx); id != "" {
+		return fw.WriteContainerFiles(ctx, id, d.Home, files)
+	}
+	return fw.WriteFiles(ctx, d.volumeFiles(files)...)
+}
+
+// ReadFiles copies relPaths straight from the container of the process while it is running,
+// and otherwise from the volume through a one-off container.
+func (d *Docker) ReadFiles(ctx context.Context, relPaths ...string) ([][]byte, error) {
+	fr := dockerutil.NewFileRetriever(d.Log, d.Client, d.TestName)
+	if id := d.runningContainerID(ctx); id != "" {
+		return fr.ContainerFilesContent(ctx, id, d.Home, relPaths...)
+	}
+	files := make([]dockerutil.VolumeFile, len(relPaths))
+	for i, relPath := range relPaths {
+		files[i] = dockerutil.VolumeFile{Volume: d.VolumeName, Path: relPath}
+	}
+	return fr.FilesContent(ctx, files...)
+}
+
+// runningContainerID returns the ID of the container of the process if it is running and not paused,
+// or an empty string otherwise.
+func (d *Docker) runningContainerID(ctx context.Context) string {
+	if d.Lifecycle == nil || d.Lifecycle.ContainerID() == "" {
+		return ""
+	}
+	c, err := d.Client.ContainerInspect(ctx, d.Lifecycle.ContainerID())
+	if err != nil || !c.State.Running || c.State.Paused {
+		return ""
+	}
+	return c.ID
+}
+
+func (d *Docker) volumeFiles(files map[string][]byte) []dockerutil.VolumeFile {
+	vfs := make([]dockerutil.VolumeFile, 0, len(files))
+	for relPath, content := range files {
+		vfs = append(vfs, dockerutil.VolumeFile{Volume: d.VolumeName, Path: relPath, Content: content})
+	}
+	return vfs
+}
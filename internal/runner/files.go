@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/docker/docker/client"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// File is a file at Path, relative to the home directory of Runner.
+type File struct {
+	Runner  Runner
+	Path    string
+	Content []byte
+}
+
+// WriteAll writes files into the home directories of their runners.
+// The files of Docker runners whose process is not running go through a single one-off container
+// per docker client, however many volumes they span; other runners write their files in one batch each.
+func WriteAll(ctx context.Context, files []File) error {
+	var b fileBatches
+	for i, f := range files {
+		b.add(ctx, i, f)
+	}
+
+	for _, v := range b.volumes {
+		fw := dockerutil.NewFileWriter(v.docker.Log, v.docker.Client, v.docker.TestName)
+		vfs := make([]dockerutil.VolumeFile, len(v.indices))
+		for j, i := range v.indices {
+			vfs[j] = v.files[j]
+			vfs[j].Content = files[i].Content
+		}
+		if err := fw.WriteFiles(ctx, vfs...); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range b.runners {
+		contents := make(map[string][]byte, len(r.indices))
+		for _, i := range r.indices {
+			contents[files[i].Path] = files[i].Content
+		}
+		if err := r.runner.WriteFiles(ctx, contents); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadAll returns the contents of files, in order, ignoring their Content fields.
+// It batches reads the same way as WriteAll.
+func ReadAll(ctx context.Context, files []File) ([][]byte, error) {
+	var b fileBatches
+	for i, f := range files {
+		b.add(ctx, i, f)
+	}
+
+	contents := make([][]byte, len(files))
+
+	for _, v := range b.volumes {
+		fr := dockerutil.NewFileRetriever(v.docker.Log, v.docker.Client, v.docker.TestName)
+		res, err := fr.FilesContent(ctx, v.files...)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range v.indices {
+			contents[i] = res[j]
+		}
+	}
+
+	for _, r := range b.runners {
+		relPaths := make([]string, len(r.indices))
+		for j, i := range r.indices {
+			relPaths[j] = files[i].Path
+		}
+		res, err := r.runner.ReadFiles(ctx, relPaths...)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range r.indices {
+			contents[i] = res[j]
+		}
+	}
+
+	return contents, nil
+}
+
+// fileBatches groups the indices of Files by how they are transferred.
+type fileBatches struct {
+	volumes []*volumeBatch
+	runners []*runnerBatch
+
+	// running caches whether the process of each Docker runner, by volume, is running.
+	running map[string]bool
+}
+
+// volumeBatch holds files in the volumes of stopped Docker runners sharing a docker client.
+type volumeBatch struct {
+	docker  *Docker
+	indices []int
+	files   []dockerutil.VolumeFile
+}
+
+// runnerBatch holds files of a single runner.
+type runnerBatch struct {
+	runner  Runner
+	key     any
+	indices []int
+}
+
+// runnerKey identifies the home directory of r.
+// Owners may construct a new Docker for each call, so Docker runners are identified by their volume.
+func runnerKey(r Runner) any {
+	if d, ok := r.(*Docker); ok {
+		return d.VolumeName
+	}
+	return r
+}
+
+func (b *fileBatches) add(ctx context.Context, i int, f File) {
+	if d, ok := f.Runner.(*Docker); ok && !b.isRunning(ctx, d) {
+		v := b.volumeBatch(d.Client)
+		if v == nil {
+			v = &volumeBatch{docker: d}
+			b.volumes = append(b.volumes, v)
+		}
+		v.indices = append(v.indices, i)
+		v.files = append(v.files, dockerutil.VolumeFile{Volume: d.VolumeName, Path: f.Path})
+		return
+	}
+
+	key := runnerKey(f.Runner)
+	for _, r := range b.runners {
+		if r.key == key {
+			r.indices = append(r.indices, i)
+			return
+		}
+	}
+	b.runners = append(b.runners, &runnerBatch{runner: f.Runner, key: key, indices: []int{i}})
+}
+
+func (b *fileBatches) isRunning(ctx context.Context, d *Docker) bool {
+	if b.running == nil {
+		b.running = make(map[string]bool)
+	}
+	running, ok := b.running[d.VolumeName]
+	if !ok {
+		running = d.runningContainerID(ctx) != ""
+		b.running[d.VolumeName] = running
+	}
+	return running
+}
+
+func (b *fileBatches) volumeBatch(cli *client.Client) *volumeBatch {
+	for _, v := range b.volumes {
+		if v.docker.Client == cli {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWriteAllReadAll(t *testing.T) {
+	ctx := context.Background()
+	a := newTestNative(t)
+	b, err := NewNative(zaptest.NewLogger(t), t.Name(), "other", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, WriteAll(ctx, []File{
+		{Runner: a, Path: "config/genesis.json", Content: []byte("a")},
+		{Runner: b, Path: "config/genesis.json", Content: []byte("b")},
+		{Runner: a, Path: "config/gentx/gentx-1.json", Content: []byte("gentx")},
+	}))
+
+	contents, err := ReadAll(ctx, []File{
+		{Runner: b, Path: "config/genesis.json"},
+		{Runner: a, Path: "config/gentx/gentx-1.json"},
+		{Runner: a, Path: "config/genesis.json"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("b"), []byte("gentx"), []byte("a")}, contents)
+
+	_, err = ReadAll(ctx, []File{{Runner: a, Path: "missing"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,483 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// NativeRoot is the directory under which each test gets a directory
+// holding the home directories and logs of its native processes.
+var NativeRoot = filepath.Join(os.TempDir(), "interchaintest-native")
+
+// NativeTestDir returns the directory of the native processes of the test with the given name.
+func NativeTestDir(testName string) string {
+	return filepath.Join(NativeRoot, dockerutil.SanitizeContainerName(testName))
+}
+
+const (
+	// nativeStopTimeout is how long Stop waits for the process to exit before killing it.
+	nativeStopTimeout = 30 * time.Second
+
+	// nativeOutputTail is the number of bytes of process output kept in memory for Report.
+	nativeOutputTail = 64 << 10
+)
+
+// Native runs local binaries as host processes.
+// Each Native has its own home directory under NativeTestDir,
+// and host ports allocated for the ports passed to NewNative.
+// The process output is appended to a log file beside the home directory.
+type Native struct {
+	log      *zap.Logger
+	testName string
+	name     string
+	home     string
+
+	// hostPorts maps ports to allocated host ports.
+	hostPorts map[string]string
+	// listeners reserve the allocated host ports until the process starts.
+	listeners dockerutil.Listeners
+
+	mu      sync.Mutex
+	cmd     []string
+	proc    *exec.Cmd
+	done    chan struct{}
+	waitErr error
+
+	stdout, stderr        *tailBuffer
+	startedAt, finishedAt time.Time
+}
+
+var _ Runner = (*Native)(nil)
+
+// NewNative returns a Native named name, with a fresh home directory.
+// A host port is allocated for each of ports, e.g. "26657/tcp".
+// Any other port is served on the same port number of the host.
+func NewNative(log *zap.Logger, testName, name string, ports []string) (*Native, error) {
+	n := &Native{
+		log:       log,
+		testName:  testName,
+		name:      name,
+		home:      filepath.Join(NativeTestDir(testName), name),
+		hostPorts: make(map[string]string, len(ports)),
+	}
+
+	// Remove leftovers from a previous run of the test.
+	if err := os.RemoveAll(n.home); err != nil {
+		return nil, fmt.Errorf("remove home directory: %w", err)
+	}
+	if err := os.MkdirAll(n.home, 0o755); err != nil {
+		return nil, fmt.Errorf("create home directory: %w", err)
+	}
+
+	portSet := make(nat.PortSet, len(ports))
+	for _, p := range ports {
+		portSet[nat.Port(p)] = struct{}{}
+	}
+	bindings, listeners, err := dockerutil.GeneratePortBindings(portSet)
+	if err != nil {
+		return nil, fmt.Errorf("allocate ports: %w", err)
+	}
+	for p, b := range bindings {
+		n.hostPorts[string(p)] = b[0].HostPort
+	}
+	n.listeners = listeners
+
+	registerNative(n)
+	return n, nil
+}
+
+func (n *Native) HomeDir() string {
+	return n.home
+}
+
+func (n *Native) hostPort(port string) string {
+	if hp, ok := n.hostPorts[port]; ok {
+		return hp
+	}
+	return nat.Port(port).Port()
+}
+
+func (n *Native) ListenAddress(port string) string {
+	return net.JoinHostPort("127.0.0.1", n.hostPort(port))
+}
+
+func (n *Native) Address(port string) string {
+	return n.ListenAddress(port)
+}
+
+func (n *Native) logFile() string {
+	return n.home + ".log"
+}
+
+func (n *Native) Create(ctx context.Context, cmd []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.proc != nil {
+		return fmt.Errorf("process %s already exists", n.name)
+	}
+	n.cmd = cmd
+	n.log.Info(
+		"Will run command",
+		zap.String("process", n.name),
+		zap.String("command", strings.Join(cmd, " ")),
+	)
+	return nil
+}
+
+func (n *Native) Start(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.cmd) == 0 {
+		return fmt.Errorf("process %s must be created before it is started", n.name)
+	}
+	if n.proc != nil {
+		select {
+		case <-n.done:
+			// Restarting a stopped process.
+		default:
+			return fmt.Errorf("process %s already started", n.name)
+		}
+	}
+
+	f, err := os.OpenFile(n.logFile(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	n.stdout, n.stderr = newTailBuffer(nativeOutputTail), newTailBuffer(nativeOutputTail)
+	proc := exec.Command(n.cmd[0], n.cmd[1:]...)
+	proc.Dir = n.home
+	proc.Stdout = &syncWriter{w: f, tail: n.stdout}
+	proc.Stderr = &syncWriter{w: f, tail: n.stderr}
+
+	// Free the reserved ports for the process to bind.
+	n.listeners.CloseAll()
+	n.listeners = nil
+
+	if err := proc.Start(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("start process %s: %w", n.name, err)
+	}
+
+	n.proc, n.done, n.waitErr = proc, make(chan struct{}), nil
+	n.startedAt, n.finishedAt = time.Now(), time.Time{}
+	go n.wait(proc, n.done, f)
+
+	n.log.Info("Process started", zap.String("process", n.name), zap.Int("pid", proc.Process.Pid))
+	return nil
+}
+
+func (n *Native) wait(proc *exec.Cmd, done chan struct{}, f *os.File) {
+	err := proc.Wait()
+	_ = f.Close()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.waitErr, n.finishedAt = err, time.Now()
+	close(done)
+}
+
+// running returns the process if it has been started and has not exited yet.
+func (n *Native) running() (*exec.Cmd, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.proc == nil {
+		return nil, fmt.Errorf("process %s is not running", n.name)
+	}
+	select {
+	case <-n.done:
+		return nil, fmt.Errorf("process %s exited: %v", n.name, n.waitErr)
+	default:
+		return n.proc, nil
+	}
+}
+
+func (n *Native) Pause(ctx context.Context) error {
+	proc, err := n.running()
+	if err != nil {
+		return err
+	}
+	return pauseProcess(proc.Process)
+}
+
+func (n *Native) Unpause(ctx context.Context) error {
+	proc, err := n.running()
+	if err != nil {
+		return err
+	}
+	return resumeProcess(proc.Process)
+}
+
+func (n *Native) Stop(ctx context.Context) error {
+	n.mu.Lock()
+	proc, done := n.proc, n.done
+	n.mu.Unlock()
+	if proc == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+
+	if err := terminateProcess(proc.Process); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("stop process %s: %w", n.name, err)
+	}
+
+	timer := time.NewTimer(nativeStopTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	n.log.Info("Killing process that did not stop in time", zap.String("process", n.name))
+	return n.kill()
+}
+
+// kill kills the process, if running, and waits for it to exit.
+func (n *Native) kill() error {
+	n.mu.Lock()
+	proc, done := n.proc, n.done
+	n.mu.Unlock()
+	if proc == nil {
+		return nil
+	}
+
+	if err := proc.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("kill process %s: %w", n.name, err)
+	}
+	<-done
+	return nil
+}
+
+func (n *Native) Remove(ctx context.Context) error {
+	if err := n.kill(); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.proc, n.done, n.cmd = nil, nil, nil
+	return nil
+}
+
+func (n *Native) Running(ctx context.Context) error {
+	_, err := n.running()
+	return err
+}
+
+func (n *Native) ID() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.proc == nil {
+		return ""
+	}
+	return strconv.Itoa(n.proc.Process.Pid)
+}
+
+func (n *Native) HostPorts(ctx context.Context, ports ...string) ([]string, error) {
+	hostPorts := make([]string, len(ports))
+	for i, p := range ports {
+		hostPorts[i] = n.Address(p)
+	}
+	return hostPorts, nil
+}
+
+func (n *Native) Exec(ctx context.Context, cmd []string, env []string) ExecResult {
+	res := ExecResult{Name: n.name, Cmd: cmd, StartedAt: time.Now()}
+	if len(cmd) == 0 {
+		res.FinishedAt = res.StartedAt
+		res.ExitCode = -1
+		res.Err = errors.New("empty command")
+		return res
+	}
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Dir = n.home
+	c.Env = append(os.Environ(), env...)
+	c.Stdout, c.Stderr = &stdout, &stderr
+
+	err := c.Run()
+	res.FinishedAt = time.Now()
+
+	res.Stdout, res.Stderr = stdout.Bytes(), stderr.Bytes()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+		res.Err = fmt.Errorf("exit code %d: %s %s", res.ExitCode, stdout.String(), stderr.String())
+	default:
+		res.ExitCode = -1
+		res.Err = fmt.Errorf("run %s: %w", cmd[0], err)
+	}
+	return res
+}
+
+func (n *Native) Report(ctx context.Context) (ExecResult, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.stdout == nil {
+		return ExecResult{}, fmt.Errorf("process %s was never started", n.name)
+	}
+
+	res := ExecResult{
+		Name:       n.name,
+		Cmd:        n.cmd,
+		Stdout:     n.stdout.Lines(reportTailLines),
+		Stderr:     n.stderr.Lines(reportTailLines),
+		StartedAt:  n.startedAt,
+		FinishedAt: n.finishedAt,
+	}
+	select {
+	case <-n.done:
+		res.ExitCode = n.proc.ProcessState.ExitCode()
+	default:
+		res.FinishedAt = time.Now()
+	}
+	return res, nil
+}
+
+func (n *Native) path(relPath string) string {
+	return filepath.Join(n.home, filepath.FromSlash(relPath))
+}
+
+func (n *Native) WriteFile(ctx context.Context, relPath string, content []byte) error {
+	p := n.path(relPath)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, content, 0o644)
+}
+
+func (n *Native) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
+	return os.ReadFile(n.path(relPath))
+}
+
+func (n *Native) WriteFiles(ctx context.Context, files map[string][]byte) error {
+	for relPath, content := range files {
+		if err := n.WriteFile(ctx, relPath, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Native) ReadFiles(ctx context.Context, relPaths ...string) ([][]byte, error) {
+	contents := make([][]byte, len(relPaths))
+	for i, relPath := range relPaths {
+		var err error
+		if contents[i], err = n.ReadFile(ctx, relPath); err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+var (
+	nativesMu sync.Mutex
+	natives   = make(map[string][]*Native)
+)
+
+func registerNative(n *Native) {
+	nativesMu.Lock()
+	defer nativesMu.Unlock()
+	natives[n.testName] = append(natives[n.testName], n)
+}
+
+// StopNative kills the remaining processes of the native runners of the test with the given name,
+// and releases their ports.
+func StopNative(testName string) error {
+	nativesMu.Lock()
+	ns := natives[testName]
+	delete(natives, testName)
+	nativesMu.Unlock()
+
+	var errs []string
+	for _, n := range ns {
+		n.mu.Lock()
+		n.listeners.CloseAll()
+		n.listeners = nil
+		n.mu.Unlock()
+
+		if err := n.kill(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// syncWriter writes process output to the log file and to the in-memory tail.
+type syncWriter struct {
+	w    *os.File
+	tail *tailBuffer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	_, _ = s.tail.Write(p)
+	return s.w.Write(p)
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+	max int
+}
+
+func newTailBuffer(max int) *tailBuffer {
+	return &tailBuffer{max: max}
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - b.max; over > 0 {
+		b.buf = append(b.buf[:0:0], b.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+// Lines returns a copy of the last n lines.
+func (b *tailBuffer) Lines(n int) []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	end := bytes.TrimSuffix(b.buf, []byte("\n"))
+	start := len(end)
+	for i := 0; i < n && start >= 0; i++ {
+		start = bytes.LastIndexByte(end[:start], '\n')
+	}
+	return append([]byte(nil), b.buf[start+1:]...)
+}
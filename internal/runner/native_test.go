@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"context"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestNative(t *testing.T, ports ...string) *Native {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test commands require a unix shell")
+	}
+
+	NativeRoot = t.TempDir()
+	n, err := NewNative(zaptest.NewLogger(t), t.Name(), "node", ports)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, StopNative(t.Name())) })
+	return n
+}
+
+func TestNative_ExecAndFiles(t *testing.T) {
+	ctx := context.Background()
+	n := newTestNative(t)
+
+	require.NoError(t, n.WriteFile(ctx, "config/app.toml", []byte("x = 1\n")))
+
+	res := n.Exec(ctx, []string{"sh", "-c", "cat config/app.toml; echo $FOO >&2; echo y > out"}, []string{"FOO=bar"})
+	require.NoError(t, res.Err)
+	require.Equal(t, "x = 1\n", string(res.Stdout))
+	require.Equal(t, "bar\n", string(res.Stderr))
+	require.Equal(t, "node", res.Name)
+
+	bz, err := n.ReadFile(ctx, "out")
+	require.NoError(t, err)
+	require.Equal(t, "y\n", string(bz))
+
+	res = n.Exec(ctx, []string{"sh", "-c", "echo oops; echo failed >&2; exit 3"}, nil)
+	require.EqualError(t, res.Err, "exit code 3: oops\n failed\n")
+	require.Equal(t, 3, res.ExitCode)
+	require.Equal(t, "oops\n", string(res.Stdout))
+	require.Equal(t, "failed\n", string(res.Stderr))
+
+	res = n.Exec(ctx, nil, nil)
+	require.EqualError(t, res.Err, "empty command")
+	require.Equal(t, -1, res.ExitCode)
+}
+
+func TestNative_Ports(t *testing.T) {
+	n := newTestNative(t, "26657/tcp")
+
+	addr := n.Address("26657/tcp")
+	require.Equal(t, addr, n.ListenAddress("26657/tcp"))
+	require.NotEqual(t, "127.0.0.1:26657", addr)
+	require.Equal(t, "127.0.0.1:9000", n.Address("9000/tcp"))
+
+	// The port is reserved until the process starts.
+	_, err := net.Listen("tcp", addr)
+	require.Error(t, err)
+}
+
+func TestNative_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	n := newTestNative(t)
+
+	require.Error(t, n.Running(ctx))
+	require.NoError(t, n.Create(ctx, []string{"sh", "-c", "echo started; exec sleep 60"}))
+	require.NoError(t, n.Start(ctx))
+	require.NoError(t, n.Running(ctx))
+	require.NotEmpty(t, n.ID())
+	waitForLog(t, n, 1)
+
+	require.NoError(t, n.Pause(ctx))
+	require.NoError(t, n.Unpause(ctx))
+
+	require.NoError(t, n.Stop(ctx))
+	require.ErrorContains(t, n.Running(ctx), "exited")
+
+	res, err := n.Report(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "started\n", string(res.Stdout))
+	require.Equal(t, -1, res.ExitCode) // Killed by a signal.
+
+	// Restarting a stopped process appends to the same log.
+	require.NoError(t, n.Start(ctx))
+	waitForLog(t, n, 2)
+	require.NoError(t, n.Remove(ctx))
+	require.Empty(t, n.ID())
+}
+
+// waitForLog waits until the process log holds count start messages.
+func waitForLog(t *testing.T, n *Native, count int) {
+	require.Eventually(t, func() bool {
+		bz, _ := os.ReadFile(n.logFile())
+		return strings.Count(string(bz), "started") == count
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := newTailBuffer(8)
+	require.Empty(t, b.Lines(2))
+
+	_, _ = b.Write([]byte("a\nb\nc\n"))
+	require.Equal(t, "b\nc\n", string(b.Lines(2)))
+	require.Equal(t, "a\nb\nc\n", string(b.Lines(5)))
+
+	_, _ = b.Write([]byte("dddd"))
+	require.Equal(t, "b\nc\ndddd", string(b.Lines(5)))
+	require.Equal(t, "dddd", string(b.Lines(1)))
+}
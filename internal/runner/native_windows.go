@@ -0,0 +1,20 @@
+package runner
+
+import (
+	"errors"
+	"os"
+)
+
+var errPauseUnsupported = errors.New("pausing native processes is not supported on windows")
+
+func pauseProcess(p *os.Process) error {
+	return errPauseUnsupported
+}
+
+func resumeProcess(p *os.Process) error {
+	return errPauseUnsupported
+}
+
+func terminateProcess(p *os.Process) error {
+	return p.Kill()
+}
@@ -0,0 +1,94 @@
+// Package runner runs the long-lived process of a chain node, sidecar or relayer,
+// and the one-off commands that operate on its home directory.
+//
+// Docker runs everything in containers sharing a docker volume.
+// Native runs local binaries as host processes, each in its own home directory on the host.
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// Runner is the runtime of a single long-lived process and its home directory.
+type Runner interface {
+	// HomeDir is the home directory, as seen by the process and one-off commands.
+	HomeDir() string
+
+	// ListenAddress is the host:port the process must listen on to serve port, e.g. "26657/tcp".
+	ListenAddress(port string) string
+
+	// Address is the host:port other processes of the test reach port on.
+	Address(port string) string
+
+	// Create prepares the process to run cmd. It does not start the process.
+	Create(ctx context.Context, cmd []string) error
+	Start(ctx context.Context) error
+	Pause(ctx context.Context) error
+	Unpause(ctx context.Context) error
+	Stop(ctx context.Context) error
+
+	// Remove removes the process, but not its home directory.
+	Remove(ctx context.Context) error
+
+	// Running returns nil if the process is running, otherwise an error describing why it is not.
+	Running(ctx context.Context) error
+
+	// ID identifies the created process, e.g. a container ID or a process ID.
+	// It is empty until the process is created.
+	ID() string
+
+	// HostPorts returns the host:port the test reaches each of ports on, once started.
+	HostPorts(ctx context.Context, ports ...string) ([]string, error)
+
+	// Exec runs cmd to completion beside the process, with access to the home directory.
+	// A non-zero exit code is reported as an error.
+	Exec(ctx context.Context, cmd []string, env []string) ExecResult
+
+	// Report returns the command, tail of the output and exit status of the stopped process.
+	// The Err of the result reports whether the process was killed for running out of memory.
+	Report(ctx context.Context) (ExecResult, error)
+
+	// WriteFile writes content to relPath in the home directory.
+	WriteFile(ctx context.Context, relPath string, content []byte) error
+
+	// ReadFile reads relPath in the home directory.
+	ReadFile(ctx context.Context, relPath string) ([]byte, error)
+
+	// WriteFiles writes files, keyed by their path relative to the home directory, in one batch.
+	WriteFiles(ctx context.Context, files map[string][]byte) error
+
+	// ReadFiles reads each of relPaths in the home directory in one batch, returning their contents in order.
+	ReadFiles(ctx context.Context, relPaths ...string) ([][]byte, error)
+}
+
+// ExecResult is the result of a command run by a Runner.
+type ExecResult struct {
+	// Name is the container or process that ran the command.
+	Name string
+	Cmd  []string
+
+	Err      error // Err is nil, unless the command could not run or exited with a non-zero code.
+	ExitCode int
+	// Stdout and Stderr are also set when the command exited with a non-zero code.
+	Stdout, Stderr []byte
+
+	StartedAt, FinishedAt time.Time
+}
+
+// TrackChainExec reports the command, run with the extra environment variables env, to rep.
+func (res ExecResult) TrackChainExec(rep ibc.ChainExecReporter, env []string) {
+	rep.TrackChainExec(
+		res.Name,
+		res.Cmd, env,
+		string(res.Stdout), string(res.Stderr),
+		res.ExitCode,
+		res.StartedAt, res.FinishedAt,
+		res.Err,
+	)
+}
+
+// reportTailLines is the number of lines of output Report returns.
+const reportTailLines = 50
@@ -0,0 +1,236 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"go.uber.org/zap"
+)
+
+// defaultPauseOnFailure is how long a failing test pauses
+// when IBCTEST_PAUSE_ON_FAILURE is set to something other than a duration.
+const defaultPauseOnFailure = time.Hour
+
+// pauseOnFailure is initialized from the IBCTEST_PAUSE_ON_FAILURE environment variable,
+// and set through PauseOnFailure.
+var pauseOnFailure = pauseOnFailureFromEnv()
+
+func pauseOnFailureFromEnv() time.Duration {
+	v := os.Getenv("IBCTEST_PAUSE_ON_FAILURE")
+	if v == "" {
+		return 0
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return d
+	}
+	return defaultPauseOnFailure
+}
+
+// PauseOnFailure sets how long Interchain.Build arranges for a failing test to pause before its docker cleanup,
+// keeping every container running so that the live network can be inspected.
+// While paused, the host addresses of every chain, the relayer containers, the relayer and test user wallets,
+// and a docker exec command for every container are printed to stderr.
+// The pause ends early on an interrupt or terminate signal, e.g. Ctrl-C.
+// A zero duration disables pausing.
+//
+// The value is zero by default, but can be initialized by setting the environment variable
+// IBCTEST_PAUSE_ON_FAILURE to a duration such as 30m, or to any other non-empty value to pause for an hour.
+// It can also be set per Interchain with InterchainBuildOptions.PauseOnFailure.
+//
+// The test binary must run with a -timeout longer than the pause, or it panics while paused.
+// The pause runs in a cleanup that Build registers with InterchainBuildOptions.T,
+// so cleanups registered before Build, such as the docker cleanup of DockerSetup, run after it.
+// Interchain.Close pauses first as well, so a cleanup closing the Interchain after Build runs after the pause.
+// Other cleanups registered after Build run before it, unless they call PauseBeforeCleanup first.
+// Without InterchainBuildOptions.T, the pause runs at the start of the docker cleanup instead.
+func PauseOnFailure(d time.Duration) {
+	pauseOnFailure = d
+}
+
+var (
+	pausesMu sync.Mutex
+	// pauses are the pauses arranged by Interchain.Build, by test.
+	pauses = make(map[testing.TB][]pause)
+)
+
+type pause struct {
+	ic   *Interchain
+	opts InterchainBuildOptions
+	d    time.Duration
+}
+
+// PauseBeforeCleanup pauses t if it failed and Interchain.Build arranged for it to pause on failure,
+// unless it already paused. See PauseOnFailure.
+//
+// Call it first in cleanups registered after Interchain.Build that remove what is worth inspecting,
+// such as a cleanup stopping the relayer, so that the resources are still running during the pause.
+func PauseBeforeCleanup(t testing.TB) {
+	pausesMu.Lock()
+	ps := pauses[t]
+	delete(pauses, t)
+	pausesMu.Unlock()
+
+	if len(ps) == 0 || !t.Failed() {
+		return
+	}
+
+	var d time.Duration
+	for _, p := range ps {
+		p.ic.log.Info("Pausing failed test before cleanup", zap.String("test_name", p.opts.TestName), zap.Duration("timeout", p.d))
+		p.ic.writePauseInfo(context.Background(), os.Stderr, p.opts, p.d)
+		if p.d > d {
+			d = p.d
+		}
+	}
+	waitForSignal(d)
+}
+
+// pauseOnFailure arranges for the test to pause for up to d if it fails,
+// in a cleanup of InterchainBuildOptions.T or else at the start of the docker cleanup.
+func (ic *Interchain) pauseOnFailure(opts InterchainBuildOptions, d time.Duration) {
+	if opts.Client == nil {
+		return
+	}
+
+	if t := opts.T; t != nil {
+		pausesMu.Lock()
+		pauses[t] = append(pauses[t], pause{ic: ic, opts: opts, d: d})
+		pausesMu.Unlock()
+
+		t.Cleanup(func() {
+			PauseBeforeCleanup(t)
+		})
+		return
+	}
+
+	if opts.NetworkID == "" {
+		return
+	}
+	dockerutil.BeforeDockerCleanup(opts.NetworkID, func(failed bool) {
+		if !failed {
+			return
+		}
+
+		ic.log.Info("Pausing failed test before docker cleanup", zap.String("test_name", opts.TestName), zap.Duration("timeout", d))
+		ic.writePauseInfo(context.Background(), os.Stderr, opts, d)
+		waitForSignal(d)
+	})
+}
+
+// writePauseInfo writes how to reach the chains, relayers and containers of the paused test to w.
+func (ic *Interchain) writePauseInfo(ctx context.Context, w io.Writer, opts InterchainBuildOptions, d time.Duration) {
+	fmt.Fprintf(w, "\nTest %s failed. Keeping its containers running for %s before cleaning up.\n", opts.TestName, d)
+	fmt.Fprintf(w, "Press Ctrl-C, or run kill %d, to clean up now.\n", os.Getpid())
+
+	chains := make([]string, 0, len(ic.chains))
+	byName := make(map[string]chainInfo, len(ic.chains))
+	for c, name := range ic.chains {
+		chains = append(chains, name)
+		info := chainInfo{
+			chainID: c.Config().ChainID,
+			rpc:     c.GetHostRPCAddress(),
+			grpc:    c.GetHostGRPCAddress(),
+		}
+		if a, ok := c.(interface{ GetHostAPIAddress() string }); ok {
+			info.api = a.GetHostAPIAddress()
+		}
+		for k, wallet := range ic.relayerWallets {
+			if k.C == c {
+				info.wallets = append(info.wallets, fmt.Sprintf("relayer %s: %s %s", ic.relayers[k.R], wallet.FormattedAddress(), wallet.Mnemonic()))
+			}
+		}
+		for _, wallet := range ic.chainTestUsers(c) {
+			info.wallets = append(info.wallets, fmt.Sprintf("user %s: %s %s", wallet.KeyName(), wallet.FormattedAddress(), wallet.Mnemonic()))
+		}
+		sort.Strings(info.wallets)
+		byName[name] = info
+	}
+	sort.Strings(chains)
+
+	fmt.Fprintln(w, "\nChains:")
+	for _, name := range chains {
+		info := byName[name]
+		fmt.Fprintf(w, "  %s (%s)\n", name, info.chainID)
+		fmt.Fprintf(w, "    RPC:  %s\n", info.rpc)
+		fmt.Fprintf(w, "    gRPC: %s\n", info.grpc)
+		if info.api != "" {
+			fmt.Fprintf(w, "    API:  %s\n", info.api)
+		}
+		for _, wallet := range info.wallets {
+			fmt.Fprintf(w, "    %s\n", wallet)
+		}
+	}
+
+	if len(ic.relayers) > 0 {
+		relayers := make([]string, 0, len(ic.relayers))
+		for r, name := range ic.relayers {
+			container := "not running"
+			if c, ok := r.(interface{ ContainerName() string }); ok && c.ContainerName() != "" {
+				container = "container " + c.ContainerName()
+			}
+			relayers = append(relayers, fmt.Sprintf("  %s (%s)", name, container))
+		}
+		sort.Strings(relayers)
+
+		fmt.Fprintln(w, "\nRelayers:")
+		fmt.Fprintln(w, strings.Join(relayers, "\n"))
+	}
+
+	filter := filters.Arg("label", dockerutil.CleanupLabel+"="+opts.TestName)
+	if opts.NetworkID != "" {
+		filter = filters.Arg("network", opts.NetworkID)
+	}
+	cs, err := opts.Client.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filter),
+	})
+	if err != nil {
+		fmt.Fprintf(w, "\nFailed to list containers: %v\n", err)
+		return
+	}
+	names := make([]string, 0, len(cs))
+	for _, c := range cs {
+		if len(c.Names) > 0 {
+			names = append(names, strings.TrimPrefix(c.Names[0], "/"))
+		}
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "\nContainers:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  docker exec -it %s sh\n", name)
+	}
+	fmt.Fprintln(w)
+}
+
+type chainInfo struct {
+	chainID        string
+	rpc, grpc, api string
+	wallets        []string
+}
+
+// waitForSignal blocks until the process receives an interrupt or terminate signal, or until d elapses.
+func waitForSignal(d time.Duration) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-sigCh:
+	case <-timer.C:
+	}
+}
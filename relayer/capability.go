@@ -17,6 +17,14 @@ const (
 
 	// Whether the relayer supports a one-off flush command.
 	Flush
+
+	// Whether the relayer implements ibc.ChannelCloser,
+	// performing the ChanCloseInit and ChanCloseConfirm steps.
+	ChannelClose
+
+	// Whether the relayer implements ibc.ChannelUpgrader,
+	// performing the ibc-go channel upgrade handshake.
+	ChannelUpgrade
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -29,5 +37,8 @@ func FullCapabilities() map[Capability]bool {
 		HeightTimeout:    true,
 
 		Flush: true,
+
+		ChannelClose:   true,
+		ChannelUpgrade: true,
 	}
 }
@@ -1,22 +1,22 @@
 package relayer
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/stdcopy"
 	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/runner"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 )
 
@@ -25,7 +25,7 @@ const (
 )
 
 // DockerRelayer provides a common base for relayer implementations
-// that run on Docker.
+// that run on Docker, or as host processes with the RelayerOptionNative option.
 type DockerRelayer struct {
 	log *zap.Logger
 
@@ -41,8 +41,24 @@ type DockerRelayer struct {
 	customImage *ibc.DockerImage
 	pullImage   bool
 
-	// The ID of the container created by StartRelayer.
-	containerLifecycle *dockerutil.ContainerLifecycle
+	// resources limits the containers of the relayer, if set by RelayerOptionResources.
+	resources ibc.Resources
+
+	// execContainer runs the one-off commands of the relayer,
+	// unless disabled with RelayerOptionExecContainer or it failed to start.
+	execMu             sync.Mutex
+	execContainer      *dockerutil.Container
+	reuseExecContainer bool
+	execContainerErr   error
+
+	// The relayer process created by StartRelayer,
+	// and the name of its container if it does not run natively.
+	process       runner.Runner
+	containerName string
+
+	// native runs the relayer on the host, if set by RelayerOptionNative.
+	native    *runner.Native
+	nativeBin string
 
 	// wallets contains a mapping of chainID to relayer wallet
 	wallets map[string]ibc.Wallet
@@ -65,6 +81,8 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		// pull true by default, can be overridden with options
 		pullImage: true,
 
+		reuseExecContainer: true,
+
 		testName: testName,
 
 		wallets: map[string]ibc.Wallet{},
@@ -80,21 +98,56 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 			r.pullImage = o.Pull
 		case RelayerOptionHomeDir:
 			r.homeDir = o.HomeDir
+		case RelayerOptionResources:
+			r.resources = o.Resources
+		case RelayerOptionExecContainer:
+			r.reuseExecContainer = o.Reuse
+		case RelayerOptionNative:
+			native, err := runner.NewNative(log, testName, r.Name(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("creating native runtime: %w", err)
+			}
+			r.native, r.nativeBin = native, o.Bin
+		}
+	}
+
+	if r.native == nil {
+		if err := r.createVolume(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if init := r.c.Init(r.HomeDir()); len(init) > 0 {
+		// Initialization should complete immediately,
+		// but add a 1-minute timeout in case Docker hangs on a developer workstation.
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		// Using a nop reporter here because it keeps the API simpler,
+		// and the init command is typically not of high interest.
+		res := r.Exec(ctx, ibc.NopRelayerExecReporter{}, init, nil)
+		if res.Err != nil {
+			return nil, res.Err
 		}
 	}
 
+	return &r, nil
+}
+
+// createVolume pulls the relayer image if necessary, and creates the volume holding the home directory.
+func (r *DockerRelayer) createVolume(ctx context.Context) error {
 	containerImage := r.containerImage()
 	if err := r.pullContainerImageIfNecessary(containerImage); err != nil {
-		return nil, fmt.Errorf("pulling container image %s: %w", containerImage.Ref(), err)
+		return fmt.Errorf("pulling container image %s: %w", containerImage.Ref(), err)
 	}
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+	v, err := r.client.VolumeCreate(ctx, volumetypes.CreateOptions{
 		// Have to leave Driver unspecified for Docker Desktop compatibility.
 
-		Labels: map[string]string{dockerutil.CleanupLabel: testName},
+		Labels: map[string]string{dockerutil.CleanupLabel: r.testName},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("creating volume: %w", err)
+		return fmt.Errorf("creating volume: %w", err)
 	}
 	r.volumeName = v.Name
 
@@ -109,34 +162,66 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 
 		VolumeName: r.volumeName,
 		ImageRef:   containerImage.Ref(),
-		TestName:   testName,
+		TestName:   r.testName,
 		UidGid:     containerImage.UidGid,
 	}); err != nil {
-		return nil, fmt.Errorf("set volume owner: %w", err)
+		return fmt.Errorf("set volume owner: %w", err)
 	}
+	return nil
+}
 
-	if init := r.c.Init(r.HomeDir()); len(init) > 0 {
-		// Initialization should complete immediately,
-		// but add a 1-minute timeout in case Docker hangs on a developer workstation.
-		ctx, cancel := context.WithTimeout(ctx, time.Minute)
-		defer cancel()
+// runner returns the runtime of the relayer commands and home directory.
+func (r *DockerRelayer) runner() runner.Runner {
+	if r.native != nil {
+		return r.native
+	}
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+	return &runner.Docker{
+		Log:        r.log,
+		Client:     r.client,
+		NetworkID:  r.networkID,
+		TestName:   r.testName,
+		Image:      r.containerImage(),
+		VolumeName: r.volumeName,
+		Home:       r.HomeDir(),
+		Resources:  r.resources,
 
-		// Using a nop reporter here because it keeps the API simpler,
-		// and the init command is typically not of high interest.
-		res := r.Exec(ctx, ibc.NopRelayerExecReporter{}, init, nil)
-		if res.Err != nil {
-			return nil, res.Err
-		}
+		ExecContainer: r.execContainer,
 	}
+}
 
-	return &r, nil
+// startExecContainer starts the idle container running the one-off commands of the relayer,
+// unless it is disabled, already started, or failed to start before.
+func (r *DockerRelayer) startExecContainer(ctx context.Context) {
+	if r.native != nil || !r.reuseExecContainer {
+		return
+	}
+
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+	if r.execContainer != nil || r.execContainerErr != nil {
+		return
+	}
+
+	image := r.containerImage()
+	c, err := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, image.Repository, image.Version).
+		StartIdle(ctx, dockerutil.ContainerOptions{
+			Binds:     r.Bind(),
+			Resources: r.resources,
+		})
+	if err != nil {
+		r.log.Info("Running relayer commands in one-off containers, because the exec container failed to start", zap.Error(err))
+		r.execContainerErr = err
+		return
+	}
+	r.execContainer = c
 }
 
 // WriteFileToHomeDir writes the given contents to a file at the relative path specified. The file is relative
 // to the home directory in the relayer container.
 func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath string, contents []byte) error {
-	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
-	if err := fw.WriteFile(ctx, r.volumeName, relativePath, contents); err != nil {
+	if err := r.runner().WriteFile(ctx, relativePath, contents); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 	return nil
@@ -145,8 +230,7 @@ func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath str
 // ReadFileFromHomeDir reads a file at the relative path specified and returns the contents. The file is
 // relative to the home directory in the relayer container.
 func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
-	bytes, err := fr.SingleFileContent(ctx, r.volumeName, relativePath)
+	bytes, err := r.runner().ReadFile(ctx, relativePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve %s: %w", relativePath, err)
 	}
@@ -155,7 +239,17 @@ func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath st
 
 // Modify a toml config file in relayer home directory
 func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath string, modification testutil.Toml) error {
-	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
+	config, err := r.ReadFileFromHomeDir(ctx, relativePath)
+	if err != nil {
+		return err
+	}
+
+	config, err = testutil.ModifyToml(config, modification)
+	if err != nil {
+		return fmt.Errorf("failed to modify %s: %w", relativePath, err)
+	}
+
+	return r.WriteFileToHomeDir(ctx, relativePath, config)
 }
 
 // AddWallet adds a stores a wallet for the given chain ID.
@@ -175,8 +269,7 @@ func (r *DockerRelayer) AddChainConfiguration(ctx context.Context, rep ibc.Relay
 		return fmt.Errorf("failed to generate config content: %w", err)
 	}
 
-	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
-	if err := fw.WriteFile(ctx, r.volumeName, chainConfigFile, configContent); err != nil {
+	if err := r.runner().WriteFile(ctx, chainConfigFile, configContent); err != nil {
 		return fmt.Errorf("failed to rly config: %w", err)
 	}
 
@@ -295,14 +388,9 @@ func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporte
 }
 
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
-	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.containerImage().Repository, r.containerImage().Version)
-	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: r.Bind(),
-	}
-
-	startedAt := time.Now()
-	res := job.Run(ctx, cmd, opts)
+	cmd = r.nativeCommand(cmd)
+	r.startExecContainer(ctx)
+	res := r.runner().Exec(ctx, cmd, env)
 
 	defer func() {
 		rep.TrackRelayerExec(
@@ -310,7 +398,7 @@ func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, c
 			cmd,
 			string(res.Stdout), string(res.Stderr),
 			res.ExitCode,
-			startedAt, time.Now(),
+			res.StartedAt, res.FinishedAt,
 			res.Err,
 		)
 	}()
@@ -351,113 +439,129 @@ func (r *DockerRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecRe
 }
 
 func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
-	if r.containerLifecycle != nil {
+	if r.process != nil {
 		return fmt.Errorf("tried to start relayer again without stopping first")
 	}
 
-	containerImage := r.containerImage()
 	joinedPaths := strings.Join(pathNames, ".")
-	containerName := fmt.Sprintf("%s-%s-%s", r.c.Name(), joinedPaths, dockerutil.RandLowerCaseLetterString(5))
-
-	cmd := r.c.StartRelayer(r.HomeDir(), pathNames...)
+	cmd := r.nativeCommand(r.c.StartRelayer(r.HomeDir(), pathNames...))
 
-	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.client, containerName)
+	if r.native != nil {
+		r.process = r.native
+	} else {
+		containerName := fmt.Sprintf("%s-%s-%s", r.c.Name(), joinedPaths, dockerutil.RandLowerCaseLetterString(5))
+		d := r.runner().(*runner.Docker)
+		d.HostName = r.HostName(joinedPaths)
+		d.Lifecycle = dockerutil.NewContainerLifecycle(r.log, r.client, containerName)
+		r.process = d
+		r.containerName = containerName
+	}
 
-	if err := r.containerLifecycle.CreateContainer(
-		ctx, r.testName, r.networkID, containerImage, nil,
-		r.Bind(), r.HostName(joinedPaths), cmd,
-	); err != nil {
+	if err := r.process.Create(ctx, cmd); err != nil {
 		return err
 	}
 
-	return r.containerLifecycle.StartContainer(ctx)
+	return r.process.Start(ctx)
+}
+
+// ContainerName returns the name of the container started by StartRelayer,
+// or an empty string if the relayer is not running in a container.
+func (r *DockerRelayer) ContainerName() string {
+	return r.containerName
 }
 
+// StopRelayer stops the relayer started by StartRelayer, if any,
+// and removes the container running the one-off commands of the relayer.
+// Later commands start a new one.
 func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
-	if r.containerLifecycle == nil {
-		return nil
-	}
-	if err := r.containerLifecycle.StopContainer(ctx); err != nil {
+	if err := r.stopProcess(ctx, rep); err != nil {
 		return err
 	}
+	return r.stopExecContainer()
+}
 
-	stdoutBuf := new(bytes.Buffer)
-	stderrBuf := new(bytes.Buffer)
-	containerID := r.containerLifecycle.ContainerID()
-	rc, err := r.client.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-		Tail:       "50",
-	})
-	if err != nil {
-		return fmt.Errorf("StopRelayer: retrieving ContainerLogs: %w", err)
-	}
-	defer func() { _ = rc.Close() }()
+// Close removes the container running the one-off commands of the relayer.
+// It does not stop the relayer started by StartRelayer, which is stopped with StopRelayer.
+func (r *DockerRelayer) Close() error {
+	return r.stopExecContainer()
+}
 
-	// Logs are multiplexed into one stream; see docs for ContainerLogs.
-	_, err = stdcopy.StdCopy(stdoutBuf, stderrBuf, rc)
-	if err != nil {
-		return fmt.Errorf("StopRelayer: demuxing logs: %w", err)
+// stopExecContainer stops and removes the exec container, if it is running.
+func (r *DockerRelayer) stopExecContainer() error {
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+	c := r.execContainer
+	if c == nil {
+		return nil
 	}
-	_ = rc.Close()
-
-	stdout := stdoutBuf.String()
-	stderr := stderrBuf.String()
-
-	c, err := r.client.ContainerInspect(ctx, containerID)
-	if err != nil {
-		return fmt.Errorf("StopRelayer: inspecting container: %w", err)
+	r.execContainer = nil
+	if err := c.Stop(10 * time.Second); err != nil {
+		return fmt.Errorf("failed to remove exec container: %w", err)
 	}
+	return nil
+}
 
-	startedAt, err := time.Parse(time.RFC3339Nano, c.State.StartedAt)
-	if err != nil {
-		r.log.Info("Failed to parse container StartedAt", zap.Error(err))
-		startedAt = time.Unix(0, 0)
+func (r *DockerRelayer) stopProcess(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if r.process == nil {
+		return nil
+	}
+	if err := r.process.Stop(ctx); err != nil {
+		return err
 	}
 
-	finishedAt, err := time.Parse(time.RFC3339Nano, c.State.FinishedAt)
+	res, err := r.process.Report(ctx)
 	if err != nil {
-		r.log.Info("Failed to parse container FinishedAt", zap.Error(err))
-		finishedAt = time.Now().UTC()
+		return fmt.Errorf("StopRelayer: %w", err)
 	}
+	stdout := string(res.Stdout)
+	stderr := string(res.Stderr)
 
 	rep.TrackRelayerExec(
-		c.Name,
-		c.Args,
+		res.Name,
+		res.Cmd,
 		stdout, stderr,
-		c.State.ExitCode,
-		startedAt,
-		finishedAt,
-		nil,
+		res.ExitCode,
+		res.StartedAt,
+		res.FinishedAt,
+		res.Err,
 	)
 
 	r.log.Debug(
-		fmt.Sprintf("Stopped docker container\nstdout:\n%s\nstderr:\n%s", stdout, stderr),
-		zap.String("container_id", containerID),
-		zap.String("container", c.Name),
+		fmt.Sprintf("Stopped relayer\nstdout:\n%s\nstderr:\n%s", stdout, stderr),
+		zap.String("id", r.process.ID()),
+		zap.String("name", res.Name),
 	)
 
-	if err := r.containerLifecycle.RemoveContainer(ctx); err != nil {
+	if err := r.process.Remove(ctx); err != nil {
 		return err
 	}
 
-	r.containerLifecycle = nil
+	r.process = nil
+	r.containerName = ""
 
 	return nil
 }
 
 func (r *DockerRelayer) PauseRelayer(ctx context.Context) error {
-	if r.containerLifecycle == nil {
+	if r.process == nil {
 		return fmt.Errorf("container not running")
 	}
-	return r.client.ContainerPause(ctx, r.containerLifecycle.ContainerID())
+	return r.process.Pause(ctx)
 }
 
 func (r *DockerRelayer) ResumeRelayer(ctx context.Context) error {
-	if r.containerLifecycle == nil {
+	if r.process == nil {
 		return fmt.Errorf("container not running")
 	}
-	return r.client.ContainerUnpause(ctx, r.containerLifecycle.ContainerID())
+	return r.process.Unpause(ctx)
+}
+
+// nativeCommand replaces the relayer binary of cmd with the binary of RelayerOptionNative, if any.
+func (r *DockerRelayer) nativeCommand(cmd []string) []string {
+	if r.nativeBin == "" || len(cmd) == 0 {
+		return cmd
+	}
+	return append([]string{r.nativeBin}, cmd[1:]...)
 }
 
 func (r *DockerRelayer) containerImage() ibc.DockerImage {
@@ -495,8 +599,12 @@ func (r *DockerRelayer) Bind() []string {
 	return []string{r.volumeName + ":" + r.HomeDir()}
 }
 
-// HomeDir returns the home directory of the relayer on the underlying Docker container's filesystem.
+// HomeDir returns the home directory of the relayer on the underlying Docker container's filesystem,
+// or on the host filesystem if the relayer runs natively.
 func (r *DockerRelayer) HomeDir() string {
+	if r.native != nil {
+		return r.native.HomeDir()
+	}
 	return r.homeDir
 }
 
@@ -505,7 +613,7 @@ func (r *DockerRelayer) HostName(pathName string) string {
 }
 
 func (r *DockerRelayer) UseDockerNetwork() bool {
-	return true
+	return r.native == nil
 }
 
 func (r *DockerRelayer) SetClientContractHash(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, hash string) error {
@@ -20,6 +20,18 @@ type commander struct {
 	extraStartFlags []string
 }
 
+// newCommander returns the commander of hermes, applying the options it handles.
+func newCommander(log *zap.Logger, options relayer.RelayerOptions) relayer.RelayerCommander {
+	c := commander{log: log}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		}
+	}
+	return c
+}
+
 func (c commander) Name() string {
 	return hermes
 }
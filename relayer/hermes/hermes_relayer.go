@@ -13,6 +13,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
 )
 
 const (
@@ -26,7 +27,10 @@ const (
 )
 
 var (
-	_ ibc.Relayer = &Relayer{}
+	_ ibc.Relayer             = &Relayer{}
+	_ ibc.ChannelCloser       = &Relayer{}
+	_ ibc.ChannelUpgrader     = &Relayer{}
+	_ ibc.ClientPathGenerator = &Relayer{}
 	// parseRestoreKeyOutputPattern extracts the address from the hermes output.
 	// SUCCESS Restored key 'g2-2' (cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e) on chain g2-2
 	parseRestoreKeyOutputPattern = regexp.MustCompile(`\((.*)\)`)
@@ -59,15 +63,19 @@ type pathChainConfig struct {
 	portID       string
 }
 
+func init() {
+	relayer.Register(hermes, relayer.Implementation{
+		Commander: newCommander,
+		New: func(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) (ibc.Relayer, error) {
+			return NewHermesRelayer(log, testName, cli, networkID, options...), nil
+		},
+		Capabilities: Capabilities,
+	})
+}
+
 // NewHermesRelayer returns a new hermes relayer.
 func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *Relayer {
-	c := commander{log: log}
-	for _, opt := range options {
-		switch o := opt.(type) {
-		case relayer.RelayerOptionExtraStartFlags:
-			c.extraStartFlags = o.Flags
-		}
-	}
+	c := newCommander(log, options)
 	options = append(options, relayer.HomeDir(hermesHome))
 	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
 	if err != nil {
@@ -79,6 +87,23 @@ func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 	}
 }
 
+// Capabilities returns the set of capabilities of the given version of hermes.
+//
+// Flush uses the clear packets command and ChannelClose the chan-close-init and chan-close-confirm transactions,
+// both available in DefaultContainerVersion.
+// ChannelUpgrade requires the chan-upgrade transactions of hermes v1.8.0,
+// so it is not supported by DefaultContainerVersion, nor by versions that are not semantic versions, such as "main".
+func Capabilities(version string) map[relayer.Capability]bool {
+	caps := relayer.FullCapabilities()
+
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	caps[relayer.ChannelUpgrade] = semver.IsValid(version) && semver.Compare(version, "v1.8.0") >= 0
+
+	return caps
+}
+
 // AddChainConfiguration is called once per chain configuration, which means that in the case of hermes, the single
 // config file is overwritten with a new entry each time this function is called.
 func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
@@ -235,6 +260,114 @@ func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathNa
 	return res.Err
 }
 
+// CloseChannel closes the channel on chain A of the path, and its counterparty on chain B.
+// Hermes has no single command for the full handshake, so chan-close-init is sent to chain A
+// and chan-close-confirm is sent to chain B.
+func (r *Relayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID, portID string) error {
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	counterparty, err := r.channelCounterparty(ctx, rep, pathConfig.chainA.chainID, channelID, portID)
+	if err != nil {
+		return err
+	}
+
+	closeInitCmd := []string{hermes, "--json", "tx", "chan-close-init",
+		"--dst-chain", pathConfig.chainA.chainID,
+		"--src-chain", pathConfig.chainB.chainID,
+		"--dst-connection", pathConfig.chainA.connectionID,
+		"--dst-port", portID,
+		"--src-port", counterparty.PortID,
+		"--dst-channel", channelID,
+		"--src-channel", counterparty.ChannelID,
+	}
+	res := r.Exec(ctx, rep, closeInitCmd, nil)
+	if res.Err != nil {
+		return res.Err
+	}
+
+	closeConfirmCmd := []string{hermes, "--json", "tx", "chan-close-confirm",
+		"--dst-chain", pathConfig.chainB.chainID,
+		"--src-chain", pathConfig.chainA.chainID,
+		"--dst-connection", pathConfig.chainB.connectionID,
+		"--dst-port", counterparty.PortID,
+		"--src-port", portID,
+		"--dst-channel", counterparty.ChannelID,
+		"--src-channel", channelID,
+	}
+	return r.Exec(ctx, rep, closeConfirmCmd, nil).Err
+}
+
+// UpgradeChannel relays the upgrade handshake of the channel on chain A of the path,
+// whose upgrade must already be initiated on chain A.
+// Hermes has no single command for the handshake, so chan-upgrade-try is sent to chain B, chan-upgrade-ack to chain A,
+// chan-upgrade-confirm to chain B and chan-upgrade-open to chain A.
+// The chan-upgrade commands are available from hermes v1.8.0.
+func (r *Relayer) UpgradeChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID, portID string, opts ibc.ChannelUpgradeOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	counterparty, err := r.channelCounterparty(ctx, rep, pathConfig.chainA.chainID, channelID, portID)
+	if err != nil {
+		return err
+	}
+
+	a := channelEnd{chainID: pathConfig.chainA.chainID, connectionID: pathConfig.chainA.connectionID, portID: portID, channelID: channelID}
+	b := channelEnd{chainID: pathConfig.chainB.chainID, connectionID: pathConfig.chainB.connectionID, portID: counterparty.PortID, channelID: counterparty.ChannelID}
+
+	steps := []struct {
+		cmd      string
+		dst, src channelEnd
+	}{
+		{"chan-upgrade-try", b, a},
+		{"chan-upgrade-ack", a, b},
+		{"chan-upgrade-confirm", b, a},
+		{"chan-upgrade-open", a, b},
+	}
+	for _, step := range steps {
+		cmd := []string{hermes, "--json", "tx", step.cmd,
+			"--dst-chain", step.dst.chainID,
+			"--src-chain", step.src.chainID,
+			"--dst-connection", step.dst.connectionID,
+			"--dst-port", step.dst.portID,
+			"--src-port", step.src.portID,
+			"--dst-channel", step.dst.channelID,
+			"--src-channel", step.src.channelID,
+		}
+		if res := r.Exec(ctx, rep, cmd, nil); res.Err != nil {
+			return fmt.Errorf("%s: %w", step.cmd, res.Err)
+		}
+	}
+	return nil
+}
+
+// channelEnd identifies one end of a channel for the hermes channel handshake commands.
+type channelEnd struct {
+	chainID, connectionID, portID, channelID string
+}
+
+// channelCounterparty returns the counterparty of the channel with channelID and portID on chainID.
+func (r *Relayer) channelCounterparty(ctx context.Context, rep ibc.RelayerExecReporter, chainID, channelID, portID string) (ibc.ChannelCounterparty, error) {
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return ibc.ChannelCounterparty{}, err
+	}
+	for _, c := range channels {
+		if c.ChannelID == channelID && c.PortID == portID {
+			return c.Counterparty, nil
+		}
+	}
+	return ibc.ChannelCounterparty{}, fmt.Errorf("channel %s on port %s not found on chain %s", channelID, portID, chainID)
+}
+
 // GeneratePath establishes an in memory path representation. The concept does not exist in hermes, so it is handled
 // at the interchain test level.
 func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
@@ -252,6 +385,19 @@ func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter,
 	return nil
 }
 
+// GeneratePathWithClients generates a new path whose ends use existing clients.
+// Hermes creates a new connection on every call to CreateConnections,
+// so no further configuration is needed to reuse the clients.
+func (r *Relayer) GeneratePathWithClients(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, srcClientID, dstClientID, pathName string) error {
+	if err := r.GeneratePath(ctx, rep, srcChainID, dstChainID, pathName); err != nil {
+		return err
+	}
+	pathConfig := r.paths[pathName]
+	pathConfig.chainA.clientID = srcClientID
+	pathConfig.chainB.clientID = dstClientID
+	return nil
+}
+
 // configContent returns the contents of the hermes config file as a byte array. Note: as hermes expects a single file
 // rather than multiple config files, we need to maintain a list of chain configs each time they are added to write the
 // full correct file update calling Relayer.AddChainConfiguration.
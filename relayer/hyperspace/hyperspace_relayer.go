@@ -29,15 +29,18 @@ type HyperspaceRelayer struct {
 	*relayer.DockerRelayer
 }
 
+func init() {
+	relayer.Register("hyperspace", relayer.Implementation{
+		Commander: newCommander,
+		New: func(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) (ibc.Relayer, error) {
+			return NewHyperspaceRelayer(log, testName, cli, networkID, options...), nil
+		},
+		Capabilities: HyperspaceCapabilities,
+	})
+}
+
 func NewHyperspaceRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *HyperspaceRelayer {
-	c := hyperspaceCommander{log: log}
-	for _, opt := range options {
-		switch o := opt.(type) {
-		case relayer.RelayerOptionExtraStartFlags:
-			c.extraStartFlags = o.Flags
-		}
-	}
-	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, &c, options...)
+	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, newCommander(log, options), options...)
 	if err != nil {
 		panic(err) // TODO: return
 	}
@@ -61,11 +64,21 @@ func NewHyperspaceRelayer(log *zap.Logger, testName string, cli *client.Client,
 	return r
 }
 
-// HyperspaceCapabilities returns the set of capabilities of the Cosmos relayer.
-//
-// Note, this API may change if the rly package eventually needs
-// to distinguish between multiple rly versions.
-func HyperspaceCapabilities() map[relayer.Capability]bool {
+// newCommander returns the commander of hyperspace, applying the options it handles.
+func newCommander(log *zap.Logger, options relayer.RelayerOptions) relayer.RelayerCommander {
+	c := hyperspaceCommander{log: log}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		}
+	}
+	return &c
+}
+
+// HyperspaceCapabilities returns the set of capabilities of the hyperspace relayer.
+// None of them are reported yet, so the conformance tests that require them are skipped.
+func HyperspaceCapabilities(string) map[relayer.Capability]bool {
 	// RC1 matches the full set of capabilities as of writing.
 	return nil // relayer.FullCapabilities()
 }
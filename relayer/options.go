@@ -66,3 +66,47 @@ func StartupFlags(flags ...string) RelayerOption {
 }
 
 func (opt RelayerOptionExtraStartFlags) relayerOption() {}
+
+// RelayerOptionResources limits the resources of every container of the relayer.
+type RelayerOptionResources struct {
+	Resources ibc.Resources
+}
+
+// ContainerResources limits the CPU, memory, processes and block I/O of every container of the relayer.
+func ContainerResources(r ibc.Resources) RelayerOption {
+	return RelayerOptionResources{Resources: r}
+}
+
+func (opt RelayerOptionResources) relayerOption() {}
+
+// RelayerOptionNative runs the relayer as a host process instead of in docker containers.
+type RelayerOptionNative struct {
+	// Bin replaces the relayer binary of each command, e.g. with the path to a locally built relayer.
+	// If empty, the binary is looked up in PATH.
+	Bin string
+}
+
+// NativeRuntime runs the relayer as a host process of the binary bin, in its own home directory.
+// The relayer reaches the chains on their host addresses,
+// so it works with chains of either runtime, as long as their ports are published on the host.
+func NativeRuntime(bin string) RelayerOption {
+	return RelayerOptionNative{Bin: bin}
+}
+
+func (opt RelayerOptionNative) relayerOption() {}
+
+// RelayerOptionExecContainer configures how the relayer runs its one-off commands,
+// such as adding keys, linking paths and querying channels.
+type RelayerOptionExecContainer struct {
+	Reuse bool
+}
+
+// ReuseExecContainer configures whether the one-off commands of the relayer run with docker exec
+// in a single idle container, which is the default, instead of in a new container each.
+// Commands run in new containers regardless if the idle container cannot be started,
+// e.g. because the relayer image lacks sh or sleep.
+func ReuseExecContainer(reuse bool) RelayerOption {
+	return RelayerOptionExecContainer{Reuse: reuse}
+}
+
+func (opt RelayerOptionExecContainer) relayerOption() {}
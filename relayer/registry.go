@@ -0,0 +1,87 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"go.uber.org/zap"
+)
+
+// Implementation describes a relayer implementation that the relayer factory can build by name.
+// Relayer packages register their Implementation with Register, typically from an init function.
+type Implementation struct {
+	// Commander returns the RelayerCommander of the relayer,
+	// with the options it handles applied, e.g. RelayerOptionExtraStartFlags.
+	Commander func(log *zap.Logger, options RelayerOptions) RelayerCommander
+
+	// New builds the relayer, e.g. to wrap the DockerRelayer with methods of the implementation.
+	// If nil, a DockerRelayer running the Commander is built.
+	New func(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...RelayerOption) (ibc.Relayer, error)
+
+	// Capabilities returns the features supported by the given version of the relayer,
+	// as returned by Version. Tests of unsupported features are skipped.
+	Capabilities func(version string) map[Capability]bool
+}
+
+var (
+	implementationsMu sync.RWMutex
+	implementations   = map[string]Implementation{}
+)
+
+// Register registers impl under name, replacing any previous registration of name.
+// The built-in relayers are registered as "rly", "hermes" and "hyperspace".
+func Register(name string, impl Implementation) {
+	if impl.Commander == nil {
+		panic(fmt.Errorf("relayer %s registered without Commander", name))
+	}
+	if impl.Capabilities == nil {
+		panic(fmt.Errorf("relayer %s registered without Capabilities", name))
+	}
+	implementationsMu.Lock()
+	defer implementationsMu.Unlock()
+	implementations[name] = impl
+}
+
+// Lookup returns the Implementation registered under name.
+func Lookup(name string) (Implementation, bool) {
+	implementationsMu.RLock()
+	defer implementationsMu.RUnlock()
+	impl, ok := implementations[name]
+	return impl, ok
+}
+
+// Registered returns the sorted names of the registered relayers.
+func Registered() []string {
+	implementationsMu.RLock()
+	defer implementationsMu.RUnlock()
+	names := make([]string, 0, len(implementations))
+	for name := range implementations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build returns a relayer of the implementation.
+func (impl Implementation) Build(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...RelayerOption) (ibc.Relayer, error) {
+	if impl.New != nil {
+		return impl.New(log, testName, cli, networkID, options...)
+	}
+	return NewDockerRelayer(context.TODO(), log, testName, cli, networkID, impl.Commander(log, options), options...)
+}
+
+// Version returns the version of the docker image of the relayer, set by RelayerOptionDockerImage
+// or else the default version of the Commander.
+func (impl Implementation) Version(log *zap.Logger, options RelayerOptions) string {
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case RelayerOptionDockerImage:
+			return o.DockerImage.Version
+		}
+	}
+	return impl.Commander(log, options).DefaultContainerVersion()
+}
@@ -24,15 +24,23 @@ type CosmosRelayer struct {
 	*relayer.DockerRelayer
 }
 
+var (
+	_ ibc.ChannelCloser       = (*CosmosRelayer)(nil)
+	_ ibc.ClientPathGenerator = (*CosmosRelayer)(nil)
+)
+
+func init() {
+	relayer.Register("rly", relayer.Implementation{
+		Commander: newCommander,
+		New: func(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) (ibc.Relayer, error) {
+			return NewCosmosRelayer(log, testName, cli, networkID, options...), nil
+		},
+		Capabilities: Capabilities,
+	})
+}
+
 func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *CosmosRelayer {
-	c := commander{log: log}
-	for _, opt := range options {
-		switch o := opt.(type) {
-		case relayer.RelayerOptionExtraStartFlags:
-			c.extraStartFlags = o.Flags
-		}
-	}
-	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
+	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, newCommander(log, options), options...)
 	if err != nil {
 		panic(err) // TODO: return
 	}
@@ -70,12 +78,41 @@ const (
 )
 
 // Capabilities returns the set of capabilities of the Cosmos relayer.
-//
-// Note, this API may change if the rly package eventually needs
-// to distinguish between multiple rly versions.
-func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+// They do not depend on the version of rly yet.
+func Capabilities(string) map[relayer.Capability]bool {
+	caps := relayer.FullCapabilities()
+
+	// The channel upgrade handshake is not yet supported by rly.
+	caps[relayer.ChannelUpgrade] = false
+
+	return caps
+}
+
+// CloseChannel closes the channel with the rly channel-close command,
+// which sends ChanCloseInit to the source chain and ChanCloseConfirm to the destination chain.
+func (r *CosmosRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID, portID string) error {
+	cmd := []string{
+		"rly", "tx", "channel-close", pathName, channelID, portID,
+		"--home", r.HomeDir(),
+	}
+	res := r.Exec(ctx, rep, cmd, nil)
+	return res.Err
+}
+
+// GeneratePathWithClients generates a new path and points both of its ends at existing clients,
+// so that the next connection handshake on the path reuses them.
+func (r *CosmosRelayer) GeneratePathWithClients(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, srcClientID, dstClientID, pathName string) error {
+	if err := r.GeneratePath(ctx, rep, srcChainID, dstChainID, pathName); err != nil {
+		return err
+	}
+	cmd := []string{
+		"rly", "paths", "update", pathName,
+		"--src-client-id", srcClientID,
+		"--dst-client-id", dstClientID,
+		"--home", r.HomeDir(),
+	}
+	res := r.Exec(ctx, rep, cmd, nil)
+	return res.Err
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
@@ -108,6 +145,18 @@ type commander struct {
 	extraStartFlags []string
 }
 
+// newCommander returns the commander of rly, applying the options it handles.
+func newCommander(log *zap.Logger, options relayer.RelayerOptions) relayer.RelayerCommander {
+	c := commander{log: log}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		}
+	}
+	return c
+}
+
 func (commander) Name() string {
 	return "rly"
 }
@@ -131,7 +180,7 @@ func (commander) AddKey(chainID, keyName, coinType, homeDir string) []string {
 }
 
 func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
-	return []string{
+	cmd := []string{
 		"rly", "tx", "channel", pathName,
 		"--src-port", opts.SourcePortName,
 		"--dst-port", opts.DestPortName,
@@ -140,6 +189,10 @@ func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, h
 
 		"--home", homeDir,
 	}
+	if opts.Override {
+		cmd = append(cmd, "--override")
+	}
+	return cmd
 }
 
 func (commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string {
@@ -2,13 +2,16 @@ package interchaintest
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/relayer"
-	"github.com/strangelove-ventures/interchaintest/v7/relayer/hermes"
-	"github.com/strangelove-ventures/interchaintest/v7/relayer/hyperspace"
-	"github.com/strangelove-ventures/interchaintest/v7/relayer/rly"
+
+	// Register the built-in relayers.
+	_ "github.com/strangelove-ventures/interchaintest/v7/relayer/hermes"
+	_ "github.com/strangelove-ventures/interchaintest/v7/relayer/hyperspace"
+	_ "github.com/strangelove-ventures/interchaintest/v7/relayer/rly"
 	"go.uber.org/zap"
 )
 
@@ -34,84 +37,70 @@ type RelayerFactory interface {
 	Capabilities() map[relayer.Capability]bool
 }
 
+// builtinRelayerNames are the names under which the relayer implementations are registered with relayer.Register.
+var builtinRelayerNames = map[ibc.RelayerImplementation]string{
+	ibc.CosmosRly:  "rly",
+	ibc.Hermes:     "hermes",
+	ibc.Hyperspace: "hyperspace",
+}
+
 // builtinRelayerFactory is the built-in relayer factory that understands
-// how to start the cosmos relayer in a docker container.
+// how to start the relayers registered with relayer.Register in a docker container.
 type builtinRelayerFactory struct {
-	impl    ibc.RelayerImplementation
+	name    string
 	log     *zap.Logger
 	options relayer.RelayerOptions
 }
 
 func NewBuiltinRelayerFactory(impl ibc.RelayerImplementation, logger *zap.Logger, options ...relayer.RelayerOption) RelayerFactory {
-	return builtinRelayerFactory{impl: impl, log: logger, options: options}
+	name, ok := builtinRelayerNames[impl]
+	if !ok {
+		name = fmt.Sprintf("RelayerImplementation %v", impl)
+	}
+	return builtinRelayerFactory{name: name, log: logger, options: options}
+}
+
+// NewRelayerFactory returns a RelayerFactory of the relayer registered under name with relayer.Register,
+// e.g. "rly", "hermes" or "hyperspace", or a third-party relayer.
+func NewRelayerFactory(name string, logger *zap.Logger, options ...relayer.RelayerOption) (RelayerFactory, error) {
+	if _, ok := relayer.Lookup(name); !ok {
+		return nil, fmt.Errorf("unknown relayer %q (registered relayers: %s)", name, strings.Join(relayer.Registered(), ", "))
+	}
+	return builtinRelayerFactory{name: name, log: logger, options: options}, nil
+}
+
+func (f builtinRelayerFactory) impl() relayer.Implementation {
+	impl, ok := relayer.Lookup(f.name)
+	if !ok {
+		panic(fmt.Errorf("%s unknown", f.name))
+	}
+	return impl
 }
 
-// Build returns a relayer chosen depending on f.impl.
+// Build returns a relayer of the implementation registered under f.name.
 func (f builtinRelayerFactory) Build(
 	t TestName,
 	cli *client.Client,
 	networkID string,
 ) ibc.Relayer {
-	switch f.impl {
-	case ibc.CosmosRly:
-		return rly.NewCosmosRelayer(
-			f.log,
-			t.Name(),
-			cli,
-			networkID,
-			f.options...,
-		)
-	case ibc.Hyperspace:
-		return hyperspace.NewHyperspaceRelayer(
-			f.log,
-			t.Name(),
-			cli,
-			networkID,
-			f.options...,
-		)
-	case ibc.Hermes:
-		return hermes.NewHermesRelayer(f.log, t.Name(), cli, networkID, f.options...)
-	default:
-		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
+	r, err := f.impl().Build(f.log, t.Name(), cli, networkID, f.options...)
+	if err != nil {
+		panic(err)
 	}
+	return r
 }
 
+// Name returns the registered name of the relayer and the version of its image.
+// This is using the name, e.g. "rly", instead of the image repository
+// so that the slashes in the image repository don't add ambiguity
+// to subtest paths, when the factory name is used in calls to t.Run.
 func (f builtinRelayerFactory) Name() string {
-	switch f.impl {
-	case ibc.CosmosRly:
-		// This is using the string "rly" instead of rly.ContainerImage
-		// so that the slashes in the image repository don't add ambiguity
-		// to subtest paths, when the factory name is used in calls to t.Run.
-		for _, opt := range f.options {
-			switch o := opt.(type) {
-			case relayer.RelayerOptionDockerImage:
-				return "rly@" + o.DockerImage.Version
-			}
-		}
-		return "rly@" + rly.DefaultContainerVersion
-	case ibc.Hermes:
-		for _, opt := range f.options {
-			switch o := opt.(type) {
-			case relayer.RelayerOptionDockerImage:
-				return "hermes@" + o.DockerImage.Version
-			}
-		}
-		return "hermes@" + hermes.DefaultContainerVersion
-	default:
-		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
-	}
+	return f.name + "@" + f.impl().Version(f.log, f.options)
 }
 
 // Capabilities returns the set of capabilities for the
 // relayer implementation backing this factory.
 func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
-	switch f.impl {
-	case ibc.CosmosRly:
-		return rly.Capabilities()
-	case ibc.Hermes:
-		// TODO: specify capability for hermes.
-		return rly.Capabilities()
-	default:
-		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
-	}
+	impl := f.impl()
+	return impl.Capabilities(impl.Version(f.log, f.options))
 }
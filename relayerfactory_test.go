@@ -0,0 +1,70 @@
+package interchaintest_test
+
+import (
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+// fakeCommander is the commander of the "fakerly" relayer registered by TestNewRelayerFactory.
+type fakeCommander struct {
+	relayer.RelayerCommander
+}
+
+func (fakeCommander) DefaultContainerVersion() string { return "v0.1.0" }
+
+func TestNewRelayerFactory(t *testing.T) {
+	relayer.Register("fakerly", relayer.Implementation{
+		Commander: func(*zap.Logger, relayer.RelayerOptions) relayer.RelayerCommander {
+			return fakeCommander{}
+		},
+		Capabilities: func(version string) map[relayer.Capability]bool {
+			return map[relayer.Capability]bool{relayer.HeightTimeout: true, relayer.Flush: version != "v0.1.0"}
+		},
+	})
+	require.Contains(t, relayer.Registered(), "fakerly")
+
+	log := zaptest.NewLogger(t)
+	rf, err := interchaintest.NewRelayerFactory("fakerly", log)
+	require.NoError(t, err)
+	require.Equal(t, "fakerly@v0.1.0", rf.Name())
+	require.Equal(t, map[relayer.Capability]bool{relayer.HeightTimeout: true, relayer.Flush: false}, rf.Capabilities())
+
+	rf, err = interchaintest.NewRelayerFactory("fakerly", log, relayer.CustomDockerImage("example/fakerly", "v0.2.0", ""))
+	require.NoError(t, err)
+	require.Equal(t, "fakerly@v0.2.0", rf.Name())
+	require.True(t, rf.Capabilities()[relayer.Flush])
+
+	require.PanicsWithError(t, "relayer nocaps registered without Capabilities", func() {
+		relayer.Register("nocaps", relayer.Implementation{
+			Commander: func(*zap.Logger, relayer.RelayerOptions) relayer.RelayerCommander { return fakeCommander{} },
+		})
+	})
+
+	_, err = interchaintest.NewRelayerFactory("unknown", log)
+	require.ErrorContains(t, err, `unknown relayer "unknown" (registered relayers: `)
+
+	t.Run("builtin", func(t *testing.T) {
+		require.Equal(t, "rly@v2.4.1", interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, log).Name())
+
+		hermes := interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, log)
+		require.Equal(t, "hermes@1.4.0", hermes.Name())
+		caps := hermes.Capabilities()
+		require.True(t, caps[relayer.Flush])
+		require.True(t, caps[relayer.ChannelClose])
+		require.False(t, caps[relayer.ChannelUpgrade])
+
+		rf, err := interchaintest.NewRelayerFactory("hermes", log)
+		require.NoError(t, err)
+		require.Equal(t, hermes.Name(), rf.Name())
+
+		rf, err = interchaintest.NewRelayerFactory("hermes", log, relayer.CustomDockerImage("ghcr.io/informalsystems/hermes", "v1.8.0", ""))
+		require.NoError(t, err)
+		require.True(t, rf.Capabilities()[relayer.ChannelUpgrade])
+	})
+}
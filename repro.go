@@ -0,0 +1,275 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.uber.org/zap"
+)
+
+// ReproBundleManifest is the name of the manifest file in the root of a reproduction bundle directory.
+const ReproBundleManifest = "manifest.json"
+
+// writeReproBundleOnFailure is initialized from the IBCTEST_REPRO_BUNDLE environment variable,
+// and set through WriteReproBundleOnFailure.
+var writeReproBundleOnFailure = os.Getenv("IBCTEST_REPRO_BUNDLE") != ""
+
+// WriteReproBundleOnFailure sets whether Interchain.Build arranges for a reproduction bundle
+// to be written to the test's artifact directory when the test fails.
+//
+// A bundle holds everything needed to inspect or relaunch the failed topology:
+// the resolved chain configurations, relayer wallet mnemonics, the image and command of every container,
+// an archive of every docker volume, readable copies of genesis and configuration files, and the block database.
+// See RelaunchReproBundle.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_REPRO_BUNDLE to a non-empty value.
+// Alternatively, importers of the interchaintest package may call WriteReproBundleOnFailure(true).
+func WriteReproBundleOnFailure(b bool) {
+	writeReproBundleOnFailure = b
+}
+
+// ReproBundle is the manifest of a reproduction bundle.
+type ReproBundle struct {
+	TestName  string    `json:"test_name"`
+	CreatedAt time.Time `json:"created_at"`
+	GitSha    string    `json:"git_sha,omitempty"`
+
+	Chains []ReproChain `json:"chains"`
+
+	// Topology holds the image and command of every container of the test,
+	// and the location of the volume archives in the bundle.
+	Topology dockerutil.Topology `json:"topology"`
+
+	// BlockDatabaseFile is the path of the copied block database, relative to the bundle directory.
+	BlockDatabaseFile string `json:"block_database_file,omitempty"`
+}
+
+// ReproChain is a chain of a reproduction bundle.
+type ReproChain struct {
+	// Name is the name the chain was added to the Interchain with.
+	Name   string          `json:"name"`
+	Config ibc.ChainConfig `json:"config"`
+
+	// RelayerWallets are the wallets created for the relayers connected to the chain.
+	RelayerWallets []ReproWallet `json:"relayer_wallets,omitempty"`
+
+	// ValidatorWallets are the wallets of the validator keys of the chain, for chains exposing them.
+	// Validator keys are generated in the keyring of their node, which is in the volume archives,
+	// so these wallets have no mnemonic.
+	ValidatorWallets []ReproWallet `json:"validator_wallets,omitempty"`
+
+	// UserWallets are the wallets of the test users created on the chain,
+	// e.g. with GetAndFundTestUsers.
+	UserWallets []ReproWallet `json:"user_wallets,omitempty"`
+}
+
+// ReproWallet is a wallet of a reproduction bundle.
+type ReproWallet struct {
+	// Relayer is the name of the relayer using the wallet, for relayer wallets.
+	Relayer  string `json:"relayer,omitempty"`
+	KeyName  string `json:"key_name"`
+	Address  string `json:"address"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+}
+
+// reproBundleOnFailure registers a docker cleanup hook that writes a reproduction bundle of ic
+// to the artifact directory of the test if the test fails.
+func (ic *Interchain) reproBundleOnFailure(rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) {
+	dir := dockerutil.TestArtifactsDir(opts.TestName)
+	if dir == "" || opts.Client == nil || opts.NetworkID == "" {
+		return
+	}
+	dir = filepath.Join(dir, "repro")
+
+	dockerutil.BeforeDockerCleanup(opts.NetworkID, func(failed bool) {
+		if !failed {
+			return
+		}
+
+		if err := ic.writeReproBundle(context.Background(), opts, dir); err != nil {
+			ic.log.Warn("Failed to write reproduction bundle", zap.String("dir", dir), zap.Error(err))
+			return
+		}
+		ic.log.Info("Wrote reproduction bundle", zap.String("dir", dir))
+		if rep != nil {
+			rep.TrackArtifact("repro bundle", "relaunch with: interchaintest relaunch -bundle "+dir, dir)
+		}
+	})
+}
+
+func (ic *Interchain) writeReproBundle(ctx context.Context, opts InterchainBuildOptions, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mkdirall: %w", err)
+	}
+
+	b := ReproBundle{
+		TestName:  opts.TestName,
+		CreatedAt: time.Now().UTC(),
+		GitSha:    opts.GitSha,
+	}
+
+	for c, name := range ic.chains {
+		rc := ReproChain{Name: name, Config: c.Config()}
+		for k, w := range ic.relayerWallets {
+			if k.C != c {
+				continue
+			}
+			rc.RelayerWallets = append(rc.RelayerWallets, ReproWallet{
+				Relayer:  ic.relayers[k.R],
+				KeyName:  w.KeyName(),
+				Address:  w.FormattedAddress(),
+				Mnemonic: w.Mnemonic(),
+			})
+		}
+		sort.Slice(rc.RelayerWallets, func(i, j int) bool {
+			return rc.RelayerWallets[i].Relayer < rc.RelayerWallets[j].Relayer
+		})
+		if v, ok := c.(validatorWalleter); ok {
+			wallets, err := v.ValidatorWallets(ctx)
+			if err != nil {
+				ic.log.Warn("Failed to get validator wallets", zap.String("chain", name), zap.Error(err))
+			}
+			for _, w := range wallets {
+				rc.ValidatorWallets = append(rc.ValidatorWallets, reproWallet(w))
+			}
+		}
+		for _, w := range ic.chainTestUsers(c) {
+			rc.UserWallets = append(rc.UserWallets, reproWallet(w))
+		}
+		b.Chains = append(b.Chains, rc)
+	}
+	sort.Slice(b.Chains, func(i, j int) bool { return b.Chains[i].Name < b.Chains[j].Name })
+
+	top, err := dockerutil.CaptureTopology(ctx, ic.log, opts.Client, opts.TestName, dir)
+	if err != nil {
+		return fmt.Errorf("capturing docker resources: %w", err)
+	}
+	b.Topology = top
+
+	if opts.BlockDatabaseFile != "" {
+		name := filepath.Base(opts.BlockDatabaseFile)
+		if err := copyFile(opts.BlockDatabaseFile, filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("copying block database: %w", err)
+		}
+		// The write-ahead log holds the most recent blocks until sqlite checkpoints it.
+		if _, err := os.Stat(opts.BlockDatabaseFile + "-wal"); err == nil {
+			if err := copyFile(opts.BlockDatabaseFile+"-wal", filepath.Join(dir, name+"-wal")); err != nil {
+				return fmt.Errorf("copying block database write-ahead log: %w", err)
+			}
+		}
+		b.BlockDatabaseFile = name
+	}
+
+	return writeReproBundleManifest(dir, b)
+}
+
+// validatorWalleter is implemented by chains exposing the wallets of their validator keys, such as cosmos chains.
+type validatorWalleter interface {
+	ValidatorWallets(ctx context.Context) ([]ibc.Wallet, error)
+}
+
+func reproWallet(w ibc.Wallet) ReproWallet {
+	return ReproWallet{
+		KeyName:  w.KeyName(),
+		Address:  w.FormattedAddress(),
+		Mnemonic: w.Mnemonic(),
+	}
+}
+
+func writeReproBundleManifest(dir string, b ReproBundle) error {
+	bz, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, ReproBundleManifest), bz, 0o644)
+}
+
+// ReadReproBundle reads the manifest of the reproduction bundle in dir.
+func ReadReproBundle(dir string) (ReproBundle, error) {
+	var b ReproBundle
+	bz, err := os.ReadFile(filepath.Join(dir, ReproBundleManifest))
+	if err != nil {
+		return b, err
+	}
+	if err := json.Unmarshal(bz, &b); err != nil {
+		return b, fmt.Errorf("unmarshal manifest: %w", err)
+	}
+	return b, nil
+}
+
+// RelaunchedReproBundle is a topology relaunched from a reproduction bundle.
+type RelaunchedReproBundle struct {
+	Bundle ReproBundle
+
+	// HostPorts maps container names to their exposed container ports, such as "26657/tcp",
+	// to the host address they are published on.
+	HostPorts map[string]map[string]string
+
+	rt *dockerutil.RelaunchedTopology
+}
+
+// RelaunchReproBundle starts the containers of the reproduction bundle in dir,
+// with their original names, images, and commands.
+// Chains resume from their preserved volumes when those still exist,
+// for example when the failed test ran with KeepDockerVolumesOnFailure(true),
+// and from the volume archives in the bundle otherwise.
+//
+// A relayer is only relaunched if it was running when the test failed,
+// but the configuration of every relayer is restored in its volume.
+// Call Stop on the result to remove the relaunched containers.
+func RelaunchReproBundle(ctx context.Context, log *zap.Logger, cli *client.Client, dir string) (*RelaunchedReproBundle, error) {
+	b, err := ReadReproBundle(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	label := "interchaintest-relaunch/" + b.TestName
+	rt, err := dockerutil.RelaunchTopology(ctx, log, cli, label, dir, b.Topology)
+	if err != nil {
+		if rt != nil {
+			_ = rt.Stop(ctx, true)
+		}
+		return nil, err
+	}
+
+	return &RelaunchedReproBundle{
+		Bundle:    b,
+		HostPorts: rt.HostPorts,
+		rt:        rt,
+	}, nil
+}
+
+// Stop removes the relaunched containers.
+// Volumes restored from the bundle archives are removed too, but preserved volumes are kept.
+func (r *RelaunchedReproBundle) Stop(ctx context.Context) error {
+	return r.rt.Stop(ctx, true)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -3,6 +3,7 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/runner"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 )
@@ -30,6 +32,35 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+// CaptureContainerLogsAlways sets whether the logs of every container associated with a particular test
+// are written to the test's artifact directory when the test passes.
+// Container logs are always written when the test fails.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_CAPTURE_CONTAINER_LOGS to a non-empty value.
+// Alternatively, importers of the interchaintest package may call CaptureContainerLogsAlways(true).
+func CaptureContainerLogsAlways(b bool) {
+	dockerutil.CaptureContainerLogsAlways = b
+}
+
+// SetArtifactsDir sets the root directory under which each test gets an artifact directory,
+// holding files that aid debugging the test, such as captured container logs.
+// The directory of a test is nested by subtest name.
+//
+// The value defaults to the environment variable IBCTEST_ARTIFACTS_DIR,
+// or $HOME/.interchaintest/artifacts if that is not set.
+// Setting an empty directory disables writing artifacts.
+func SetArtifactsDir(dir string) {
+	dockerutil.ArtifactsDir = dir
+}
+
+// TestArtifactsDir returns the artifact directory of the test with the given name,
+// or an empty string if artifacts are disabled through SetArtifactsDir.
+// The directory is not created.
+func TestArtifactsDir(testName string) string {
+	return dockerutil.TestArtifactsDir(testName)
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
@@ -38,6 +69,56 @@ func DockerSetup(t dockerutil.DockerSetupTestingT) (*client.Client, string) {
 	return dockerutil.DockerSetup(t)
 }
 
+// DockerResource is a container, volume or network labeled by interchaintest, found by CleanupDocker.
+type DockerResource = dockerutil.LabeledResource
+
+// CleanupDocker finds the containers, volumes and networks labeled by interchaintest,
+// such as those left behind when a test process is killed before the cleanup registered by DockerSetup runs.
+// If testNames is not empty, only the resources of those tests and their subtests are included.
+//
+// Unless dryRun is set, the containers are stopped and removed, then the volumes and networks are removed,
+// and CleanupDocker returns the resources it removed.
+// With dryRun, nothing is removed, and CleanupDocker returns the resources it would remove.
+//
+// Without testNames, CleanupDocker also removes the resources of tests that are still running.
+func CleanupDocker(ctx context.Context, cli *client.Client, dryRun bool, testNames ...string) ([]DockerResource, error) {
+	rs, err := dockerutil.FindLabeled(ctx, cli, testNames)
+	if err != nil || dryRun {
+		return rs, err
+	}
+	return dockerutil.RemoveLabeled(ctx, cli, rs)
+}
+
+// NativeSetup prepares t to run chains of the native runtime (ibc.NativeRuntime),
+// which need no docker client or network.
+// When t finishes, the processes started by t are killed, and their home directories are removed,
+// unless t failed and KeepTempDirOnFailure is set.
+func NativeSetup(t TempDirTestingT) {
+	t.Helper()
+
+	dir := runner.NativeTestDir(t.Name())
+
+	// Eagerly remove leftovers from a previous run of the test, e.g. if the test was interrupted.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Errorf("NativeSetup RemoveAll: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := runner.StopNative(t.Name()); err != nil {
+			t.Errorf("NativeSetup stopping processes: %v", err)
+		}
+
+		if keepTempDirOnFailure && t.Failed() {
+			t.Logf("Not removing native home directories for test at: %s", dir)
+			return
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("NativeSetup RemoveAll cleanup: %v", err)
+		}
+	})
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
@@ -76,6 +157,7 @@ func StartChainPair(
 		NetworkID:         networkID,
 		GitSha:            version.GitSha,
 		BlockDatabaseFile: blockSqlite,
+		T:                 t,
 	}); err != nil {
 		return nil, err
 	}
@@ -136,6 +218,7 @@ func StopStartRelayerWithPreStartFuncs(
 	// TODO: cleanup since this will stack multiple StopRelayer calls for
 	// multiple calls to this func, requires StopRelayer to be idempotent.
 	t.Cleanup(func() {
+		PauseBeforeCleanup(t)
 		if err := relayerImpl.StopRelayer(ctx, eRep); err != nil {
 			t.Logf("error stopping relayer: %v", err)
 		}
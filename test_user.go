@@ -3,6 +3,7 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"cosmossdk.io/math"
@@ -37,9 +38,59 @@ func GetAndFundTestUserWithMnemonic(
 	if err != nil {
 		return nil, fmt.Errorf("failed to get funds from faucet: %w", err)
 	}
+	trackTestUser(chain, user)
 	return user, nil
 }
 
+var (
+	builtInterchainsMu sync.Mutex
+	// builtInterchains are the Interchains being built or built, by chain,
+	// so that the users created by GetAndFundTestUserWithMnemonic are kept by the Interchain holding the chain,
+	// for its reproduction bundle and pause information.
+	builtInterchains = make(map[ibc.Chain]*Interchain)
+)
+
+func trackTestUser(chain ibc.Chain, user ibc.Wallet) {
+	builtInterchainsMu.Lock()
+	ic := builtInterchains[chain]
+	builtInterchainsMu.Unlock()
+	if ic == nil {
+		return
+	}
+
+	ic.testUsersMu.Lock()
+	defer ic.testUsersMu.Unlock()
+	ic.testUsers[chain] = append(ic.testUsers[chain], user)
+}
+
+// chainTestUsers returns the users created for chain by GetAndFundTestUserWithMnemonic.
+func (ic *Interchain) chainTestUsers(chain ibc.Chain) []ibc.Wallet {
+	ic.testUsersMu.Lock()
+	defer ic.testUsersMu.Unlock()
+	return append([]ibc.Wallet(nil), ic.testUsers[chain]...)
+}
+
+// trackTestUsers keeps the users created for the chains of ic from now on, until untrackTestUsers.
+func (ic *Interchain) trackTestUsers() {
+	builtInterchainsMu.Lock()
+	defer builtInterchainsMu.Unlock()
+	for c := range ic.chains {
+		builtInterchains[c] = ic
+	}
+}
+
+// untrackTestUsers stops keeping the users created for the chains of ic.
+// The users already created are kept by ic.
+func (ic *Interchain) untrackTestUsers() {
+	builtInterchainsMu.Lock()
+	defer builtInterchainsMu.Unlock()
+	for c := range ic.chains {
+		if builtInterchains[c] == ic {
+			delete(builtInterchains, c)
+		}
+	}
+}
+
 // GetAndFundTestUsers generates and funds chain users with the native chain denom.
 // The caller should wait for some blocks to complete before the funds will be accessible.
 func GetAndFundTestUsers(
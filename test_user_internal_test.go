@@ -0,0 +1,35 @@
+package interchaintest
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeChain struct{ ibc.Chain }
+
+type fakeWallet struct{ ibc.Wallet }
+
+func TestInterchain_TestUsersOutliveClose(t *testing.T) {
+	chain := &fakeChain{}
+	ic := NewInterchain()
+	ic.chains[chain] = "fake"
+	ic.cs = newChainSet(zap.NewNop(), nil)
+
+	user := fakeWallet{}
+	trackTestUser(chain, user)
+	require.Empty(t, ic.chainTestUsers(chain), "users created before Build are not kept")
+
+	ic.trackTestUsers()
+	trackTestUser(chain, user)
+	require.NoError(t, ic.Close())
+
+	// The reproduction bundle and pause information are written after the Interchain is closed.
+	require.Equal(t, []ibc.Wallet{user}, ic.chainTestUsers(chain))
+
+	trackTestUser(chain, user)
+	require.Len(t, ic.chainTestUsers(chain), 1, "users created after Close are not kept")
+	require.NotContains(t, builtInterchains, ibc.Chain(chain))
+}
@@ -0,0 +1,172 @@
+package testreporter
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WriteHTML writes msgs, as tracked by a Reporter, as a self-contained HTML page.
+//
+// The page shows the tree of tests with their outcome and timing,
+// the errors, skips, and pause and continue events of each test,
+// collapsible entries for every relayer and chain command a test executed,
+// and links to the artifacts written for each test, such as captured container logs.
+func WriteHTML(w io.Writer, msgs []Message) error {
+	root := buildTestTree(msgs)
+
+	r := htmlReport{}
+	if !root.begun.IsZero() {
+		r.StartedAt = root.begun.UTC().Format(time.RFC3339)
+		if !root.finished.IsZero() {
+			r.Duration = formatDuration(root.finished.Sub(root.begun))
+		}
+	}
+	for _, c := range root.children {
+		r.Tests = append(r.Tests, newHTMLTest(c))
+	}
+	var count func(n *testNode)
+	count = func(n *testNode) {
+		if len(n.children) == 0 {
+			switch n.status() {
+			case "pass":
+				r.Passed++
+			case "skip":
+				r.Skipped++
+			default:
+				r.Failed++
+			}
+		}
+		for _, c := range n.children {
+			count(c)
+		}
+	}
+	count(root)
+
+	return htmlReportTemplate.Execute(w, r)
+}
+
+type htmlReport struct {
+	StartedAt, Duration     string
+	Passed, Failed, Skipped int
+
+	Tests []htmlTest
+}
+
+type htmlTest struct {
+	Name, FullName string
+	Status         string
+	Duration       string
+	Paused         string
+
+	Events    []htmlEvent
+	Metrics   []TestMetricMessage
+	Execs     []htmlExec
+	Artifacts []htmlArtifact
+
+	Children []htmlTest
+}
+
+type htmlEvent struct {
+	Offset string // Time since the test began.
+	Kind   string
+	Detail string
+}
+
+type htmlArtifact struct {
+	Kind, Description string
+	Path              string
+	URL               template.URL
+}
+
+type htmlExec struct {
+	Source    string
+	Container string
+	Command   string
+	Env       []string
+	Offset    string
+	Duration  string
+	ExitCode  int
+	Error     string
+
+	Stdout, Stderr string
+}
+
+// Failed reports whether the command exited unsuccessfully.
+func (e htmlExec) Failed() bool {
+	return e.ExitCode != 0 || e.Error != ""
+}
+
+func newHTMLTest(n *testNode) htmlTest {
+	begun, finished := n.span()
+	t := htmlTest{
+		Name:     n.name,
+		FullName: n.fullName,
+		Status:   n.status(),
+		Metrics:  n.metrics,
+	}
+	if !begun.IsZero() && !finished.IsZero() {
+		t.Duration = formatDuration(finished.Sub(begun))
+	}
+	if p := n.paused(); p > 0 {
+		t.Paused = formatDuration(p)
+	}
+
+	offset := func(when time.Time) string {
+		if begun.IsZero() || when.IsZero() {
+			return ""
+		}
+		return "+" + formatDuration(when.Sub(begun))
+	}
+
+	for _, e := range n.events {
+		t.Events = append(t.Events, htmlEvent{Offset: offset(e.When), Kind: e.Kind, Detail: e.Detail})
+	}
+
+	for _, e := range n.sortedExecs() {
+		t.Execs = append(t.Execs, htmlExec{
+			Source:    e.Source,
+			Container: e.ContainerName,
+			Command:   strings.Join(e.Command, " "),
+			Env:       e.Env,
+			Offset:    offset(e.StartedAt),
+			Duration:  formatDuration(e.FinishedAt.Sub(e.StartedAt)),
+			ExitCode:  e.ExitCode,
+			Error:     e.Error,
+			Stdout:    e.Stdout,
+			Stderr:    e.Stderr,
+		})
+	}
+
+	for _, a := range n.artifacts {
+		u := url.URL{Scheme: "file", Path: filepath.ToSlash(a.Path)}
+		t.Artifacts = append(t.Artifacts, htmlArtifact{
+			Kind:        a.Kind,
+			Description: a.Description,
+			Path:        a.Path,
+			URL:         template.URL(u.String()),
+		})
+	}
+
+	for _, c := range n.children {
+		t.Children = append(t.Children, newHTMLTest(c))
+	}
+	return t
+}
+
+// formatDuration rounds d for display.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
+
+//go:embed report.html.tmpl
+var reportHTML string
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(reportHTML))
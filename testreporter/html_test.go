@@ -0,0 +1,69 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTML(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	msgs := []testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: start},
+		testreporter.BeginTestMessage{Name: "TestFoo", StartedAt: at(0)},
+		testreporter.BeginTestMessage{Name: "TestFoo/relay", StartedAt: at(time.Second)},
+		testreporter.PauseTestMessage{Name: "TestFoo/relay", When: at(time.Second)},
+		testreporter.ContinueTestMessage{Name: "TestFoo/relay", When: at(3 * time.Second)},
+		testreporter.RelayerExecMessage{
+			Name:          "TestFoo/relay",
+			StartedAt:     at(4 * time.Second),
+			FinishedAt:    at(5 * time.Second),
+			ContainerName: "rly-exec-1",
+			Command:       []string{"rly", "tx", "flush"},
+			Stdout:        "flushed",
+			Stderr:        "<script>alert(1)</script>",
+			ExitCode:      1,
+		},
+		testreporter.TestErrorMessage{Name: "TestFoo/relay", When: at(5 * time.Second), Message: "packet not relayed"},
+		testreporter.TestArtifactMessage{Name: "TestFoo/relay", When: at(6 * time.Second), Kind: "container logs", Description: "rly-1", Path: "/tmp/logs/rly 1.log"},
+		testreporter.FinishTestMessage{Name: "TestFoo/relay", FinishedAt: at(6 * time.Second), Failed: true},
+		testreporter.BeginTestMessage{Name: "TestFoo/timeout", StartedAt: at(time.Second)},
+		testreporter.TestSkipMessage{Name: "TestFoo/timeout", When: at(time.Second), Message: "not supported"},
+		testreporter.FinishTestMessage{Name: "TestFoo/timeout", FinishedAt: at(time.Second), Skipped: true},
+		testreporter.FinishTestMessage{Name: "TestFoo", FinishedAt: at(7 * time.Second), Failed: true},
+		testreporter.FinishSuiteMessage{FinishedAt: at(8 * time.Second)},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, testreporter.WriteHTML(&buf, msgs))
+	out := buf.String()
+
+	require.Contains(t, out, "Ran for 8s.")
+	require.Contains(t, out, `<span class="pass">0 passed</span>`)
+	require.Contains(t, out, `<span class="fail">1 failed</span>`)
+	require.Contains(t, out, `<span class="skip">1 skipped</span>`)
+
+	// The failed test is expanded, with its timing and time spent paused.
+	require.Contains(t, out, `<details open>
+<summary><span class="status fail">fail</span> relay <span class="timing">5s</span> <span class="timing">(paused 2s)</span></summary>`)
+	require.Contains(t, out, `<td class="pause">pause</td>`)
+	require.Contains(t, out, `<td class="continue">continue</td>`)
+	require.Contains(t, out, "<pre>packet not relayed</pre>")
+
+	require.Contains(t, out, `<details class="exec failed">`)
+	require.Contains(t, out, "<code>rly tx flush</code> exit code 1")
+	require.Contains(t, out, "<code>rly-exec-1</code>")
+	require.Contains(t, out, "<pre>flushed</pre>")
+	require.Contains(t, out, "&lt;script&gt;")
+	require.NotContains(t, out, "<script>")
+
+	require.Contains(t, out, `<li>container logs (rly-1): <a href="file:///tmp/logs/rly%201.log">/tmp/logs/rly 1.log</a></li>`)
+
+	require.Contains(t, out, `<span class="status skip">skip</span> timeout`)
+	require.Contains(t, out, "<pre>not supported</pre>")
+}
@@ -0,0 +1,246 @@
+package testreporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriteJUnit writes msgs, as tracked by a Reporter, as a JUnit XML document.
+//
+// Tests that contain subtests become nested testsuite elements,
+// and tests without subtests become testcase elements.
+// Errors tracked through TestifyT become the failure text of their test,
+// and relayer and chain command executions are written to the system-out element of the test that ran them.
+func WriteJUnit(w io.Writer, msgs []Message) error {
+	root := buildTestTree(msgs)
+
+	doc := junitTestSuites{
+		Name: "interchaintest",
+	}
+	for _, c := range root.children {
+		s := c.suite()
+		doc.Suites = append(doc.Suites, s)
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+		doc.Skipped += s.Skipped
+	}
+	if !root.begun.IsZero() && !root.finished.IsZero() {
+		doc.Time = junitSeconds(root.finished.Sub(root.begun))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode junit xml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name `xml:"testsuites"`
+	Name     string   `xml:"name,attr"`
+	Tests    int      `xml:"tests,attr"`
+	Failures int      `xml:"failures,attr"`
+	Skipped  int      `xml:"skipped,attr"`
+	Time     string   `xml:"time,attr,omitempty"`
+
+	Suites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string `xml:"name,attr"`
+	Tests     int    `xml:"tests,attr"`
+	Failures  int    `xml:"failures,attr"`
+	Skipped   int    `xml:"skipped,attr"`
+	Time      string `xml:"time,attr,omitempty"`
+	Timestamp string `xml:"timestamp,attr,omitempty"`
+
+	Properties []junitProperty  `xml:"properties>property,omitempty"`
+	Suites     []junitTestSuite `xml:"testsuite"`
+	Cases      []junitTestCase  `xml:"testcase"`
+	SystemOut  string           `xml:"system-out,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string `xml:"name,attr"`
+	Classname string `xml:"classname,attr"`
+	Time      string `xml:"time,attr,omitempty"`
+
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	Skipped    *junitSkipped   `xml:"skipped,omitempty"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+func (n *testNode) duration() string {
+	begun, finished := n.span()
+	if begun.IsZero() || finished.IsZero() {
+		return ""
+	}
+	return junitSeconds(finished.Sub(begun))
+}
+
+func (n *testNode) suite() junitTestSuite {
+	begun, _ := n.span()
+	s := junitTestSuite{
+		Name:       n.fullName,
+		Time:       n.duration(),
+		Properties: n.properties(),
+		SystemOut:  n.systemOut(),
+	}
+	if !begun.IsZero() {
+		s.Timestamp = begun.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	// A top-level test without subtests is reported as a suite with a single test case.
+	// A test with subtests may still fail or skip on its own, e.g. during setup before running subtests.
+	// Report that as a test case named after the suite, so the failure is not lost.
+	if len(n.children) == 0 || len(n.errors) > 0 || (n.skipped && n.skipMsg != "") || (n.failed && !n.anyChildFailed()) {
+		c := n.testCase()
+		c.Properties = nil
+		c.SystemOut = ""
+		s.Cases = append(s.Cases, c)
+	}
+
+	for _, c := range n.children {
+		if len(c.children) > 0 {
+			s.Suites = append(s.Suites, c.suite())
+			continue
+		}
+		s.Cases = append(s.Cases, c.testCase())
+	}
+
+	for _, c := range s.Cases {
+		s.Tests++
+		if c.Failure != nil {
+			s.Failures++
+		}
+		if c.Skipped != nil {
+			s.Skipped++
+		}
+	}
+	for _, sub := range s.Suites {
+		s.Tests += sub.Tests
+		s.Failures += sub.Failures
+		s.Skipped += sub.Skipped
+	}
+
+	return s
+}
+
+func (n *testNode) testCase() junitTestCase {
+	c := junitTestCase{
+		Name:       n.name,
+		Classname:  n.classname(),
+		Time:       n.duration(),
+		Properties: n.properties(),
+		SystemOut:  n.systemOut(),
+	}
+
+	switch {
+	case n.failed || len(n.errors) > 0:
+		f := &junitFailure{Message: "test failed"}
+		if len(n.errors) > 0 {
+			f.Message = firstLine(n.errors[0])
+			f.Text = strings.Join(n.errors, "\n\n")
+		}
+		c.Failure = f
+	case n.tracked && n.finished.IsZero():
+		c.Failure = &junitFailure{Message: "test did not finish"}
+	case n.skipped:
+		c.Skipped = &junitSkipped{Message: n.skipMsg}
+	}
+
+	return c
+}
+
+func (n *testNode) classname() string {
+	if i := strings.LastIndex(n.fullName, "/"); i >= 0 {
+		return n.fullName[:i]
+	}
+	return n.fullName
+}
+
+func (n *testNode) properties() []junitProperty {
+	if len(n.metrics) == 0 {
+		return nil
+	}
+	props := make([]junitProperty, len(n.metrics))
+	for i, m := range n.metrics {
+		v := fmt.Sprintf("%g", m.Value)
+		if m.Unit != "" {
+			v += " " + m.Unit
+		}
+		props[i] = junitProperty{Name: m.Metric, Value: v}
+	}
+	return props
+}
+
+// systemOut formats the relayer and chain executions of the test, in the order they started,
+// followed by the artifacts of the test in the attachment format understood by Jenkins.
+func (n *testNode) systemOut() string {
+	if len(n.execs) == 0 && len(n.artifacts) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, e := range n.sortedExecs() {
+		fmt.Fprintf(&sb, "$ %s\n", strings.Join(e.Command, " "))
+		fmt.Fprintf(&sb, "%s", e.Source)
+		if e.ContainerName != "" {
+			fmt.Fprintf(&sb, " container: %s", e.ContainerName)
+		}
+		sb.WriteString("\n")
+		if len(e.Env) > 0 {
+			fmt.Fprintf(&sb, "env: %s\n", strings.Join(e.Env, " "))
+		}
+		fmt.Fprintf(&sb, "exit code: %d (%s)\n", e.ExitCode, e.FinishedAt.Sub(e.StartedAt))
+		if e.Error != "" {
+			fmt.Fprintf(&sb, "error: %s\n", e.Error)
+		}
+		if e.Stdout != "" {
+			fmt.Fprintf(&sb, "stdout:\n%s\n", strings.TrimRight(e.Stdout, "\n"))
+		}
+		if e.Stderr != "" {
+			fmt.Fprintf(&sb, "stderr:\n%s\n", strings.TrimRight(e.Stderr, "\n"))
+		}
+		sb.WriteString("\n")
+	}
+	for _, a := range n.artifacts {
+		fmt.Fprintf(&sb, "[[ATTACHMENT|%s]]\n", a.Path)
+	}
+	return sb.String()
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
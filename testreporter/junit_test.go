@@ -0,0 +1,140 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	msgs := []testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: start},
+		testreporter.BeginTestMessage{Name: "TestFoo", StartedAt: at(0)},
+		// TestFoo/group is not tracked, but still becomes a suite.
+		testreporter.BeginTestMessage{Name: "TestFoo/group/pass", StartedAt: at(time.Second)},
+		testreporter.RelayerExecMessage{
+			Name:       "TestFoo/group/pass",
+			StartedAt:  at(time.Second),
+			FinishedAt: at(2 * time.Second),
+			Command:    []string{"rly", "tx", "link"},
+			Stdout:     "linked\n",
+			Stderr:     "<warn>",
+			ExitCode:   0,
+		},
+		testreporter.ChainExecMessage{
+			Name:          "TestFoo/group/pass",
+			StartedAt:     at(1500 * time.Millisecond),
+			FinishedAt:    at(2 * time.Second),
+			ContainerName: "gaia-exec",
+			Command:       []string{"gaiad", "status"},
+			Env:           []string{"HOME=/home/gaia"},
+		},
+		testreporter.FinishTestMessage{Name: "TestFoo/group/pass", FinishedAt: at(3 * time.Second)},
+		testreporter.BeginTestMessage{Name: "TestFoo/group/fail", StartedAt: at(time.Second)},
+		testreporter.TestErrorMessage{Name: "TestFoo/group/fail", Message: "first error\nwith trace"},
+		testreporter.TestErrorMessage{Name: "TestFoo/group/fail", Message: "second error"},
+		testreporter.TestArtifactMessage{Name: "TestFoo/group/fail", Kind: "container logs", Path: "/tmp/logs/gaia.log"},
+		testreporter.FinishTestMessage{Name: "TestFoo/group/fail", FinishedAt: at(4 * time.Second), Failed: true},
+		testreporter.BeginTestMessage{Name: "TestFoo/skip", StartedAt: at(time.Second)},
+		testreporter.TestSkipMessage{Name: "TestFoo/skip", Message: "not today"},
+		testreporter.FinishTestMessage{Name: "TestFoo/skip", FinishedAt: at(time.Second), Skipped: true},
+		testreporter.FinishTestMessage{Name: "TestFoo", FinishedAt: at(5 * time.Second), Failed: true},
+		testreporter.BeginTestMessage{Name: "TestBar", StartedAt: at(0)},
+		testreporter.FinishSuiteMessage{FinishedAt: at(6 * time.Second)},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, testreporter.WriteJUnit(&buf, msgs))
+
+	type testCase struct {
+		Name      string `xml:"name,attr"`
+		Classname string `xml:"classname,attr"`
+		Time      string `xml:"time,attr"`
+		Failure   *struct {
+			Message string `xml:"message,attr"`
+			Text    string `xml:",chardata"`
+		} `xml:"failure"`
+		Skipped *struct {
+			Message string `xml:"message,attr"`
+		} `xml:"skipped"`
+		SystemOut string `xml:"system-out"`
+	}
+	type testSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Skipped  int         `xml:"skipped,attr"`
+		Time     string      `xml:"time,attr"`
+		Suites   []testSuite `xml:"testsuite"`
+		Cases    []testCase  `xml:"testcase"`
+	}
+	var doc struct {
+		XMLName  xml.Name    `xml:"testsuites"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Skipped  int         `xml:"skipped,attr"`
+		Time     string      `xml:"time,attr"`
+		Suites   []testSuite `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	require.Equal(t, 4, doc.Tests)
+	require.Equal(t, 2, doc.Failures)
+	require.Equal(t, 1, doc.Skipped)
+	require.Equal(t, "6.000", doc.Time)
+	require.Len(t, doc.Suites, 2)
+
+	foo := doc.Suites[0]
+	require.Equal(t, "TestFoo", foo.Name)
+	require.Equal(t, "5.000", foo.Time)
+	require.Equal(t, 3, foo.Tests)
+	require.Equal(t, 1, foo.Failures)
+	require.Equal(t, 1, foo.Skipped)
+
+	require.Len(t, foo.Cases, 1)
+	skip := foo.Cases[0]
+	require.Equal(t, "skip", skip.Name)
+	require.NotNil(t, skip.Skipped)
+	require.Equal(t, "not today", skip.Skipped.Message)
+
+	require.Len(t, foo.Suites, 1)
+	group := foo.Suites[0]
+	require.Equal(t, "TestFoo/group", group.Name)
+	require.Equal(t, "3.000", group.Time)
+	require.Len(t, group.Cases, 2)
+
+	pass := group.Cases[0]
+	require.Equal(t, "pass", pass.Name)
+	require.Equal(t, "TestFoo/group", pass.Classname)
+	require.Equal(t, "2.000", pass.Time)
+	require.Nil(t, pass.Failure)
+	require.Contains(t, pass.SystemOut, "$ rly tx link")
+	require.Contains(t, pass.SystemOut, "exit code: 0")
+	require.Contains(t, pass.SystemOut, "stdout:\nlinked\n")
+	require.Contains(t, pass.SystemOut, "stderr:\n<warn>\n")
+	require.Contains(t, pass.SystemOut, "$ gaiad status\nchain container: gaia-exec\nenv: HOME=/home/gaia\n")
+	require.Less(t, strings.Index(pass.SystemOut, "rly tx link"), strings.Index(pass.SystemOut, "gaiad status"))
+
+	fail := group.Cases[1]
+	require.Equal(t, "fail", fail.Name)
+	require.NotNil(t, fail.Failure)
+	require.Equal(t, "first error", fail.Failure.Message)
+	require.Equal(t, "first error\nwith trace\n\nsecond error", fail.Failure.Text)
+	require.Equal(t, "[[ATTACHMENT|/tmp/logs/gaia.log]]\n", fail.SystemOut)
+
+	// TestBar began but never finished.
+	bar := doc.Suites[1]
+	require.Equal(t, "TestBar", bar.Name)
+	require.Equal(t, 1, bar.Tests)
+	require.Equal(t, 1, bar.Failures)
+	require.Len(t, bar.Cases, 1)
+	require.Equal(t, "test did not finish", bar.Cases[0].Failure.Message)
+}
@@ -2,7 +2,9 @@ package testreporter
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -110,6 +112,23 @@ func (m TestSkipMessage) typ() string {
 	return "TestSkip"
 }
 
+// TestMetricMessage is tracked when a Reporter's TrackMetric method is called.
+// It records a single named measurement taken during a test,
+// such as the time a relayer took to deliver a batch of packets,
+// so that the measurement can be compared across test runs.
+type TestMetricMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	Metric string
+	Value  float64
+	Unit   string `json:",omitempty"`
+}
+
+func (m TestMetricMessage) typ() string {
+	return "TestMetric"
+}
+
 // RelayerExecMessage is the result of executing a relayer command.
 // This message is populated through the RelayerExecReporter type,
 // which is returned by the Reporter's RelayerExecReporter method.
@@ -133,6 +152,49 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// ChainExecMessage is the result of executing a command in a one-off container of a chain node or sidecar.
+// This message is populated through the ChainExecReporter type,
+// which is returned by the Reporter's ChainExecReporter method.
+type ChainExecMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	StartedAt, FinishedAt time.Time
+
+	ContainerName string `json:",omitempty"`
+
+	Command []string
+	Env     []string `json:",omitempty"`
+
+	Stdout, Stderr string
+
+	ExitCode int
+
+	Error string `json:",omitempty"`
+}
+
+func (m ChainExecMessage) typ() string {
+	return "ChainExec"
+}
+
+// TestArtifactMessage is tracked when a file is written to aid debugging a test,
+// such as the captured logs of a container.
+type TestArtifactMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	// Kind groups artifacts of the same sort, e.g. "container logs".
+	Kind string
+
+	// Description distinguishes artifacts of the same kind, e.g. the container name.
+	Description string `json:",omitempty"`
+
+	Path string
+}
+
+func (m TestArtifactMessage) typ() string {
+	return "TestArtifact"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -195,10 +257,22 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := TestSkipMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "TestMetric":
+		x := TestMetricMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	case "RelayerExec":
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "TestArtifact":
+		x := TestArtifactMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "ChainExec":
+		x := ChainExecMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
@@ -210,3 +284,22 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 	m.Message = msg
 	return nil
 }
+
+// ReadMessages decodes the stream of wrapped messages written by a Reporter,
+// such as the contents of a report file, returning the unwrapped messages in order.
+func ReadMessages(r io.Reader) ([]Message, error) {
+	var msgs []Message
+
+	dec := json.NewDecoder(r)
+	for {
+		var wm WrappedMessage
+		if err := dec.Decode(&wm); err != nil {
+			if errors.Is(err, io.EOF) {
+				return msgs, nil
+			}
+			return msgs, fmt.Errorf("failed to decode message %d: %w", len(msgs)+1, err)
+		}
+
+		msgs = append(msgs, wm.Message)
+	}
+}
@@ -1,6 +1,7 @@
 package testreporter_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 		{Message: testreporter.FinishTestMessage{Name: "foo", FinishedAt: time.Now(), Skipped: true, Failed: true}},
 		{Message: testreporter.TestErrorMessage{Name: "foo", When: time.Now(), Message: "something failed"}},
 		{Message: testreporter.TestSkipMessage{Name: "foo", When: time.Now(), Message: "skipped for reasons"}},
+		{Message: testreporter.TestMetricMessage{Name: "foo", When: time.Now(), Metric: "completion time", Value: 12.5, Unit: "s"}},
 		{
 			Message: testreporter.RelayerExecMessage{
 				Name:          "foo",
@@ -39,6 +41,20 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{Message: testreporter.TestArtifactMessage{Name: "foo", When: time.Now(), Kind: "container logs", Description: "gaia-val-0", Path: "/tmp/gaia-val-0.log"}},
+		{
+			Message: testreporter.ChainExecMessage{
+				Name:          "foo",
+				StartedAt:     time.Now(),
+				FinishedAt:    time.Now().Add(time.Second),
+				ContainerName: "gaia-exec-123",
+				Command:       []string{"gaiad", "tx", "bank", "send"},
+				Env:           []string{"HOME=/var/cosmos-chain/gaia"},
+				Stderr:        "insufficient funds",
+				ExitCode:      1,
+				Error:         "exit code 1: insufficient funds",
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -54,3 +70,18 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 		require.Empty(t, diff)
 	}
 }
+
+func TestReadMessages(t *testing.T) {
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+	require.NoError(t, r.Close())
+
+	msgs, err := testreporter.ReadMessages(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.IsType(t, testreporter.BeginSuiteMessage{}, msgs[0])
+	require.IsType(t, testreporter.FinishSuiteMessage{}, msgs[1])
+
+	_, err = testreporter.ReadMessages(bytes.NewReader(append(buf.Bytes(), `{"Type":"Bogus"}`...)))
+	require.ErrorContains(t, err, "failed to decode message 3")
+}
@@ -118,6 +118,29 @@ func (r *Reporter) TrackSkip(t T, format string, args ...any) {
 	t.Skip(msg)
 }
 
+// TrackMetric records a named measurement for t, such as a duration or a count.
+// The unit is free-form and only used for display, e.g. "s" or "packets".
+func (r *Reporter) TrackMetric(t T, metric string, value float64, unit string) {
+	r.in <- TestMetricMessage{
+		Name:   t.Name(),
+		When:   time.Now(),
+		Metric: metric,
+		Value:  value,
+		Unit:   unit,
+	}
+}
+
+// TrackArtifact records that a file of the given kind was written to path to aid debugging t.
+func (r *Reporter) TrackArtifact(t T, kind, description, path string) {
+	r.in <- TestArtifactMessage{
+		Name:        t.Name(),
+		When:        time.Now(),
+		Kind:        kind,
+		Description: description,
+		Path:        path,
+	}
+}
+
 // RelayerExecReporter returns a RelayerExecReporter associated with t.
 func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 	return &RelayerExecReporter{r: r, testName: t.Name()}
@@ -156,6 +179,64 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	}
 }
 
+// ChainExecReporter returns a ChainExecReporter associated with t.
+func (r *Reporter) ChainExecReporter(t T) *ChainExecReporter {
+	return &ChainExecReporter{r: r, testName: t.Name()}
+}
+
+// TrackArtifact records that a file of the given kind was written to path to aid debugging the test associated with r.
+// This allows code that only has access to the RelayerExecReporter, such as Interchain.Build,
+// to reference files from the report.
+func (r *RelayerExecReporter) TrackArtifact(kind, description, path string) {
+	r.r.in <- TestArtifactMessage{
+		Name:        r.testName,
+		When:        time.Now(),
+		Kind:        kind,
+		Description: description,
+		Path:        path,
+	}
+}
+
+// ChainExecReporter returns a ChainExecReporter associated with the same test as r.
+func (r *RelayerExecReporter) ChainExecReporter() *ChainExecReporter {
+	return &ChainExecReporter{r: r.r, testName: r.testName}
+}
+
+// ChainExecReporter provides one method that satisfies the ibc.ChainExecReporter interface.
+// Instances of ChainExecReporter must be retrieved through (*Reporter).ChainExecReporter
+// or (*RelayerExecReporter).ChainExecReporter.
+type ChainExecReporter struct {
+	r        *Reporter
+	testName string
+}
+
+// TrackChainExec tracks the execution of an individual command in a chain container.
+func (r *ChainExecReporter) TrackChainExec(
+	containerName string,
+	command, env []string,
+	stdout, stderr string,
+	exitCode int,
+	startedAt, finishedAt time.Time,
+	err error,
+) {
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+	r.r.in <- ChainExecMessage{
+		Name:          r.testName,
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+		ContainerName: containerName,
+		Command:       command,
+		Env:           env,
+		Stdout:        stdout,
+		Stderr:        stderr,
+		ExitCode:      exitCode,
+		Error:         errMsg,
+	}
+}
+
 // TestifyT returns a TestifyReporter which will track logged errors in test.
 // Typically you will use this with the New method on the require or assert package:
 //
@@ -3,6 +3,7 @@ package testreporter_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"testing"
 	"time"
@@ -259,6 +260,113 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_ChainExec(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	execStartedAt := time.Now()
+	execFinishedAt := execStartedAt.Add(time.Second)
+	// Chain commands reported through a RelayerExecReporter's ChainExecReporter belong to the same test.
+	r.RelayerExecReporter(mt).ChainExecReporter().TrackChainExec(
+		"my_container",
+		[]string{"gaiad", "status"},
+		[]string{"HOME=/home"},
+		"stdout", "stderr",
+		2,
+		execStartedAt, execFinishedAt,
+		errors.New("exit code 2"),
+	)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.ChainExecMessage{
+		Name:          "my_test",
+		StartedAt:     execStartedAt,
+		FinishedAt:    execFinishedAt,
+		ContainerName: "my_container",
+		Command:       []string{"gaiad", "status"},
+		Env:           []string{"HOME=/home"},
+		Stdout:        "stdout",
+		Stderr:        "stderr",
+		ExitCode:      2,
+		Error:         "exit code 2",
+	}, msgs[2].(testreporter.ChainExecMessage))
+	require.Empty(t, diff)
+}
+
+func TestReporter_TrackMetric(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	beforeMetric := time.Now()
+	r.TrackMetric(mt, "completion time", 1.5, "s")
+	afterMetric := time.Now()
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	metricMsg := msgs[2].(testreporter.TestMetricMessage)
+	require.Equal(t, "my_test", metricMsg.Name)
+	require.Equal(t, "completion time", metricMsg.Metric)
+	require.Equal(t, 1.5, metricMsg.Value)
+	require.Equal(t, "s", metricMsg.Unit)
+	requireTimeInRange(t, metricMsg.When, beforeMetric, afterMetric)
+}
+
+func TestReporter_TrackArtifact(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	beforeArtifact := time.Now()
+	r.TrackArtifact(mt, "container logs", "gaia-val-0", "/tmp/gaia-val-0.log")
+	r.RelayerExecReporter(mt).TrackArtifact("container logs", "rly", "/tmp/rly.log")
+	afterArtifact := time.Now()
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 6)
+
+	for i, want := range []testreporter.TestArtifactMessage{
+		{Name: "my_test", Kind: "container logs", Description: "gaia-val-0", Path: "/tmp/gaia-val-0.log"},
+		{Name: "my_test", Kind: "container logs", Description: "rly", Path: "/tmp/rly.log"},
+	} {
+		got := msgs[2+i].(testreporter.TestArtifactMessage)
+		requireTimeInRange(t, got.When, beforeArtifact, afterArtifact)
+		got.When = time.Time{}
+		require.Equal(t, want, got)
+	}
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
@@ -0,0 +1,233 @@
+package testreporter
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// testNode is a test in the tree of tests built from a message stream.
+type testNode struct {
+	name, fullName string
+
+	// tracked is set when a BeginTestMessage was seen for this test.
+	// Intermediate subtests that never called TrackTest are not tracked.
+	tracked bool
+
+	begun, finished time.Time
+	failed, skipped bool
+
+	skipMsg string
+	errors  []string
+	execs   []testExec
+	metrics []TestMetricMessage
+
+	artifacts []TestArtifactMessage
+
+	// events are the begin, pause, continue, skip, error and finish messages of the test, in order.
+	events []testEvent
+
+	children []*testNode
+	byName   map[string]*testNode
+}
+
+func buildTestTree(msgs []Message) *testNode {
+	root := &testNode{byName: make(map[string]*testNode)}
+
+	for _, m := range msgs {
+		switch m := m.(type) {
+		case BeginSuiteMessage:
+			root.begun = m.StartedAt
+		case FinishSuiteMessage:
+			root.finished = m.FinishedAt
+		case BeginTestMessage:
+			n := root.node(m.Name)
+			n.tracked = true
+			n.begun = m.StartedAt
+			n.addEvent(m.StartedAt, "begin", "")
+		case FinishTestMessage:
+			n := root.node(m.Name)
+			n.finished = m.FinishedAt
+			n.failed = m.Failed
+			n.skipped = m.Skipped
+			n.addEvent(m.FinishedAt, "finish", "")
+		case PauseTestMessage:
+			root.node(m.Name).addEvent(m.When, "pause", "")
+		case ContinueTestMessage:
+			root.node(m.Name).addEvent(m.When, "continue", "")
+		case TestErrorMessage:
+			n := root.node(m.Name)
+			n.errors = append(n.errors, m.Message)
+			n.addEvent(m.When, "error", m.Message)
+		case TestSkipMessage:
+			n := root.node(m.Name)
+			n.skipMsg = m.Message
+			n.addEvent(m.When, "skip", m.Message)
+		case RelayerExecMessage:
+			n := root.node(m.Name)
+			n.execs = append(n.execs, testExec{
+				Source:        "relayer",
+				StartedAt:     m.StartedAt,
+				FinishedAt:    m.FinishedAt,
+				ContainerName: m.ContainerName,
+				Command:       m.Command,
+				Stdout:        m.Stdout,
+				Stderr:        m.Stderr,
+				ExitCode:      m.ExitCode,
+				Error:         m.Error,
+			})
+		case ChainExecMessage:
+			n := root.node(m.Name)
+			n.execs = append(n.execs, testExec{
+				Source:        "chain",
+				StartedAt:     m.StartedAt,
+				FinishedAt:    m.FinishedAt,
+				ContainerName: m.ContainerName,
+				Command:       m.Command,
+				Env:           m.Env,
+				Stdout:        m.Stdout,
+				Stderr:        m.Stderr,
+				ExitCode:      m.ExitCode,
+				Error:         m.Error,
+			})
+		case TestArtifactMessage:
+			n := root.node(m.Name)
+			n.artifacts = append(n.artifacts, m)
+		case TestMetricMessage:
+			n := root.node(m.Name)
+			n.metrics = append(n.metrics, m)
+		}
+	}
+
+	return root
+}
+
+// testExec is a command executed by a relayer or a chain during a test.
+type testExec struct {
+	Source string // "relayer" or "chain".
+
+	StartedAt, FinishedAt time.Time
+
+	ContainerName string
+	Command, Env  []string
+
+	Stdout, Stderr string
+	ExitCode       int
+	Error          string
+}
+
+// sortedExecs returns the commands executed by the test, in the order they started.
+func (n *testNode) sortedExecs() []testExec {
+	execs := append([]testExec(nil), n.execs...)
+	sort.SliceStable(execs, func(i, j int) bool {
+		return execs[i].StartedAt.Before(execs[j].StartedAt)
+	})
+	return execs
+}
+
+// testEvent is a point in the life of a test.
+type testEvent struct {
+	When   time.Time
+	Kind   string
+	Detail string
+}
+
+func (n *testNode) addEvent(when time.Time, kind, detail string) {
+	n.events = append(n.events, testEvent{When: when, Kind: kind, Detail: detail})
+}
+
+// paused returns the total time the test spent waiting to continue after calling t.Parallel.
+func (n *testNode) paused() time.Duration {
+	var (
+		total    time.Duration
+		pausedAt time.Time
+	)
+	for _, e := range n.events {
+		switch e.Kind {
+		case "pause":
+			pausedAt = e.When
+		case "continue":
+			if !pausedAt.IsZero() {
+				total += e.When.Sub(pausedAt)
+				pausedAt = time.Time{}
+			}
+		}
+	}
+	return total
+}
+
+// node returns the node for the test with the given full name,
+// creating it and any missing ancestors.
+func (n *testNode) node(fullName string) *testNode {
+	cur := n
+	parts := strings.Split(fullName, "/")
+	for i, p := range parts {
+		child, ok := cur.byName[p]
+		if !ok {
+			child = &testNode{
+				name:     p,
+				fullName: strings.Join(parts[:i+1], "/"),
+				byName:   make(map[string]*testNode),
+			}
+			cur.byName[p] = child
+			cur.children = append(cur.children, child)
+		}
+		cur = child
+	}
+	return cur
+}
+
+// span returns the start and end of the test,
+// falling back to the span of its subtests if it was not tracked itself.
+func (n *testNode) span() (begun, finished time.Time) {
+	begun, finished = n.begun, n.finished
+	for _, c := range n.children {
+		cb, cf := c.span()
+		if !cb.IsZero() && (begun.IsZero() || cb.Before(begun)) {
+			begun = cb
+		}
+		if cf.After(finished) {
+			finished = cf
+		}
+	}
+	return begun, finished
+}
+
+func (n *testNode) anyChildFailed() bool {
+	for _, c := range n.children {
+		if c.failed || len(c.errors) > 0 || c.anyChildFailed() {
+			return true
+		}
+	}
+	return false
+}
+
+// status summarizes the outcome of the test as one of "pass", "fail", "skip" or "unfinished".
+// The outcome of a test that was not tracked itself is derived from its subtests.
+func (n *testNode) status() string {
+	switch {
+	case n.failed || len(n.errors) > 0 || n.anyChildFailed():
+		return "fail"
+	case n.tracked && n.finished.IsZero():
+		return "unfinished"
+	case n.skipped:
+		return "skip"
+	case n.tracked:
+		return "pass"
+	}
+
+	allSkipped := len(n.children) > 0
+	for _, c := range n.children {
+		switch c.status() {
+		case "unfinished":
+			return "unfinished"
+		case "skip":
+		default:
+			allSkipped = false
+		}
+	}
+	if allSkipped {
+		return "skip"
+	}
+	return "pass"
+}
@@ -42,6 +42,24 @@ func recursiveModifyToml(c map[string]any, modifications Toml) error {
 	return nil
 }
 
+// ModifyToml applies modifications to the toml encoded config, and returns the encoded result.
+func ModifyToml(config []byte, modifications Toml) ([]byte, error) {
+	var c Toml
+	if err := toml.Unmarshal(config, &c); err != nil {
+		return nil, err
+	}
+
+	if err := recursiveModifyToml(c, modifications); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // ModifyTomlConfigFile reads, modifies, then overwrites a toml config file, useful for config.toml, app.toml, etc.
 func ModifyTomlConfigFile(
 	ctx context.Context,
@@ -58,22 +76,13 @@ func ModifyTomlConfigFile(
 		return fmt.Errorf("failed to retrieve %s: %w", filePath, err)
 	}
 
-	var c Toml
-	if err := toml.Unmarshal(config, &c); err != nil {
-		return fmt.Errorf("failed to unmarshal %s: %w", filePath, err)
-	}
-
-	if err := recursiveModifyToml(c, modifications); err != nil {
-		return err
-	}
-
-	buf := new(bytes.Buffer)
-	if err := toml.NewEncoder(buf).Encode(c); err != nil {
-		return err
+	config, err = ModifyToml(config, modifications)
+	if err != nil {
+		return fmt.Errorf("failed to modify %s: %w", filePath, err)
 	}
 
 	fw := dockerutil.NewFileWriter(logger, dockerClient, testName)
-	if err := fw.WriteFile(ctx, volumeName, filePath, buf.Bytes()); err != nil {
+	if err := fw.WriteFile(ctx, volumeName, filePath, config); err != nil {
 		return fmt.Errorf("overwriting %s: %w", filePath, err)
 	}
 